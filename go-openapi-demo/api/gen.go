@@ -10,10 +10,12 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
@@ -21,45 +23,1489 @@ import (
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
+// Defines values for ItemStatus.
+const (
+	Active   ItemStatus = "active"
+	Archived ItemStatus = "archived"
+	Draft    ItemStatus = "draft"
+)
+
+// Defines values for JSONPatchOperationOp.
+const (
+	Add     JSONPatchOperationOp = "add"
+	Copy    JSONPatchOperationOp = "copy"
+	Move    JSONPatchOperationOp = "move"
+	Remove  JSONPatchOperationOp = "remove"
+	Replace JSONPatchOperationOp = "replace"
+	Test    JSONPatchOperationOp = "test"
+)
+
+// Defines values for ProblemFieldIn.
+const (
+	Body   ProblemFieldIn = "body"
+	Header ProblemFieldIn = "header"
+	Path   ProblemFieldIn = "path"
+	Query  ProblemFieldIn = "query"
+)
+
+// Defines values for GetItemsParamsSort.
+const (
+	Id        GetItemsParamsSort = "id"
+	MinusId   GetItemsParamsSort = "-id"
+	MinusName GetItemsParamsSort = "-name"
+	Name      GetItemsParamsSort = "name"
+)
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
+}
+
 // Item defines model for Item.
 type Item struct {
-	Id   int64  `json:"id"`
-	Name string `json:"name"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	Description ItemDescription `json:"description"`
+	Id          int64           `json:"id"`
+	Name        ItemName        `json:"name"`
+	Price       *ItemPrice      `json:"price,omitempty"`
+	Status      ItemStatus      `json:"status"`
+	Tags        ItemTags        `json:"tags"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	Version     int64           `json:"version"`
+}
+
+// ItemCreate defines model for ItemCreate.
+type ItemCreate struct {
+	Description *ItemDescription `json:"description,omitempty"`
+	Name        ItemName         `json:"name"`
+	Price       *ItemPrice       `json:"price,omitempty"`
+	Status      *ItemStatus      `json:"status,omitempty"`
+	Tags        *ItemTags        `json:"tags,omitempty"`
+}
+
+// ItemDescription defines model for ItemDescription.
+type ItemDescription = string
+
+// ItemMergePatch defines model for ItemMergePatch.
+type ItemMergePatch = map[string]interface{}
+
+// ItemName defines model for ItemName.
+type ItemName = string
+
+// ItemPage defines model for ItemPage.
+type ItemPage struct {
+	Items      []Item  `json:"items"`
+	NextCursor *string `json:"nextCursor,omitempty"`
+	Total      int     `json:"total"`
+}
+
+// ItemPrice defines model for ItemPrice.
+type ItemPrice = string
+
+// ItemStatus defines model for ItemStatus.
+type ItemStatus string
+
+// ItemTags defines model for ItemTags.
+type ItemTags = []string
+
+// ItemUpdate defines model for ItemUpdate.
+type ItemUpdate struct {
+	Description *ItemDescription `json:"description,omitempty"`
+	Name        ItemName         `json:"name"`
+	Price       *ItemPrice       `json:"price,omitempty"`
+	Status      *ItemStatus      `json:"status,omitempty"`
+	Tags        *ItemTags        `json:"tags,omitempty"`
+}
+
+// JSONPatch defines model for JSONPatch.
+type JSONPatch = []JSONPatchOperation
+
+// JSONPatchOperation defines model for JSONPatchOperation.
+type JSONPatchOperation struct {
+	From  *string              `json:"from,omitempty"`
+	Op    JSONPatchOperationOp `json:"op"`
+	Path  string               `json:"path"`
+	Value interface{}          `json:"value,omitempty"`
+}
+
+// JSONPatchOperationOp defines model for JSONPatchOperation.Op.
+type JSONPatchOperationOp string
+
+// Problem defines model for Problem.
+type Problem struct {
+	Detail   *string         `json:"detail,omitempty"`
+	Errors   *[]ProblemField `json:"errors,omitempty"`
+	Instance *string         `json:"instance,omitempty"`
+	Status   int             `json:"status"`
+	Title    string          `json:"title"`
+	Type     string          `json:"type"`
+}
+
+// ProblemField defines model for ProblemField.
+type ProblemField struct {
+	Constraint string         `json:"constraint"`
+	Detail     string         `json:"detail"`
+	In         ProblemFieldIn `json:"in"`
+	Parameter  *string        `json:"parameter,omitempty"`
+	Pointer    string         `json:"pointer"`
+}
+
+// ProblemFieldIn defines model for ProblemField.In.
+type ProblemFieldIn string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// BadRequest defines model for BadRequest.
+type BadRequest = Problem
+
+// Conflict defines model for Conflict.
+type Conflict = Error
+
+// InternalError defines model for InternalError.
+type InternalError = Error
+
+// NotFound defines model for NotFound.
+type NotFound = Error
+
+// PreconditionFailed defines model for PreconditionFailed.
+type PreconditionFailed = Error
+
+// PreconditionRequired defines model for PreconditionRequired.
+type PreconditionRequired = Error
+
+// UnprocessableEntity defines model for UnprocessableEntity.
+type UnprocessableEntity = Error
+
+// GetItemsParams defines parameters for GetItems.
+type GetItemsParams struct {
+	// Limit Max number of items in the page
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor nextCursor of the previous page; only valid with the same sort and q
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort Order of the items; a leading '-' sorts descending. Ties are ordered by id.
+	Sort *GetItemsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Q Keep only items whose name contains this text (case-sensitive)
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// IfNoneMatch ETags the client already has, or *
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetItemsParamsSort defines parameters for GetItems.
+type GetItemsParamsSort string
+
+// DeleteItemParams defines parameters for DeleteItem.
+type DeleteItemParams struct {
+	// IfMatch ETag of the version the change is based on, or *
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetItemByIdParams defines parameters for GetItemById.
+type GetItemByIdParams struct {
+	// IfNoneMatch ETags the client already has, or *
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchItemParams defines parameters for PatchItem.
+type PatchItemParams struct {
+	// IfMatch ETag of the version the change is based on, or *
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateItemParams defines parameters for UpdateItem.
+type UpdateItemParams struct {
+	// IfMatch ETag of the version the change is based on, or *
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// CreateItemJSONRequestBody defines body for CreateItem for application/json ContentType.
+type CreateItemJSONRequestBody = ItemCreate
+
+// PatchItemApplicationJSONPatchPlusJSONRequestBody defines body for PatchItem for application/json-patch+json ContentType.
+type PatchItemApplicationJSONPatchPlusJSONRequestBody = JSONPatch
+
+// PatchItemApplicationMergePatchPlusJSONRequestBody defines body for PatchItem for application/merge-patch+json ContentType.
+type PatchItemApplicationMergePatchPlusJSONRequestBody = ItemMergePatch
+
+// UpdateItemJSONRequestBody defines body for UpdateItem for application/json ContentType.
+type UpdateItemJSONRequestBody = ItemUpdate
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetItems request
+	GetItems(ctx context.Context, params *GetItemsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateItemWithBody request with any body
+	CreateItemWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateItem(ctx context.Context, body CreateItemJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteItem request
+	DeleteItem(ctx context.Context, id int64, params *DeleteItemParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetItemById request
+	GetItemById(ctx context.Context, id int64, params *GetItemByIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchItemWithBody request with any body
+	PatchItemWithBody(ctx context.Context, id int64, params *PatchItemParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchItemWithApplicationJSONPatchPlusJSONBody(ctx context.Context, id int64, params *PatchItemParams, body PatchItemApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchItemWithApplicationMergePatchPlusJSONBody(ctx context.Context, id int64, params *PatchItemParams, body PatchItemApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateItemWithBody request with any body
+	UpdateItemWithBody(ctx context.Context, id int64, params *UpdateItemParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateItem(ctx context.Context, id int64, params *UpdateItemParams, body UpdateItemJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetItems(ctx context.Context, params *GetItemsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetItemsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateItemWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateItemRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateItem(ctx context.Context, body CreateItemJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateItemRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteItem(ctx context.Context, id int64, params *DeleteItemParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteItemRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetItemById(ctx context.Context, id int64, params *GetItemByIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetItemByIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchItemWithBody(ctx context.Context, id int64, params *PatchItemParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchItemRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchItemWithApplicationJSONPatchPlusJSONBody(ctx context.Context, id int64, params *PatchItemParams, body PatchItemApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchItemRequestWithApplicationJSONPatchPlusJSONBody(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchItemWithApplicationMergePatchPlusJSONBody(ctx context.Context, id int64, params *PatchItemParams, body PatchItemApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchItemRequestWithApplicationMergePatchPlusJSONBody(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateItemWithBody(ctx context.Context, id int64, params *UpdateItemParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateItemRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateItem(ctx context.Context, id int64, params *UpdateItemParams, body UpdateItemJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateItemRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetItemsRequest generates requests for GetItems
+func NewGetItemsRequest(server string, params *GetItemsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/items")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewCreateItemRequest calls the generic CreateItem builder with application/json body
+func NewCreateItemRequest(server string, body CreateItemJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateItemRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateItemRequestWithBody generates requests for CreateItem with any type of body
+func NewCreateItemRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/items")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteItemRequest generates requests for DeleteItem
+func NewDeleteItemRequest(server string, id int64, params *DeleteItemParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/items/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetItemByIdRequest generates requests for GetItemById
+func NewGetItemByIdRequest(server string, id int64, params *GetItemByIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/items/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPatchItemRequestWithApplicationJSONPatchPlusJSONBody calls the generic PatchItem builder with application/json-patch+json body
+func NewPatchItemRequestWithApplicationJSONPatchPlusJSONBody(server string, id int64, params *PatchItemParams, body PatchItemApplicationJSONPatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchItemRequestWithBody(server, id, params, "application/json-patch+json", bodyReader)
+}
+
+// NewPatchItemRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchItem builder with application/merge-patch+json body
+func NewPatchItemRequestWithApplicationMergePatchPlusJSONBody(server string, id int64, params *PatchItemParams, body PatchItemApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchItemRequestWithBody(server, id, params, "application/merge-patch+json", bodyReader)
+}
+
+// NewPatchItemRequestWithBody generates requests for PatchItem with any type of body
+func NewPatchItemRequestWithBody(server string, id int64, params *PatchItemParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/items/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewUpdateItemRequest calls the generic UpdateItem builder with application/json body
+func NewUpdateItemRequest(server string, id int64, params *UpdateItemParams, body UpdateItemJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateItemRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewUpdateItemRequestWithBody generates requests for UpdateItem with any type of body
+func NewUpdateItemRequestWithBody(server string, id int64, params *UpdateItemParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/items/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetItemsWithResponse request
+	GetItemsWithResponse(ctx context.Context, params *GetItemsParams, reqEditors ...RequestEditorFn) (*GetItemsResponse, error)
+
+	// CreateItemWithBodyWithResponse request with any body
+	CreateItemWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateItemResponse, error)
+
+	CreateItemWithResponse(ctx context.Context, body CreateItemJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateItemResponse, error)
+
+	// DeleteItemWithResponse request
+	DeleteItemWithResponse(ctx context.Context, id int64, params *DeleteItemParams, reqEditors ...RequestEditorFn) (*DeleteItemResponse, error)
+
+	// GetItemByIdWithResponse request
+	GetItemByIdWithResponse(ctx context.Context, id int64, params *GetItemByIdParams, reqEditors ...RequestEditorFn) (*GetItemByIdResponse, error)
+
+	// PatchItemWithBodyWithResponse request with any body
+	PatchItemWithBodyWithResponse(ctx context.Context, id int64, params *PatchItemParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchItemResponse, error)
+
+	PatchItemWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, id int64, params *PatchItemParams, body PatchItemApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchItemResponse, error)
+
+	PatchItemWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, id int64, params *PatchItemParams, body PatchItemApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchItemResponse, error)
+
+	// UpdateItemWithBodyWithResponse request with any body
+	UpdateItemWithBodyWithResponse(ctx context.Context, id int64, params *UpdateItemParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateItemResponse, error)
+
+	UpdateItemWithResponse(ctx context.Context, id int64, params *UpdateItemParams, body UpdateItemJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateItemResponse, error)
+}
+
+type GetItemsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *ItemPage
+	ApplicationproblemJSON400 *BadRequest
+	JSON500                   *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetItemsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetItemsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateItemResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON201                   *Item
+	ApplicationproblemJSON400 *BadRequest
+	JSON409                   *Conflict
+	JSON500                   *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateItemResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
 }
 
-// ItemCreate defines model for ItemCreate.
-type ItemCreate struct {
-	Name string `json:"name"`
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateItemResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
-// ItemUpdate defines model for ItemUpdate.
-type ItemUpdate struct {
-	Name string `json:"name"`
+type DeleteItemResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *BadRequest
+	JSON404                   *NotFound
+	JSON412                   *PreconditionFailed
+	JSON428                   *PreconditionRequired
+	JSON500                   *InternalError
 }
 
-// CreateItemJSONRequestBody defines body for CreateItem for application/json ContentType.
-type CreateItemJSONRequestBody = ItemCreate
+// Status returns HTTPResponse.Status
+func (r DeleteItemResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-// UpdateItemJSONRequestBody defines body for UpdateItem for application/json ContentType.
-type UpdateItemJSONRequestBody = ItemUpdate
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteItemResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetItemByIdResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Item
+	ApplicationproblemJSON400 *BadRequest
+	JSON404                   *NotFound
+	JSON500                   *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetItemByIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetItemByIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchItemResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Item
+	ApplicationproblemJSON400 *BadRequest
+	JSON404                   *NotFound
+	JSON409                   *Conflict
+	JSON412                   *PreconditionFailed
+	JSON422                   *UnprocessableEntity
+	JSON428                   *PreconditionRequired
+	JSON500                   *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchItemResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchItemResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateItemResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *Item
+	ApplicationproblemJSON400 *BadRequest
+	JSON404                   *NotFound
+	JSON409                   *Conflict
+	JSON412                   *PreconditionFailed
+	JSON428                   *PreconditionRequired
+	JSON500                   *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateItemResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateItemResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetItemsWithResponse request returning *GetItemsResponse
+func (c *ClientWithResponses) GetItemsWithResponse(ctx context.Context, params *GetItemsParams, reqEditors ...RequestEditorFn) (*GetItemsResponse, error) {
+	rsp, err := c.GetItems(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetItemsResponse(rsp)
+}
+
+// CreateItemWithBodyWithResponse request with arbitrary body returning *CreateItemResponse
+func (c *ClientWithResponses) CreateItemWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateItemResponse, error) {
+	rsp, err := c.CreateItemWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateItemResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateItemWithResponse(ctx context.Context, body CreateItemJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateItemResponse, error) {
+	rsp, err := c.CreateItem(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateItemResponse(rsp)
+}
+
+// DeleteItemWithResponse request returning *DeleteItemResponse
+func (c *ClientWithResponses) DeleteItemWithResponse(ctx context.Context, id int64, params *DeleteItemParams, reqEditors ...RequestEditorFn) (*DeleteItemResponse, error) {
+	rsp, err := c.DeleteItem(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteItemResponse(rsp)
+}
+
+// GetItemByIdWithResponse request returning *GetItemByIdResponse
+func (c *ClientWithResponses) GetItemByIdWithResponse(ctx context.Context, id int64, params *GetItemByIdParams, reqEditors ...RequestEditorFn) (*GetItemByIdResponse, error) {
+	rsp, err := c.GetItemById(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetItemByIdResponse(rsp)
+}
+
+// PatchItemWithBodyWithResponse request with arbitrary body returning *PatchItemResponse
+func (c *ClientWithResponses) PatchItemWithBodyWithResponse(ctx context.Context, id int64, params *PatchItemParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchItemResponse, error) {
+	rsp, err := c.PatchItemWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchItemResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchItemWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, id int64, params *PatchItemParams, body PatchItemApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchItemResponse, error) {
+	rsp, err := c.PatchItemWithApplicationJSONPatchPlusJSONBody(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchItemResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchItemWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, id int64, params *PatchItemParams, body PatchItemApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchItemResponse, error) {
+	rsp, err := c.PatchItemWithApplicationMergePatchPlusJSONBody(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchItemResponse(rsp)
+}
+
+// UpdateItemWithBodyWithResponse request with arbitrary body returning *UpdateItemResponse
+func (c *ClientWithResponses) UpdateItemWithBodyWithResponse(ctx context.Context, id int64, params *UpdateItemParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateItemResponse, error) {
+	rsp, err := c.UpdateItemWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateItemResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateItemWithResponse(ctx context.Context, id int64, params *UpdateItemParams, body UpdateItemJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateItemResponse, error) {
+	rsp, err := c.UpdateItem(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateItemResponse(rsp)
+}
+
+// ParseGetItemsResponse parses an HTTP response from a GetItemsWithResponse call
+func ParseGetItemsResponse(rsp *http.Response) (*GetItemsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetItemsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ItemPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateItemResponse parses an HTTP response from a CreateItemWithResponse call
+func ParseCreateItemResponse(rsp *http.Response) (*CreateItemResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateItemResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Item
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteItemResponse parses an HTTP response from a DeleteItemWithResponse call
+func ParseDeleteItemResponse(rsp *http.Response) (*DeleteItemResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteItemResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest PreconditionFailed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 428:
+		var dest PreconditionRequired
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON428 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetItemByIdResponse parses an HTTP response from a GetItemByIdWithResponse call
+func ParseGetItemByIdResponse(rsp *http.Response) (*GetItemByIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetItemByIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Item
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchItemResponse parses an HTTP response from a PatchItemWithResponse call
+func ParsePatchItemResponse(rsp *http.Response) (*PatchItemResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchItemResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Item
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest PreconditionFailed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest UnprocessableEntity
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 428:
+		var dest PreconditionRequired
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON428 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateItemResponse parses an HTTP response from a UpdateItemWithResponse call
+func ParseUpdateItemResponse(rsp *http.Response) (*UpdateItemResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateItemResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Item
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Conflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 412:
+		var dest PreconditionFailed
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON412 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 428:
+		var dest PreconditionRequired
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON428 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
 	// (GET /items)
-	GetItems(w http.ResponseWriter, r *http.Request)
+	GetItems(w http.ResponseWriter, r *http.Request, params GetItemsParams)
 
 	// (POST /items)
 	CreateItem(w http.ResponseWriter, r *http.Request)
 
 	// (DELETE /items/{id})
-	DeleteItem(w http.ResponseWriter, r *http.Request, id int64)
+	DeleteItem(w http.ResponseWriter, r *http.Request, id int64, params DeleteItemParams)
 
 	// (GET /items/{id})
-	GetItemById(w http.ResponseWriter, r *http.Request, id int64)
+	GetItemById(w http.ResponseWriter, r *http.Request, id int64, params GetItemByIdParams)
+
+	// (PATCH /items/{id})
+	PatchItem(w http.ResponseWriter, r *http.Request, id int64, params PatchItemParams)
 
 	// (PUT /items/{id})
-	UpdateItem(w http.ResponseWriter, r *http.Request, id int64)
+	UpdateItem(w http.ResponseWriter, r *http.Request, id int64, params UpdateItemParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -67,7 +1513,7 @@ type ServerInterface interface {
 type Unimplemented struct{}
 
 // (GET /items)
-func (_ Unimplemented) GetItems(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) GetItems(w http.ResponseWriter, r *http.Request, params GetItemsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -77,17 +1523,22 @@ func (_ Unimplemented) CreateItem(w http.ResponseWriter, r *http.Request) {
 }
 
 // (DELETE /items/{id})
-func (_ Unimplemented) DeleteItem(w http.ResponseWriter, r *http.Request, id int64) {
+func (_ Unimplemented) DeleteItem(w http.ResponseWriter, r *http.Request, id int64, params DeleteItemParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // (GET /items/{id})
-func (_ Unimplemented) GetItemById(w http.ResponseWriter, r *http.Request, id int64) {
+func (_ Unimplemented) GetItemById(w http.ResponseWriter, r *http.Request, id int64, params GetItemByIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PATCH /items/{id})
+func (_ Unimplemented) PatchItem(w http.ResponseWriter, r *http.Request, id int64, params PatchItemParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
 // (PUT /items/{id})
-func (_ Unimplemented) UpdateItem(w http.ResponseWriter, r *http.Request, id int64) {
+func (_ Unimplemented) UpdateItem(w http.ResponseWriter, r *http.Request, id int64, params UpdateItemParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -103,8 +1554,66 @@ type MiddlewareFunc func(http.Handler) http.Handler
 // GetItems operation middleware
 func (siw *ServerInterfaceWrapper) GetItems(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetItemsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetItems(w, r)
+		siw.Handler.GetItems(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -142,8 +1651,81 @@ func (siw *ServerInterfaceWrapper) DeleteItem(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteItemParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteItem(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetItemById operation middleware
+func (siw *ServerInterfaceWrapper) GetItemById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetItemByIdParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteItem(w, r, id)
+		siw.Handler.GetItemById(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -153,8 +1735,8 @@ func (siw *ServerInterfaceWrapper) DeleteItem(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetItemById operation middleware
-func (siw *ServerInterfaceWrapper) GetItemById(w http.ResponseWriter, r *http.Request) {
+// PatchItem operation middleware
+func (siw *ServerInterfaceWrapper) PatchItem(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -167,8 +1749,32 @@ func (siw *ServerInterfaceWrapper) GetItemById(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchItemParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetItemById(w, r, id)
+		siw.Handler.PatchItem(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -192,8 +1798,32 @@ func (siw *ServerInterfaceWrapper) UpdateItem(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateItemParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateItem(w, r, id)
+		siw.Handler.UpdateItem(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -328,6 +1958,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/items/{id}", wrapper.GetItemById)
 	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/items/{id}", wrapper.PatchItem)
+	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/items/{id}", wrapper.UpdateItem)
 	})
@@ -335,19 +1968,77 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	return r
 }
 
+type BadRequestApplicationProblemPlusJSONResponse Problem
+
+type ConflictJSONResponse Error
+
+type InternalErrorJSONResponse Error
+
+type NotFoundJSONResponse Error
+
+type NotModifiedResponseHeaders struct {
+	ETag string
+}
+type NotModifiedResponse struct {
+	Headers NotModifiedResponseHeaders
+}
+
+type PreconditionFailedJSONResponse Error
+
+type PreconditionRequiredJSONResponse Error
+
+type UnprocessableEntityJSONResponse Error
+
 type GetItemsRequestObject struct {
+	Params GetItemsParams
 }
 
 type GetItemsResponseObject interface {
 	VisitGetItemsResponse(w http.ResponseWriter) error
 }
 
-type GetItems200JSONResponse []Item
+type GetItems200ResponseHeaders struct {
+	ETag string
+}
+
+type GetItems200JSONResponse struct {
+	Body    ItemPage
+	Headers GetItems200ResponseHeaders
+}
 
 func (response GetItems200JSONResponse) VisitGetItemsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetItems304Response = NotModifiedResponse
+
+func (response GetItems304Response) VisitGetItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetItems400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetItems400ApplicationProblemPlusJSONResponse) VisitGetItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetItems500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetItems500JSONResponse) VisitGetItemsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
 	return json.NewEncoder(w).Encode(response)
 }
 
@@ -359,17 +2050,55 @@ type CreateItemResponseObject interface {
 	VisitCreateItemResponse(w http.ResponseWriter) error
 }
 
-type CreateItem201JSONResponse Item
+type CreateItem201ResponseHeaders struct {
+	ETag string
+}
+
+type CreateItem201JSONResponse struct {
+	Body    Item
+	Headers CreateItem201ResponseHeaders
+}
 
 func (response CreateItem201JSONResponse) VisitCreateItemResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(201)
 
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateItem400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response CreateItem400ApplicationProblemPlusJSONResponse) VisitCreateItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateItem409JSONResponse struct{ ConflictJSONResponse }
+
+func (response CreateItem409JSONResponse) VisitCreateItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateItem500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response CreateItem500JSONResponse) VisitCreateItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
 	return json.NewEncoder(w).Encode(response)
 }
 
 type DeleteItemRequestObject struct {
-	Id int64 `json:"id"`
+	Id     int64 `json:"id"`
+	Params DeleteItemParams
 }
 
 type DeleteItemResponseObject interface {
@@ -384,55 +2113,298 @@ func (response DeleteItem204Response) VisitDeleteItemResponse(w http.ResponseWri
 	return nil
 }
 
+type DeleteItem400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response DeleteItem400ApplicationProblemPlusJSONResponse) VisitDeleteItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteItem404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteItem404JSONResponse) VisitDeleteItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteItem412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response DeleteItem412JSONResponse) VisitDeleteItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteItem428JSONResponse struct {
+	PreconditionRequiredJSONResponse
+}
+
+func (response DeleteItem428JSONResponse) VisitDeleteItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(428)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteItem500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DeleteItem500JSONResponse) VisitDeleteItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetItemByIdRequestObject struct {
-	Id int64 `json:"id"`
+	Id     int64 `json:"id"`
+	Params GetItemByIdParams
 }
 
 type GetItemByIdResponseObject interface {
 	VisitGetItemByIdResponse(w http.ResponseWriter) error
 }
 
-type GetItemById200JSONResponse Item
+type GetItemById200ResponseHeaders struct {
+	ETag string
+}
+
+type GetItemById200JSONResponse struct {
+	Body    Item
+	Headers GetItemById200ResponseHeaders
+}
 
 func (response GetItemById200JSONResponse) VisitGetItemByIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetItemById304Response = NotModifiedResponse
+
+func (response GetItemById304Response) VisitGetItemByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetItemById400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response GetItemById400ApplicationProblemPlusJSONResponse) VisitGetItemByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetItemById404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetItemById404JSONResponse) VisitGetItemByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetItemById500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetItemById500JSONResponse) VisitGetItemByIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetItemById404Response struct {
+type PatchItemRequestObject struct {
+	Id                                int64 `json:"id"`
+	Params                            PatchItemParams
+	ApplicationJSONPatchPlusJSONBody  *PatchItemApplicationJSONPatchPlusJSONRequestBody
+	ApplicationMergePatchPlusJSONBody *PatchItemApplicationMergePatchPlusJSONRequestBody
+}
+
+type PatchItemResponseObject interface {
+	VisitPatchItemResponse(w http.ResponseWriter) error
+}
+
+type PatchItem200ResponseHeaders struct {
+	ETag string
+}
+
+type PatchItem200JSONResponse struct {
+	Body    Item
+	Headers PatchItem200ResponseHeaders
+}
+
+func (response PatchItem200JSONResponse) VisitPatchItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchItem400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
+}
+
+func (response PatchItem400ApplicationProblemPlusJSONResponse) VisitPatchItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response GetItemById404Response) VisitGetItemByIdResponse(w http.ResponseWriter) error {
+type PatchItem404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response PatchItem404JSONResponse) VisitPatchItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
-	return nil
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchItem409JSONResponse struct{ ConflictJSONResponse }
+
+func (response PatchItem409JSONResponse) VisitPatchItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchItem412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response PatchItem412JSONResponse) VisitPatchItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchItem422JSONResponse struct {
+	UnprocessableEntityJSONResponse
+}
+
+func (response PatchItem422JSONResponse) VisitPatchItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchItem428JSONResponse struct {
+	PreconditionRequiredJSONResponse
+}
+
+func (response PatchItem428JSONResponse) VisitPatchItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(428)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchItem500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response PatchItem500JSONResponse) VisitPatchItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
 type UpdateItemRequestObject struct {
-	Id   int64 `json:"id"`
-	Body *UpdateItemJSONRequestBody
+	Id     int64 `json:"id"`
+	Params UpdateItemParams
+	Body   *UpdateItemJSONRequestBody
 }
 
 type UpdateItemResponseObject interface {
 	VisitUpdateItemResponse(w http.ResponseWriter) error
 }
 
-type UpdateItem200JSONResponse Item
+type UpdateItem200ResponseHeaders struct {
+	ETag string
+}
+
+type UpdateItem200JSONResponse struct {
+	Body    Item
+	Headers UpdateItem200ResponseHeaders
+}
 
 func (response UpdateItem200JSONResponse) VisitUpdateItemResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateItem400ApplicationProblemPlusJSONResponse struct {
+	BadRequestApplicationProblemPlusJSONResponse
 }
 
-type UpdateItem404Response struct {
+func (response UpdateItem400ApplicationProblemPlusJSONResponse) VisitUpdateItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response UpdateItem404Response) VisitUpdateItemResponse(w http.ResponseWriter) error {
+type UpdateItem404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateItem404JSONResponse) VisitUpdateItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
-	return nil
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateItem409JSONResponse struct{ ConflictJSONResponse }
+
+func (response UpdateItem409JSONResponse) VisitUpdateItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateItem412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response UpdateItem412JSONResponse) VisitUpdateItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateItem428JSONResponse struct {
+	PreconditionRequiredJSONResponse
+}
+
+func (response UpdateItem428JSONResponse) VisitUpdateItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(428)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateItem500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response UpdateItem500JSONResponse) VisitUpdateItemResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
 // StrictServerInterface represents all server handlers.
@@ -450,6 +2422,9 @@ type StrictServerInterface interface {
 	// (GET /items/{id})
 	GetItemById(ctx context.Context, request GetItemByIdRequestObject) (GetItemByIdResponseObject, error)
 
+	// (PATCH /items/{id})
+	PatchItem(ctx context.Context, request PatchItemRequestObject) (PatchItemResponseObject, error)
+
 	// (PUT /items/{id})
 	UpdateItem(ctx context.Context, request UpdateItemRequestObject) (UpdateItemResponseObject, error)
 }
@@ -484,9 +2459,11 @@ type strictHandler struct {
 }
 
 // GetItems operation middleware
-func (sh *strictHandler) GetItems(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) GetItems(w http.ResponseWriter, r *http.Request, params GetItemsParams) {
 	var request GetItemsRequestObject
 
+	request.Params = params
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetItems(ctx, request.(GetItemsRequestObject))
 	}
@@ -539,10 +2516,11 @@ func (sh *strictHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
 }
 
 // DeleteItem operation middleware
-func (sh *strictHandler) DeleteItem(w http.ResponseWriter, r *http.Request, id int64) {
+func (sh *strictHandler) DeleteItem(w http.ResponseWriter, r *http.Request, id int64, params DeleteItemParams) {
 	var request DeleteItemRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteItem(ctx, request.(DeleteItemRequestObject))
@@ -565,10 +2543,11 @@ func (sh *strictHandler) DeleteItem(w http.ResponseWriter, r *http.Request, id i
 }
 
 // GetItemById operation middleware
-func (sh *strictHandler) GetItemById(w http.ResponseWriter, r *http.Request, id int64) {
+func (sh *strictHandler) GetItemById(w http.ResponseWriter, r *http.Request, id int64, params GetItemByIdParams) {
 	var request GetItemByIdRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetItemById(ctx, request.(GetItemByIdRequestObject))
@@ -590,11 +2569,57 @@ func (sh *strictHandler) GetItemById(w http.ResponseWriter, r *http.Request, id
 	}
 }
 
+// PatchItem operation middleware
+func (sh *strictHandler) PatchItem(w http.ResponseWriter, r *http.Request, id int64, params PatchItemParams) {
+	var request PatchItemRequestObject
+
+	request.Id = id
+	request.Params = params
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json-patch+json") {
+
+		var body PatchItemApplicationJSONPatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationJSONPatchPlusJSONBody = &body
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/merge-patch+json") {
+
+		var body PatchItemApplicationMergePatchPlusJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+			return
+		}
+		request.ApplicationMergePatchPlusJSONBody = &body
+	}
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchItem(ctx, request.(PatchItemRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchItem")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchItemResponseObject); ok {
+		if err := validResponse.VisitPatchItemResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // UpdateItem operation middleware
-func (sh *strictHandler) UpdateItem(w http.ResponseWriter, r *http.Request, id int64) {
+func (sh *strictHandler) UpdateItem(w http.ResponseWriter, r *http.Request, id int64, params UpdateItemParams) {
 	var request UpdateItemRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	var body UpdateItemJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -626,14 +2651,35 @@ func (sh *strictHandler) UpdateItem(w http.ResponseWriter, r *http.Request, id i
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xUwW4TMRD9lWjgaGW3NOLgGy0CrZAACXGqejDrSeoqa5vxBCmK/O9obCc0atQWKBKX",
-	"2LHHb97Mezs7GMMUg0fPCfQO0niDkynbgXGSNVKISOywnDorv8tAk2HQ4Dy/XoAC3kasf3GFBFmBNxNK",
-	"aLtJTM6vIGcFhN83jtCCvhK4Fnp9AAnfbnFkwRAKl4SG8T6Rp+E/CP012ueHljDnl6EAOF7L3RczxTXO",
-	"3nweQMEPpOSCBw1n837eC5kQ0ZvoQMP5vJ+fg4Jo+KZw6RzjVHYrZFmEqGEX/GBBw3vkoQQItxSDT7WE",
-	"V30vyxg8oy/vTIxrN5aX3W2S/Hu1i6z7LC8Jl6DhRffLF10zRVcckQ8VGyKzrQVbTCO5yLWuTx/qaQzp",
-	"BOUqaMGqDcXEF8Fuf4vvYzSba/KxaEwbzPc6dfasmU81pLKxcpVVk7TbOZsF0OIaqwuP+/S2nB/6dER5",
-	"UR/ezfExzC5bDSXLQ3a52A72bx3zJ30QYyhYnKbPs3dh422zjiEzISMl0Fc7cBIi38R+Wug6OY6lVXfo",
-	"PTqh8rWCuDnRozoV/rE/2+h5kj//H11yzj8DAAD//1QZTW8xBgAA",
+	"H4sIAAAAAAAC/+xZW2/buBL+KwRPgSatZMtOTk/rPhw0abvwdpMYdfsUZwFGHNssJFIhqTTerP77gqQk",
+	"y7J8y227iz5Fimb4fZwbh+NbHIo4ERy4Vrh3i6dAKEj7+OELmZi/FFQoWaKZ4LiHh1oKPkHANdMzpMkE",
+	"iTHSU0ASVCK4Ag9Ba9JCI3wwwmgsJLoGqZjg6MBIEo6Yhhh7WMJVyiRQ3NMyBQ+rcAoxMYB6lgDuYaUl",
+	"4xOcZZmHEyJJDDpn1h+fEB1Ol8kZygWfAtY8h1PCJ4CYQpdEAUWCe0hI9AJ7mBk9t2vsYU5iA90f+w5h",
+	"HS0P98engsMaLsqhRwy4RiSSQOgMTYnaiG7W3YJC5uHC7NYwR4R+hqsUlDZvoeAauH0kSRKxkBhq7USK",
+	"ywjil9+U4XlbWf6ZhDHu4f+05zHRdl9Ve+C0HOjiTo8IRQVs5uFjwccRC9dR2A36g5RCNgGXSMYXXIPk",
+	"JHLCjw5dwKEhyGuQKBf08KnQH0XK6eNTOBUaOSgHeyIoGzOgy6FoJMuvXlOONxHIxdpWxuIPJISCU2aW",
+	"/UhYBE+wyyomykFrVD6XleRJyZSwmYe/8kSKEJQilxF8sLXx8dksgKIc1YjlmtbBRTokUiQgNXOFIjZa",
+	"E2gua/PKfF4KXniFoLj8BnnKaYjt1qizCIkGFZAxiRR4NdxQAtFA31mTjIWMicY9TIkGX7MYsFenU9vz",
+	"eksZQu8r4pmHGV1AYly/OpyjMK5hAjZvXe3dvP6pkTMnkmThVgoDK2i8oolO1TYqQyeZeViTyVYa5qwx",
+	"8mlCd7Vvfk5uZaZacDBaHFqLbsp5l1ueg3iVCKiyXRVex1Z6xyC7X8T8GwKh5ie7pVUmfr9orZjc/AZ8",
+	"oqe41wmCoCFgjNIJyAkMisancd3T3I6LC3o4Zrx8N42d1iA57uHfR6PhXuvFaDTc//8zvAJ3kBetRYeb",
+	"jnLxYZOZcFYCECnJzLxzuNHHqVSuXi7Ba6FJVPmyMiksh0J+ldUHRdRUDLAX/Hne8d9cnAf+m4vbwOt0",
+	"sv290ajl3jteN1tjmWEZVMDT2BChkow19jAJNbsG8yDDKbsGii9WrPElD7LSjBXfHXQXnHVO/D8MrZd7",
+	"fvm4/6KRXUxu+m7BTlCzuodTzq5SyL+bi0BO5astDT8T/5ES/9fh2WmZvltlTalxloAkhc3qOdQgtZSt",
+	"YynixgwTSTV8CaX2lhgLG7wSkoiE5in/RygSE0AalG4M6IToaSPMNYlS47e6sUSCc60mixX3n6XtUNCE",
+	"RY1IIKWQ2xemHOIjg4g2GZdxpQkPoRFrHlvLvY1mOmrWcv/Y1ATar8UyJdQaK7ktLJkqFFxpSZjriBs6",
+	"vZWWZLwaGpeCzgpfefgqBTkrrzUrYiEfHzQungjGm7/VSzvHc2mvup2S/LJRMkt/LOzyzhF4SOIkAvRu",
+	"0K/0Rj3caQWtAHv4xqeQSAhNc+TbhqwbdF/5ncDvvLafVcoV5P//nx8c+geBSyDgJGG4hw9aQesgN5E1",
+	"fbuMwAloF7YFQjGAEUXK9inu4V9A9/OTrDp8Oa/fLU/IDeJpfAnSDF4sCmJu6pKYw9r5rnRSPuKIWMz0",
+	"wmiDwpikkca9bmCPDBan8bxjyN+aOtI6ofkxXkyCEgnXTKTKEnqLBI9m6JpEjKLvTE+tiCIxICWkRoRT",
+	"dLWCdWiXXT8UqtM5kxRKJtY8bxFBERDK+AQ9959bWIWMFnDzzxb6wkAhIgEJowwUXc4Qo60VrIx+syld",
+	"g15kjX3xqz27XzsfVu/iE0DiDOc8/H0qFCCjjkLBNWHcjLqYQhpuNNoLiQJfAVfMtB77K4hfbbJkU7Gc",
+	"B2O7On7LLmqjsG4QPNgNvOw8Gy7hZ5/uM1E5CA5XCZe7aVdnO5mHD4Ngs05lEph5+L/bqCxO0Cy9RKit",
+	"ioW7pvXng11Q+sgU6Yf0gAPB2WJVznvGmu87D4rcOHt099j7OP8OjjwM3mxWqY5F7+b5zMsPjPYto5mL",
+	"gAg0bBML761kHgu1o2NjQq9M5sOmqSY6zj18V2Nul3zlkPWw092s0DAkNard17upVqeLd03f3Y76o1mf",
+	"3sFlT1iD/7H1d+dIu3PBXnSePXXzNjk/dBld+9vb5hHghUEpf/FaH1n2KvgApWDbA8W3zHb8ZWt+FzYW",
+	"rC4ZmynXndaszci2OrN+9Fx5kgq76/l2r5K8hWrTDzt/VzlP0q3KuRudPWHW7RaYjt7PlPgxU+LJwzrL",
+	"sr8GAMSq8XKEIgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,216 @@
+// Package client calls the items API. It wraps the client generated from
+// openapi.yaml into package api with what every caller needs: retries of
+// idempotent requests, credentials, request IDs joining the logs of caller
+// and API, and errors that errors.Is tells apart:
+//
+//	c, err := client.New("https://items.example.com", client.Options{Auth: client.BearerToken(token)})
+//	...
+//	it, err := c.Get(ctx, 7)
+//	if errors.Is(err, client.ErrNotFound) {
+//		...
+//	}
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-openapi-demo/api"
+)
+
+// RequestIDHeader carries the ID of the request a call is made for.
+const RequestIDHeader = "X-Request-Id"
+
+const (
+	// DefaultRetries is the number of retries when Options.Retries is 0.
+	DefaultRetries = 3
+	// DefaultBackoff is the wait before the first retry when Options.Backoff is 0.
+	DefaultBackoff = 100 * time.Millisecond
+	// DefaultMaxBackoff is the longest wait before a retry when Options.MaxBackoff is 0.
+	DefaultMaxBackoff = 10 * time.Second
+)
+
+// Options configure a Client; the zero value is ready to use.
+type Options struct {
+	// HTTPClient sends the requests, http.DefaultClient if nil. Pass one with
+	// a TLS config to call a server that requires client certificates.
+	HTTPClient api.HttpRequestDoer
+
+	// Auth adds credentials to every request, e.g. BearerToken; none if nil.
+	Auth api.RequestEditorFn
+
+	// Retries is how often a GET, PUT or DELETE is sent again after a network
+	// error or a 429, 502, 503 or 504: DefaultRetries if 0, never if negative.
+	// POST and PATCH are never sent again.
+	Retries int
+
+	// Backoff is the wait before the first retry, doubled before each further
+	// one. A Retry-After header in seconds takes precedence.
+	Backoff time.Duration
+
+	// MaxBackoff caps every wait before a retry, including one asked for by
+	// Retry-After, so that a server cannot hold a call for hours:
+	// DefaultMaxBackoff if 0.
+	MaxBackoff time.Duration
+}
+
+// Client calls the items API. It is safe for concurrent use.
+type Client struct {
+	api *api.ClientWithResponses
+}
+
+// New returns a client of the API at the server URL, e.g. https://localhost:8443.
+func New(server string, opts Options) (*Client, error) {
+	doer := opts.HTTPClient
+	if doer == nil {
+		doer = http.DefaultClient
+	}
+	r := &retrier{doer: doer, retries: opts.Retries, backoff: opts.Backoff, maxBackoff: opts.MaxBackoff}
+	if r.retries == 0 {
+		r.retries = DefaultRetries
+	}
+	if r.backoff == 0 {
+		r.backoff = DefaultBackoff
+	}
+	if r.maxBackoff == 0 {
+		r.maxBackoff = DefaultMaxBackoff
+	}
+
+	clientOpts := []api.ClientOption{api.WithHTTPClient(r), api.WithRequestEditorFn(setRequestID)}
+	if opts.Auth != nil {
+		clientOpts = append(clientOpts, api.WithRequestEditorFn(opts.Auth))
+	}
+	c, err := api.NewClientWithResponses(server, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{api: c}, nil
+}
+
+// API returns the generated client c calls, for requests c has no method
+// for, e.g. JSON patches. They are retried and carry credentials and request
+// IDs like those of c, but their errors are not converted into *Error.
+func (c *Client) API() *api.ClientWithResponses { return c.api }
+
+// List returns one page of the items; params may be nil. The next page is
+// listed with params.Cursor set to the NextCursor of the page.
+func (c *Client) List(ctx context.Context, params *api.GetItemsParams) (api.ItemPage, error) {
+	rsp, err := c.api.GetItemsWithResponse(ctx, params)
+	if err != nil {
+		return api.ItemPage{}, err
+	}
+	if rsp.JSON200 == nil {
+		return api.ItemPage{}, responseError(rsp.HTTPResponse, rsp.Body)
+	}
+	return *rsp.JSON200, nil
+}
+
+// Get returns the item with the ID, or an error matching ErrNotFound.
+func (c *Client) Get(ctx context.Context, id int64) (api.Item, error) {
+	rsp, err := c.api.GetItemByIdWithResponse(ctx, id, nil)
+	if err != nil {
+		return api.Item{}, err
+	}
+	if rsp.JSON200 == nil {
+		return api.Item{}, responseError(rsp.HTTPResponse, rsp.Body)
+	}
+	return *rsp.JSON200, nil
+}
+
+// Create creates an item, or returns an error matching ErrDuplicateName.
+func (c *Client) Create(ctx context.Context, it api.ItemCreate) (api.Item, error) {
+	rsp, err := c.api.CreateItemWithResponse(ctx, it)
+	if err != nil {
+		return api.Item{}, err
+	}
+	if rsp.JSON201 == nil {
+		return api.Item{}, responseError(rsp.HTTPResponse, rsp.Body)
+	}
+	return *rsp.JSON201, nil
+}
+
+// Update replaces the fields of the item with the ID. Unless version is 0,
+// the item must still have that version, or the error matches
+// ErrVersionMismatch. A retried Update may find the version it made itself.
+func (c *Client) Update(ctx context.Context, id int64, it api.ItemUpdate, version int64) (api.Item, error) {
+	rsp, err := c.api.UpdateItemWithResponse(ctx, id, &api.UpdateItemParams{IfMatch: ifMatch(version)}, it)
+	if err != nil {
+		return api.Item{}, err
+	}
+	if rsp.JSON200 == nil {
+		return api.Item{}, responseError(rsp.HTTPResponse, rsp.Body)
+	}
+	return *rsp.JSON200, nil
+}
+
+// Patch applies a merge patch (RFC 7396) to the item with the ID, e.g.
+// {"price": "9.99", "description": nil}. Version works as for Update.
+func (c *Client) Patch(ctx context.Context, id int64, patch api.ItemMergePatch, version int64) (api.Item, error) {
+	rsp, err := c.api.PatchItemWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, id, &api.PatchItemParams{IfMatch: ifMatch(version)}, patch)
+	if err != nil {
+		return api.Item{}, err
+	}
+	if rsp.JSON200 == nil {
+		return api.Item{}, responseError(rsp.HTTPResponse, rsp.Body)
+	}
+	return *rsp.JSON200, nil
+}
+
+// Delete deletes the item with the ID. Version works as for Update.
+// A DELETE sent again after its first attempt succeeded unnoticed (see
+// Options.Retries) returns an error matching ErrNotFound.
+func (c *Client) Delete(ctx context.Context, id int64, version int64) error {
+	rsp, err := c.api.DeleteItemWithResponse(ctx, id, &api.DeleteItemParams{IfMatch: ifMatch(version)})
+	if err != nil {
+		return err
+	}
+	if rsp.StatusCode() != http.StatusNoContent {
+		return responseError(rsp.HTTPResponse, rsp.Body)
+	}
+	return nil
+}
+
+// ifMatch is the If-Match header of a change to the version, or nil for 0.
+func ifMatch(version int64) *string {
+	if version == 0 {
+		return nil
+	}
+	etag := strconv.Quote(strconv.FormatInt(version, 10))
+	return &etag
+}
+
+// BearerToken authenticates every request with the token.
+func BearerToken(token string) api.RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx whose calls send the request ID, e.g.
+// the ID of the request being served, in RequestIDHeader.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID set by WithRequestID, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// setRequestID sends the request ID of ctx, or a new random one. It runs once
+// per call, so the retries of a call share its ID.
+func setRequestID(ctx context.Context, req *http.Request) error {
+	id := RequestID(ctx)
+	if id == "" {
+		id = rand.Text()
+	}
+	req.Header.Set(RequestIDHeader, id)
+	return nil
+}
@@ -0,0 +1,262 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go-openapi-demo/api"
+	"go-openapi-demo/client"
+	"go-openapi-demo/internal/handlers"
+	"go-openapi-demo/internal/store"
+)
+
+// newAPI serves the items API of an empty memory store the way cmd/server
+// does, behind the middleware wrap, and returns its URL.
+func newAPI(t *testing.T, wrap func(http.Handler) http.Handler) string {
+	t.Helper()
+	h, err := handlers.NewRouter(handlers.V1("", handlers.NewItemsService(store.NewMemory())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wrap != nil {
+		h = wrap(h)
+	}
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func newClient(t *testing.T, server string, opts client.Options) *client.Client {
+	t.Helper()
+	c, err := client.New(server, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newAPI(t, nil), client.Options{})
+
+	lamp, err := c.Create(ctx, api.ItemCreate{Name: "lamp", Tags: ptr(api.ItemTags{"light"}), Price: ptr("12.50")})
+	if err != nil || lamp.Id == 0 || lamp.Version != 1 || lamp.Status != api.Active {
+		t.Fatalf("Create = %+v, %v", lamp, err)
+	}
+	if got, err := c.Get(ctx, lamp.Id); err != nil || !reflect.DeepEqual(got, lamp) {
+		t.Fatalf("Get = %+v, %v; want %+v", got, err, lamp)
+	}
+	if page, err := c.List(ctx, &api.GetItemsParams{Q: ptr("am")}); err != nil || page.Total != 1 || page.Items[0].Name != "lamp" {
+		t.Fatalf("List = %+v, %v", page, err)
+	}
+
+	updated, err := c.Update(ctx, lamp.Id, api.ItemUpdate{Name: "desk lamp"}, lamp.Version)
+	if err != nil || updated.Name != "desk lamp" || updated.Version != 2 {
+		t.Fatalf("Update = %+v, %v", updated, err)
+	}
+	_, err = c.Update(ctx, lamp.Id, api.ItemUpdate{Name: "floor lamp"}, lamp.Version)
+	var e *client.Error
+	if !errors.Is(err, client.ErrVersionMismatch) || !errors.As(err, &e) || e.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("Update of a stale version = %v, want ErrVersionMismatch", err)
+	}
+	patched, err := c.Patch(ctx, lamp.Id, api.ItemMergePatch{"price": nil, "status": "archived"}, 0)
+	if err != nil || patched.Price != nil || patched.Status != api.Archived || patched.Version != 3 {
+		t.Fatalf("Patch = %+v, %v", patched, err)
+	}
+
+	if _, err := c.Create(ctx, api.ItemCreate{Name: "desk lamp"}); !errors.Is(err, client.ErrDuplicateName) {
+		t.Fatalf("Create of a taken name = %v, want ErrDuplicateName", err)
+	}
+	if err := c.Delete(ctx, lamp.Id, 2); !errors.Is(err, client.ErrVersionMismatch) {
+		t.Fatalf("Delete of a stale version = %v, want ErrVersionMismatch", err)
+	}
+	if err := c.Delete(ctx, lamp.Id, 3); err != nil {
+		t.Fatalf("Delete = %v", err)
+	}
+	if _, err := c.Get(ctx, lamp.Id); !errors.Is(err, client.ErrNotFound) || errors.Is(err, client.ErrVersionMismatch) {
+		t.Fatalf("Get after Delete = %v, want ErrNotFound", err)
+	}
+
+	// Invalid requests come back with the problem details of the API.
+	_, err = c.Create(ctx, api.ItemCreate{Name: " lamp", Price: ptr("1.234")})
+	if !errors.As(err, &e) || e.StatusCode != http.StatusBadRequest || e.Problem == nil || e.Problem.Errors == nil || len(*e.Problem.Errors) != 2 {
+		t.Fatalf("Create of an invalid item = %#v", err)
+	}
+	if errors.Is(err, client.ErrNotFound) || errors.Is(err, client.ErrDuplicateName) {
+		t.Fatalf("400 matches a sentinel error: %v", err)
+	}
+}
+
+// flaky answers the next n requests with 503, n as set by failing, and
+// records the headers of every request it gets.
+type flaky struct {
+	mu      sync.Mutex
+	n       int
+	headers []http.Header
+}
+
+func (f *flaky) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.headers = append(f.headers, r.Header.Clone())
+		fail := f.n > 0
+		f.n--
+		f.mu.Unlock()
+		if fail {
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// failing sets the number of requests to fail.
+func (f *flaky) failing(n int) {
+	f.mu.Lock()
+	f.n = n
+	f.mu.Unlock()
+}
+
+// received returns the headers of the requests received since the last call.
+func (f *flaky) received() []http.Header {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	h := f.headers
+	f.headers = nil
+	return h
+}
+
+func TestClientRetries(t *testing.T) {
+	ctx := context.Background()
+	f := &flaky{}
+	server := newAPI(t, f.wrap)
+	c := newClient(t, server, client.Options{Retries: 2, Backoff: time.Millisecond})
+	it, err := c.Create(ctx, api.ItemCreate{Name: "lamp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Idempotent requests are sent again, with their body.
+	f.received()
+	f.failing(2)
+	if _, err := c.Get(ctx, it.Id); err != nil {
+		t.Fatalf("Get after 2 failures = %v", err)
+	}
+	f.failing(2)
+	if it, err = c.Update(ctx, it.Id, api.ItemUpdate{Name: "desk lamp"}, it.Version); err != nil || it.Name != "desk lamp" {
+		t.Fatalf("Update after 2 failures = %+v, %v", it, err)
+	}
+	if n := len(f.received()); n != 6 {
+		t.Fatalf("Get and Update were sent %d times, want 6", n)
+	}
+
+	// Until the retries are used up.
+	f.failing(3)
+	var e *client.Error
+	if _, err := c.Get(ctx, it.Id); !errors.As(err, &e) || e.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Get after 3 failures = %v, want 503", err)
+	}
+
+	// Requests that may not be repeated are sent once.
+	f.received()
+	f.failing(1)
+	if _, err := c.Create(ctx, api.ItemCreate{Name: "chair"}); !errors.As(err, &e) || e.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Create after a failure = %v, want 503", err)
+	}
+	f.failing(1)
+	if _, err := c.Patch(ctx, it.Id, api.ItemMergePatch{"name": "chair"}, 0); !errors.As(err, &e) || e.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Patch after a failure = %v, want 503", err)
+	}
+	if n := len(f.received()); n != 2 {
+		t.Fatalf("Create and Patch were sent %d times, want 2", n)
+	}
+
+	// Waiting for a retry ends with the context.
+	f.failing(1)
+	slow := newClient(t, server, client.Options{Backoff: time.Hour})
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := slow.Get(ctx, it.Id); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get with a context ending during the backoff = %v", err)
+	}
+}
+
+// doerFunc adapts a function to api.HttpRequestDoer.
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestClientNetworkErrors(t *testing.T) {
+	server := newAPI(t, nil)
+	fails := 1
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		if fails > 0 {
+			fails--
+			return nil, errors.New("connection reset by peer")
+		}
+		return http.DefaultClient.Do(req)
+	})
+	c := newClient(t, server, client.Options{HTTPClient: doer, Backoff: time.Millisecond})
+	if page, err := c.List(context.Background(), nil); err != nil || page.Total != 0 {
+		t.Fatalf("List after a network error = %+v, %v", page, err)
+	}
+}
+
+func TestClientCapsRetryAfter(t *testing.T) {
+	server := newAPI(t, nil)
+	fails := 1
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		if fails > 0 {
+			fails--
+			h := http.Header{"Retry-After": {"3600"}}
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: h, Body: http.NoBody, Request: req}, nil
+		}
+		return http.DefaultClient.Do(req)
+	})
+	c := newClient(t, server, client.Options{HTTPClient: doer, MaxBackoff: time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := c.List(ctx, nil); err != nil {
+		t.Fatalf("List after a Retry-After of an hour = %v", err)
+	}
+}
+
+func TestClientHeaders(t *testing.T) {
+	f := &flaky{}
+	c := newClient(t, newAPI(t, f.wrap), client.Options{Auth: client.BearerToken("secret"), Backoff: time.Millisecond})
+
+	// Every attempt of a call carries the credentials and the same request ID.
+	f.failing(1)
+	ctx := client.WithRequestID(context.Background(), "req-1")
+	if _, err := c.List(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	f.failing(1)
+	if _, err := c.List(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	h := f.received()
+	if len(h) != 4 {
+		t.Fatalf("%d requests, want 4", len(h))
+	}
+	for _, h := range h {
+		if got := h.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+	}
+	ids := []string{h[0].Get(client.RequestIDHeader), h[1].Get(client.RequestIDHeader), h[2].Get(client.RequestIDHeader), h[3].Get(client.RequestIDHeader)}
+	if ids[0] != "req-1" || ids[1] != "req-1" {
+		t.Errorf("request IDs %q, want req-1 from the context", ids[:2])
+	}
+	if ids[2] == "" || ids[2] == "req-1" || ids[3] != ids[2] {
+		t.Errorf("request IDs %q, want one new ID shared by the retries", ids[2:])
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"go-openapi-demo/api"
+)
+
+var (
+	// ErrNotFound matches the error of a call for an item that does not exist (404).
+	ErrNotFound = errors.New("item not found")
+
+	// ErrDuplicateName matches the error of a call giving an item a name
+	// another item already has (409).
+	ErrDuplicateName = errors.New("item name already taken")
+
+	// ErrVersionMismatch matches the error of a change to a version the item
+	// no longer has (412): get the item again and redo the change.
+	ErrVersionMismatch = errors.New("item version mismatch")
+)
+
+// statusErrors maps the statuses that have a sentinel error onto it.
+var statusErrors = map[int]error{
+	http.StatusNotFound:           ErrNotFound,
+	http.StatusConflict:           ErrDuplicateName,
+	http.StatusPreconditionFailed: ErrVersionMismatch,
+}
+
+// Error is a response of the API other than the success of the call.
+// errors.Is matches it against ErrNotFound, ErrDuplicateName and
+// ErrVersionMismatch.
+type Error struct {
+	StatusCode int
+	Message    string       // from the body, or the text of the status
+	Problem    *api.Problem // the fields a 400 rejects; nil for other statuses
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("items API: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Is reports whether target is the sentinel error of the status.
+func (e *Error) Is(target error) bool {
+	return statusErrors[e.StatusCode] == target
+}
+
+// responseError returns the *Error of a response the call did not expect,
+// with the message of its Error or Problem body.
+func responseError(rsp *http.Response, body []byte) error {
+	e := &Error{StatusCode: rsp.StatusCode, Message: http.StatusText(rsp.StatusCode)}
+	mediaType, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/problem+json":
+		var p api.Problem
+		if json.Unmarshal(body, &p) == nil {
+			e.Problem = &p
+			if p.Detail != nil {
+				e.Message = *p.Detail
+			}
+		}
+	case "application/json":
+		var b api.Error
+		if json.Unmarshal(body, &b) == nil && b.Message != "" {
+			e.Message = b.Message
+		}
+	}
+	return e
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-openapi-demo/api"
+)
+
+// idempotent are the methods whose requests have the same effect sent once or
+// many times (RFC 9110, section 9.2.2), so they can be sent again when it is
+// unknown whether the server got them.
+var idempotent = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// retryable are the statuses of servers and proxies that are overloaded or
+// unavailable for a moment.
+var retryable = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// retrier sends idempotent requests again after network errors and retryable
+// statuses, waiting longer every time.
+type retrier struct {
+	doer       api.HttpRequestDoer
+	retries    int           // negative for none
+	backoff    time.Duration // wait before the first retry
+	maxBackoff time.Duration // longest wait before a retry
+}
+
+// Do sends req, and sends it again as long as it may succeed the next time.
+// It returns the last response or error.
+func (r *retrier) Do(req *http.Request) (*http.Response, error) {
+	retries := r.retries
+	if !idempotent[req.Method] || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		retries = 0
+	}
+	ctx := req.Context()
+	wait := r.backoff
+	attempt := req
+	for i := 0; ; i++ {
+		rsp, err := r.doer.Do(attempt)
+		if i >= retries || !retry(ctx, rsp, err) {
+			return rsp, err
+		}
+		if rsp != nil {
+			if s, err := strconv.Atoi(rsp.Header.Get("Retry-After")); err == nil && s >= 0 {
+				wait = time.Duration(s) * time.Second
+			}
+			_, _ = io.Copy(io.Discard, rsp.Body) // lets the connection be reused
+			rsp.Body.Close()
+		}
+
+		wait = min(wait, r.maxBackoff)
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+		wait *= 2
+
+		attempt = req.Clone(ctx)
+		if req.GetBody != nil {
+			if attempt.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// retry reports whether a request answered with rsp or err may succeed when
+// sent again: after a network error, unless the caller gave up, or a
+// retryable status.
+func retry(ctx context.Context, rsp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return retryable[rsp.StatusCode]
+}
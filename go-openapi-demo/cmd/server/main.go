@@ -2,40 +2,153 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"time"
 
-	"github.com/go-chi/chi/v5"
-	oapimw "github.com/oapi-codegen/nethttp-middleware"
-
-	"go-openapi-demo/api"
+	"go-openapi-demo/internal/certs"
 	"go-openapi-demo/internal/handlers"
+	"go-openapi-demo/internal/store"
 )
 
 func main() {
-	// GetSwagger is generated by oapi-codegen when 'spec' is enabled in the config.
-	// It returns the parsed OpenAPI document for use by the validator middleware.
-	swagger, err := api.GetSwagger()
+	// Server settings: every flag falls back to an environment variable, so the
+	// server can be configured from the command line or from a container spec.
+	var (
+		addr              = flag.String("addr", env("ADDR", ":8080"), "listen address (ADDR)")
+		readHeaderTimeout = flag.Duration("read-header-timeout", envDuration("READ_HEADER_TIMEOUT", 5*time.Second), "max time to read request headers (READ_HEADER_TIMEOUT)")
+		readTimeout       = flag.Duration("read-timeout", envDuration("READ_TIMEOUT", 30*time.Second), "max time to read a whole request (READ_TIMEOUT)")
+		writeTimeout      = flag.Duration("write-timeout", envDuration("WRITE_TIMEOUT", 30*time.Second), "max time to write a response (WRITE_TIMEOUT)")
+		idleTimeout       = flag.Duration("idle-timeout", envDuration("IDLE_TIMEOUT", 2*time.Minute), "max keep-alive idle time (IDLE_TIMEOUT)")
+		maxHeaderBytes    = flag.Int("max-header-bytes", envInt("MAX_HEADER_BYTES", 64<<10), "max size of request headers (MAX_HEADER_BYTES)")
+		tlsCert           = flag.String("tls-cert", env("TLS_CERT_FILE", ""), "PEM server certificate; enables HTTPS and HTTP/2 (TLS_CERT_FILE)")
+		tlsKey            = flag.String("tls-key", env("TLS_KEY_FILE", ""), "PEM server key (TLS_KEY_FILE)")
+		tlsClientCA       = flag.String("tls-client-ca", env("TLS_CLIENT_CA_FILE", ""), "PEM CA bundle verifying client certificates (TLS_CLIENT_CA_FILE)")
+		tlsClientAuth     = flag.String("tls-client-auth", env("TLS_CLIENT_AUTH", "none"), "client certificates: none, request, require, verify-if-given or require-and-verify (TLS_CLIENT_AUTH)")
+		storeKind         = flag.String("store", env("STORE", "memory"), "item storage: memory, file, sqlite or postgres (STORE)")
+		storeDSN          = flag.String("store-dsn", env("STORE_DSN", ""), "directory (file), database file (sqlite) or connection URL (postgres) (STORE_DSN)")
+		requireIfMatch    = flag.Bool("require-if-match", envBool("REQUIRE_IF_MATCH", false), "reject PUT, PATCH and DELETE without If-Match with 428 (REQUIRE_IF_MATCH)")
+	)
+	flag.Parse()
+
+	// Open the item storage and add sample items to an empty one.
+	ctx := context.Background()
+	items, err := store.Open(ctx, *storeKind, *storeDSN)
+	if err != nil {
+		log.Fatalf("open %s store: %v", *storeKind, err)
+	}
+	if err := seed(ctx, items); err != nil {
+		log.Fatalf("seed items: %v", err)
+	}
+	log.Printf("items are stored in %s", *storeKind)
+
+	// Wire generated routes to our implementation, one version of the API per base URL.
+	// - oapi-codegen generated the routing function and the strict handler of each version
+	// - our ItemsService implements the typed StrictServerInterface methods of version 1,
+	//   ItemsServiceV2 adapts them to version 2, so both share the store
+	// - every request is validated against the spec of its version before it is routed.
+	// Version 1 stays at the root too, for the clients from before there were versions.
+	svc := handlers.NewItemsService(items)
+	svc.RequireIfMatch = *requireIfMatch
+	r, err := handlers.NewRouter(
+		handlers.V1("", svc),
+		handlers.V1("/v1", svc),
+		handlers.V2("/v2", handlers.NewItemsServiceV2(svc)),
+	)
 	if err != nil {
 		log.Fatalf("load spec: %v", err)
 	}
-	// Remove server URLs so the validator doesn't enforce a specific base URL in local dev.
-	swagger.Servers = nil
 
-	// Create a Chi router (lightweight idiomatic HTTP router).
-	r := chi.NewRouter()
+	// Bound every phase of a request, so slow or idle clients cannot hold connections forever.
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           r,
+		ReadHeaderTimeout: *readHeaderTimeout,
+		ReadTimeout:       *readTimeout,
+		WriteTimeout:      *writeTimeout,
+		IdleTimeout:       *idleTimeout,
+		MaxHeaderBytes:    *maxHeaderBytes,
+	}
 
-	// Add request validation middleware: every incoming request must match the OpenAPI spec
-	// (paths, params, headers, and JSON schema for bodies).
-	r.Use(oapimw.OapiRequestValidator(swagger))
+	// Start the HTTP server, or the HTTPS server (HTTP/2 negotiated via ALPN) when a
+	// certificate is configured. Certificate files are re-read when they change on disk.
+	if *tlsCert == "" {
+		log.Printf("listening on %s", *addr)
+		log.Fatal(srv.ListenAndServe())
+	}
+	auth, err := certs.ParseClientAuth(*tlsClientAuth)
+	if err != nil {
+		log.Fatalf("tls: %v", err)
+	}
+	files := &certs.Files{CAFile: *tlsClientCA, CertFile: *tlsCert, KeyFile: *tlsKey}
+	if srv.TLSConfig, err = certs.ServerConfig(files, auth, "h2", "http/1.1"); err != nil {
+		log.Fatalf("tls: %v", err)
+	}
+	log.Printf("listening on %s (https, client auth %s)", *addr, auth)
+	log.Fatal(srv.ListenAndServeTLS("", ""))
+}
 
-	// Wire generated routes to our implementation.
-	// - oapi-codegen generated the routing function (HandlerFromMux)
-	// - our ItemsService implements the non-strict server interface methods.
-	svc := handlers.NewItemsService()
-	api.HandlerFromMux(svc, r)
+// seed creates a couple of sample items when the store is empty.
+func seed(ctx context.Context, s store.ItemStore) error {
+	existing, err := s.List(ctx)
+	if err != nil || len(existing) > 0 {
+		return err
+	}
+	for _, name := range []string{"Item 1", "Item 2"} {
+		if _, err := s.Create(ctx, store.Item{Name: name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	// Start the HTTP server.
-	log.Println("listening on :8080")
-	log.Fatal(http.ListenAndServe(":8080", r))
+// env returns the environment variable, or def if it is unset or empty.
+func env(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// envDuration returns the environment variable parsed as a duration such as "30s", or def.
+func envDuration(name string, def time.Duration) time.Duration {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+	return d
+}
+
+// envBool returns the environment variable parsed as a boolean such as "true" or "1", or def.
+func envBool(name string, def bool) bool {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+	return b
+}
+
+// envInt returns the environment variable parsed as an integer, or def.
+func envInt(name string, def int) int {
+	v := os.Getenv(name)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalf("%s: %v", name, err)
+	}
+	return n
 }
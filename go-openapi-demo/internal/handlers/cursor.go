@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"go-openapi-demo/api"
+	"go-openapi-demo/internal/store"
+)
+
+// cursor is the position of the last item of a page, in the order of the
+// request that produced it. Clients treat it as an opaque string.
+type cursor struct {
+	Sort api.GetItemsParamsSort `json:"s"`
+	Q    string                 `json:"q,omitempty"`
+	ID   int64                  `json:"id"`
+	Name string                 `json:"n,omitempty"` // only kept when sorting by name
+}
+
+// errBadCursor is answered with 400; it does not say why the cursor was rejected.
+var errBadCursor = errors.New("invalid cursor")
+
+// encodeCursor returns the cursor of the page ending with last.
+func encodeCursor(sort api.GetItemsParamsSort, q string, last store.Item) string {
+	c := cursor{Sort: sort, Q: q, ID: last.ID}
+	if sort == api.Name || sort == api.MinusName {
+		c.Name = last.Name
+	}
+	b, _ := json.Marshal(c) // cannot fail: only strings and integers
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor returns the item the next page starts after. The cursor must
+// come from a request with the same sort and q, or pages would overlap or skip items.
+func decodeCursor(s string, sort api.GetItemsParamsSort, q string) (store.Item, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return store.Item{}, errBadCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Sort != sort || c.Q != q {
+		return store.Item{}, errBadCursor
+	}
+	return store.Item{ID: c.ID, Name: c.Name}, nil
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+)
+
+// Extensions of a spec, or of one of its operations, dating the deprecation
+// of the operations marked deprecated. The dates are YYYY-MM-DD, in UTC.
+const (
+	extDeprecatedAt = "x-deprecated-at" // since when the operations are deprecated; required
+	extSunset       = "x-sunset"        // when they may be removed; optional
+)
+
+// deprecation holds the headers answering a deprecated operation.
+type deprecation struct {
+	deprecation string // date of the deprecation (RFC 9745), e.g. @1792281600
+	sunset      string // HTTP date of the sunset (RFC 8594), or ""
+}
+
+// deprecations returns the headers of the operations of swagger marked
+// deprecated, by method and chi route pattern under baseURL, e.g.
+// "GET /v1/items/{id}". An operation takes its dates from its own extensions,
+// or else from those of the info of the spec.
+func deprecations(swagger *openapi3.T, baseURL string) (map[string]deprecation, error) {
+	out := map[string]deprecation{}
+	for path, item := range swagger.Paths.Map() {
+		for method, op := range item.Operations() {
+			if !op.Deprecated {
+				continue
+			}
+			since, err := extDate(op.Extensions, swagger.Info.Extensions, extDeprecatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			if since.IsZero() {
+				return nil, fmt.Errorf("%s %s: deprecated without %s", method, path, extDeprecatedAt)
+			}
+			d := deprecation{deprecation: "@" + strconv.FormatInt(since.Unix(), 10)}
+			sunset, err := extDate(op.Extensions, swagger.Info.Extensions, extSunset)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			if !sunset.IsZero() {
+				d.sunset = sunset.Format(http.TimeFormat)
+			}
+			out[method+" "+baseURL+path] = d
+		}
+	}
+	return out, nil
+}
+
+// extDate returns the date of the extension in op, or else in info; the
+// zero time if neither has it.
+func extDate(op, info map[string]any, name string) (time.Time, error) {
+	v, ok := op[name]
+	if !ok {
+		v, ok = info[name]
+	}
+	if !ok {
+		return time.Time{}, nil
+	}
+	s, _ := v.(string)
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %v is not a date such as 2026-12-31", name, v)
+	}
+	return t, nil
+}
+
+// deprecate sets the headers of the deprecated operations of a version on
+// their responses. It runs in the route group of the version, after chi
+// matched the route.
+func deprecate(ops map[string]deprecation) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if d, ok := ops[r.Method+" "+chi.RouteContext(r.Context()).RoutePattern()]; ok {
+				w.Header().Set("Deprecation", d.deprecation)
+				if d.sunset != "" {
+					w.Header().Set("Sunset", d.sunset)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
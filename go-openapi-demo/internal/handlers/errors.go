@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"go-openapi-demo/api"
+)
+
+// StrictOptions answers the errors of the strict handler as openapi.yaml
+// declares them: 400 with problem details when a request cannot be decoded,
+// 500 with the Error schema when a service method fails. The cause of a 500
+// is logged, not sent to the client.
+func StrictOptions() api.StrictHTTPServerOptions {
+	return api.StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: BadRequest,
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+			WriteError(w, http.StatusInternalServerError, "internal error")
+		},
+	}
+}
+
+// BadRequest answers 400 with problem details holding the error message. It
+// also serves as the ErrorHandlerFunc of the generated router, e.g. for
+// malformed path parameters.
+func BadRequest(w http.ResponseWriter, r *http.Request, err error) {
+	WriteProblem(w, r, http.StatusBadRequest, err.Error(), nil)
+}
+
+// WriteError writes an api.Error JSON body with the status.
+func WriteError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(api.Error{Message: message})
+}
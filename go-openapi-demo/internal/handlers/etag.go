@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"go-openapi-demo/api"
+)
+
+// Errors of conditional changes, answered with 412 and 428.
+var (
+	errPreconditionFailed   = errors.New("item was changed: If-Match does not match its current ETag")
+	errPreconditionRequired = errors.New("If-Match required: send the ETag of the item being changed")
+)
+
+// itemETag is the strong ETag of an item: its version. Every change of the
+// item increments the version, so equal ETags mean byte-identical representations.
+func itemETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// pageETag is the strong ETag of a page of items: a hash of its JSON encoding.
+func pageETag(page api.ItemPage) string {
+	b, _ := json.Marshal(page) // cannot fail: only strings and integers
+	sum := sha256.Sum256(b)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+}
+
+// parseETags splits an If-Match or If-None-Match header into its entity tags,
+// e.g. `"1", W/"2"` into `"1"` and `W/"2"`, or `*` into `*`.
+// Malformed parts end the list.
+func parseETags(h string) []string {
+	var tags []string
+	for {
+		h = strings.TrimLeft(h, " \t,")
+		if h == "" {
+			return tags
+		}
+		if h[0] == '*' {
+			tags, h = append(tags, "*"), h[1:]
+			continue
+		}
+		prefix := ""
+		if strings.HasPrefix(h, "W/") {
+			prefix, h = "W/", h[2:]
+		}
+		if !strings.HasPrefix(h, `"`) {
+			return tags
+		}
+		end := strings.IndexByte(h[1:], '"') + 2 // just past the closing quote
+		if end < 2 {
+			return tags
+		}
+		tags, h = append(tags, prefix+h[:end]), h[end:]
+	}
+}
+
+// noneMatch reports whether an If-None-Match header lists etag, or *.
+// It uses the weak comparison of RFC 9110: W/ prefixes are ignored.
+func noneMatch(header *string, etag string) bool {
+	if header == nil {
+		return false
+	}
+	for _, t := range parseETags(*header) {
+		if t == "*" || strings.TrimPrefix(t, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion returns the version a change conditioned by an If-Match
+// header is based on, for the store to check atomically with the change:
+//
+//	no header   0 (unconditional), or errPreconditionRequired if s.RequireIfMatch
+//	*           0: any existing item matches
+//	ETags       the item's version if one of them is its strong ETag; errPreconditionFailed otherwise
+//
+// Errors of the store, ErrNotFound included, are returned as they are.
+func (s *ItemsService) ifMatchVersion(ctx context.Context, id int64, header *string) (int64, error) {
+	if header == nil {
+		if s.RequireIfMatch {
+			return 0, errPreconditionRequired
+		}
+		return 0, nil
+	}
+	tags := parseETags(*header)
+	if len(tags) == 1 && tags[0] == "*" {
+		return 0, nil
+	}
+	it, err := s.store.Get(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if !ifMatch(tags, it.Version) {
+		return 0, errPreconditionFailed
+	}
+	return it.Version, nil
+}
+
+// ifMatch reports whether one of the If-Match tags is the strong ETag of version, or *.
+// Weak tags never match (strong comparison of RFC 9110).
+func ifMatch(tags []string, version int64) bool {
+	etag := itemETag(version)
+	for _, t := range tags {
+		if t == "*" || t == etag {
+			return true
+		}
+	}
+	return false
+}
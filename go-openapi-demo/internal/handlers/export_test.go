@@ -0,0 +1,20 @@
+package handlers
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+// PatchAttempts is how often PatchItem applies a patch before it answers 409.
+const PatchAttempts = patchAttempts
+
+// Deprecations returns the Deprecation and Sunset headers of the deprecated
+// operations of swagger by method and route pattern under baseURL.
+func Deprecations(swagger *openapi3.T, baseURL string) (map[string][2]string, error) {
+	ops, err := deprecations(swagger, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string][2]string{}
+	for k, d := range ops {
+		out[k] = [2]string{d.deprecation, d.sunset}
+	}
+	return out, nil
+}
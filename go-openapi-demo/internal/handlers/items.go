@@ -2,127 +2,312 @@
 package handlers
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
-	"sync"
-	"sync/atomic"
+	"strings"
 
 	"go-openapi-demo/api"
+	"go-openapi-demo/internal/store"
 )
 
-// ItemsService is a simple in-memory service implementing the server interface.
-// It is concurrency-safe via a RWMutex.
+// ItemsService implements the generated StrictServerInterface on top of an ItemStore.
+// Every method returns one of the typed responses declared for its operation in
+// openapi.yaml; unexpected store errors are returned as errors and answered with
+// 500 by the ResponseErrorHandlerFunc of StrictOptions.
+// It is as concurrency-safe as the store (all stores are).
+//
+// The request validator enforces the constraints of openapi.yaml on every
+// field; the service keeps id, version, createdAt and updatedAt to the server
+// and answers a name another item already has with 409.
+//
+// Responses carry strong ETags (the version of an item, a hash of a page of
+// items): reads answer If-None-Match with 304, changes answer an If-Match that
+// no longer matches with 412, so concurrent changes cannot overwrite each other.
 type ItemsService struct {
-	mu        sync.RWMutex       // guards access to the map below
-	items     map[int64]api.Item // in-memory storage of items by ID
-	currentID int64              // auto-incrementing ID counter
+	store store.ItemStore // where items are persisted
+
+	// RequireIfMatch makes PUT, PATCH and DELETE without If-Match fail with
+	// 428 instead of changing the item unconditionally.
+	RequireIfMatch bool
 }
 
-// NewItemsService seeds a couple of sample items and returns a ready service.
-func NewItemsService() *ItemsService {
-	s := &ItemsService{
-		items:     map[int64]api.Item{},
-		currentID: 2, // start beyond the seeded IDs below
-	}
-	s.items[1] = api.Item{Id: 1, Name: "Item 1"}
-	s.items[2] = api.Item{Id: 2, Name: "Item 2"}
-	return s
+// NewItemsService returns a service keeping its items in s.
+func NewItemsService(s store.ItemStore) *ItemsService {
+	return &ItemsService{store: s}
 }
 
-// GetItems returns all items.
-// Method signature matches the non-strict interface generated by oapi-codegen.
-func (s *ItemsService) GetItems(w http.ResponseWriter, r *http.Request) {
-	s.mu.RLock()
-	out := make([]api.Item, 0, len(s.items))
-	for _, it := range s.items {
-		out = append(out, it)
+// Compile-time check that the service implements the generated interface.
+var _ api.StrictServerInterface = (*ItemsService)(nil)
+
+// defaultLimit is the page size when the request has no limit, as declared in openapi.yaml.
+const defaultLimit = 20
+
+// GetItems returns one page of the items, filtered by q and ordered by sort.
+// nextCursor is set when more items follow; it holds the position of the last
+// item rather than an offset, so concurrent creates and deletes never make the
+// next page repeat or skip items.
+func (s *ItemsService) GetItems(ctx context.Context, req api.GetItemsRequestObject) (api.GetItemsResponseObject, error) {
+	p := req.Params
+	limit, sort, q := defaultLimit, api.Id, ""
+	if p.Limit != nil {
+		limit = *p.Limit
+	}
+	if p.Sort != nil {
+		sort = *p.Sort
+	}
+	if p.Q != nil {
+		q = *p.Q
 	}
-	s.mu.RUnlock()
 
-	// Write JSON response
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(out)
+	// Ask for one more item than the page holds to learn whether another page follows.
+	opts := store.ListOptions{
+		Contains: q,
+		SortBy:   store.SortKey(strings.TrimPrefix(string(sort), "-")),
+		Desc:     strings.HasPrefix(string(sort), "-"),
+		Limit:    limit + 1,
+	}
+	if p.Cursor != nil {
+		after, err := decodeCursor(*p.Cursor, sort, q)
+		if err != nil {
+			return api.GetItems400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse: badRequest(err.Error())}, nil
+		}
+		opts.After = &after
+	}
+
+	page, err := s.store.ListPage(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := api.ItemPage{Items: make([]api.Item, 0, limit), Total: page.Total}
+	if len(page.Items) > limit {
+		page.Items = page.Items[:limit]
+		next := encodeCursor(sort, q, page.Items[limit-1])
+		out.NextCursor = &next
+	}
+	for _, it := range page.Items {
+		out.Items = append(out.Items, toAPI(it))
+	}
+
+	etag := pageETag(out)
+	if noneMatch(p.IfNoneMatch, etag) {
+		return api.GetItems304Response{Headers: api.NotModifiedResponseHeaders{ETag: etag}}, nil
+	}
+	return api.GetItems200JSONResponse{Body: out, Headers: api.GetItems200ResponseHeaders{ETag: etag}}, nil
 }
 
 // GetItemById returns one item if it exists; 404 otherwise.
-func (s *ItemsService) GetItemById(w http.ResponseWriter, r *http.Request, id int64) {
-	s.mu.RLock()
-	it, ok := s.items[id]
-	s.mu.RUnlock()
-	if !ok {
-		http.Error(w, "not found", http.StatusNotFound)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(it)
+// 304 if the client's If-None-Match already lists its ETag.
+func (s *ItemsService) GetItemById(ctx context.Context, req api.GetItemByIdRequestObject) (api.GetItemByIdResponseObject, error) {
+	it, err := s.store.Get(ctx, req.Id)
+	if errors.Is(err, store.ErrNotFound) {
+		return api.GetItemById404JSONResponse{NotFoundJSONResponse: notFound(req.Id)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	etag := itemETag(it.Version)
+	if noneMatch(req.Params.IfNoneMatch, etag) {
+		return api.GetItemById304Response{Headers: api.NotModifiedResponseHeaders{ETag: etag}}, nil
+	}
+	return api.GetItemById200JSONResponse{Body: toAPI(it), Headers: api.GetItemById200ResponseHeaders{ETag: etag}}, nil
 }
 
-// CreateItem creates a new item from JSON body and returns it with 201 Created.
-func (s *ItemsService) CreateItem(w http.ResponseWriter, r *http.Request) {
-	var req api.ItemCreate
-	// Decode request JSON body into ItemCreate
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
-		return
+// CreateItem creates a new item and returns it with 201 Created.
+// The body was decoded (and validated by the request validator) before this is called.
+func (s *ItemsService) CreateItem(ctx context.Context, req api.CreateItemRequestObject) (api.CreateItemResponseObject, error) {
+	if req.Body == nil {
+		return api.CreateItem400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse: badRequest("request body required")}, nil
 	}
 
-	// Generate a new unique ID atomically
-	id := atomic.AddInt64(&s.currentID, 1)
-	item := api.Item{Id: id, Name: req.Name}
+	// Store the new item; the store assigns its ID
+	it, err := s.store.Create(ctx, fromAPI(api.ItemUpdate(*req.Body)))
+	if errors.Is(err, store.ErrDuplicateName) {
+		return api.CreateItem409JSONResponse{ConflictJSONResponse: conflict(req.Body.Name)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return api.CreateItem201JSONResponse{Body: toAPI(it), Headers: api.CreateItem201ResponseHeaders{ETag: itemETag(it.Version)}}, nil
+}
 
-	// Store the new item
-	s.mu.Lock()
-	s.items[id] = item
-	s.mu.Unlock()
+// UpdateItem replaces an item's fields; returns 404 if the item doesn't exist,
+// 409 if another item has the name and 412 or 428 if the If-Match
+// precondition fails (see ifMatchVersion).
+func (s *ItemsService) UpdateItem(ctx context.Context, req api.UpdateItemRequestObject) (api.UpdateItemResponseObject, error) {
+	if req.Body == nil {
+		return api.UpdateItem400ApplicationProblemPlusJSONResponse{BadRequestApplicationProblemPlusJSONResponse: badRequest("request body required")}, nil
+	}
 
-	// Respond with the created resource
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	_ = json.NewEncoder(w).Encode(item)
+	version, err := s.ifMatchVersion(ctx, req.Id, req.Params.IfMatch)
+	var it store.Item
+	if err == nil {
+		it = fromAPI(*req.Body)
+		it.ID, it.Version = req.Id, version
+		it, err = s.store.Update(ctx, it)
+	}
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return api.UpdateItem404JSONResponse{NotFoundJSONResponse: notFound(req.Id)}, nil
+	case errors.Is(err, store.ErrDuplicateName):
+		return api.UpdateItem409JSONResponse{ConflictJSONResponse: conflict(req.Body.Name)}, nil
+	case errors.Is(err, errPreconditionRequired):
+		return api.UpdateItem428JSONResponse{PreconditionRequiredJSONResponse: api.PreconditionRequiredJSONResponse{Message: err.Error()}}, nil
+	case errors.Is(err, errPreconditionFailed), errors.Is(err, store.ErrVersionMismatch):
+		return api.UpdateItem412JSONResponse{PreconditionFailedJSONResponse: preconditionFailed()}, nil
+	case err != nil:
+		return nil, err
+	}
+	return api.UpdateItem200JSONResponse{Body: toAPI(it), Headers: api.UpdateItem200ResponseHeaders{ETag: itemETag(it.Version)}}, nil
 }
 
-// UpdateItem replaces an item's fields; returns 404 if the item doesn't exist.
-func (s *ItemsService) UpdateItem(w http.ResponseWriter, r *http.Request, id int64) {
-	var req api.ItemUpdate
-	// Decode request JSON body into ItemUpdate
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
-		return
-	}
+// patchAttempts is how often PatchItem applies a patch that keeps losing the
+// race to other changes of the item before it gives up.
+const patchAttempts = 5
 
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	if _, ok := s.items[id]; !ok {
-		http.Error(w, "not found", http.StatusNotFound)
-		return
+// PatchItem changes some fields of an item; see patchItem for the patch formats.
+// Returns 404 if the item doesn't exist, 409 if another item has the patched
+// name, 412 or 428 if the If-Match precondition fails and 422 if the patch
+// cannot be applied.
+//
+// The patch is applied to the version read, and the store only accepts the
+// result while the item still has that version. Without If-Match, a patch that
+// loses this race to another change is applied again to the newer version,
+// up to patchAttempts times in all; then the answer is 409.
+func (s *ItemsService) PatchItem(ctx context.Context, req api.PatchItemRequestObject) (api.PatchItemResponseObject, error) {
+	if req.Params.IfMatch == nil && s.RequireIfMatch {
+		return api.PatchItem428JSONResponse{PreconditionRequiredJSONResponse: api.PreconditionRequiredJSONResponse{Message: errPreconditionRequired.Error()}}, nil
 	}
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err // the client is gone
+		}
+		it, err := s.store.Get(ctx, req.Id)
+		if errors.Is(err, store.ErrNotFound) {
+			return api.PatchItem404JSONResponse{NotFoundJSONResponse: notFound(req.Id)}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h := req.Params.IfMatch; h != nil && !ifMatch(parseETags(*h), it.Version) {
+			return api.PatchItem412JSONResponse{PreconditionFailedJSONResponse: preconditionFailed()}, nil
+		}
 
-	// Update and return the item
-	s.items[id] = api.Item{Id: id, Name: req.Name}
+		patched, err := patchItem(toAPI(it), req)
+		var pe *patchError
+		if errors.As(err, &pe) {
+			return api.PatchItem422JSONResponse{UnprocessableEntityJSONResponse: api.UnprocessableEntityJSONResponse{Message: pe.Error()}}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(s.items[id])
+		next := fromAPI(api.ItemUpdate{
+			Name:        patched.Name,
+			Description: &patched.Description,
+			Tags:        &patched.Tags,
+			Price:       patched.Price,
+			Status:      &patched.Status,
+		})
+		next.ID, next.Version = req.Id, it.Version
+		it, err = s.store.Update(ctx, next)
+		switch {
+		case errors.Is(err, store.ErrVersionMismatch) && req.Params.IfMatch != nil:
+			return api.PatchItem412JSONResponse{PreconditionFailedJSONResponse: preconditionFailed()}, nil
+		case errors.Is(err, store.ErrVersionMismatch) && attempt < patchAttempts:
+			continue // re-read and apply the patch to the newer version
+		case errors.Is(err, store.ErrVersionMismatch):
+			return api.PatchItem409JSONResponse{ConflictJSONResponse: api.ConflictJSONResponse{
+				Message: fmt.Sprintf("item %d kept changing while it was patched; retry the patch", req.Id),
+			}}, nil
+		case errors.Is(err, store.ErrNotFound):
+			return api.PatchItem404JSONResponse{NotFoundJSONResponse: notFound(req.Id)}, nil
+		case errors.Is(err, store.ErrDuplicateName):
+			return api.PatchItem409JSONResponse{ConflictJSONResponse: conflict(patched.Name)}, nil
+		case err != nil:
+			return nil, err
+		}
+		return api.PatchItem200JSONResponse{Body: toAPI(it), Headers: api.PatchItem200ResponseHeaders{ETag: itemETag(it.Version)}}, nil
+	}
 }
 
-// DeleteItem removes an item by ID; returns 404 if not found.
-// Note: your OpenAPI spec currently only defines 204 for DELETE.
-// If you want to return 404, add it to the spec for full alignment.
-func (s *ItemsService) DeleteItem(w http.ResponseWriter, r *http.Request, id int64) {
-	s.mu.Lock()
-	_, ok := s.items[id]
-	if ok {
-		delete(s.items, id)
+// DeleteItem removes an item by ID; returns 404 if not found and 412 or 428
+// if the If-Match precondition fails (see ifMatchVersion).
+func (s *ItemsService) DeleteItem(ctx context.Context, req api.DeleteItemRequestObject) (api.DeleteItemResponseObject, error) {
+	version, err := s.ifMatchVersion(ctx, req.Id, req.Params.IfMatch)
+	if err == nil {
+		err = s.store.Delete(ctx, req.Id, version)
+	}
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return api.DeleteItem404JSONResponse{NotFoundJSONResponse: notFound(req.Id)}, nil
+	case errors.Is(err, errPreconditionRequired):
+		return api.DeleteItem428JSONResponse{PreconditionRequiredJSONResponse: api.PreconditionRequiredJSONResponse{Message: err.Error()}}, nil
+	case errors.Is(err, errPreconditionFailed), errors.Is(err, store.ErrVersionMismatch):
+		return api.DeleteItem412JSONResponse{PreconditionFailedJSONResponse: preconditionFailed()}, nil
+	case err != nil:
+		return nil, err
 	}
-	s.mu.Unlock()
+	return api.DeleteItem204Response{}, nil
+}
+
+// toAPI converts a stored item into its API representation.
+func toAPI(it store.Item) api.Item {
+	out := api.Item{
+		Id:          it.ID,
+		Name:        it.Name,
+		Description: it.Description,
+		Tags:        append(api.ItemTags{}, it.Tags...), // [] rather than null
+		Status:      api.ItemStatus(it.Status),
+		Version:     it.Version,
+		CreatedAt:   it.CreatedAt,
+		UpdatedAt:   it.UpdatedAt,
+	}
+	if it.Price != "" {
+		out.Price = &it.Price
+	}
+	return out
+}
 
-	if !ok {
-		http.Error(w, "not found", http.StatusNotFound)
-		return
+// fromAPI converts the client's fields of an item into a stored item without
+// ID or version. Absent fields are left empty; the store defaults the status.
+// ItemCreate converts to ItemUpdate, which has the same fields.
+func fromAPI(body api.ItemUpdate) store.Item {
+	it := store.Item{Name: body.Name}
+	if body.Description != nil {
+		it.Description = *body.Description
 	}
+	if body.Tags != nil {
+		it.Tags = *body.Tags
+	}
+	if body.Price != nil {
+		it.Price = *body.Price
+	}
+	if body.Status != nil {
+		it.Status = string(*body.Status)
+	}
+	return it
+}
+
+// badRequest is the body of the 400 responses of the service itself, for
+// requests the validator accepts but the service cannot.
+func badRequest(detail string) api.BadRequestApplicationProblemPlusJSONResponse {
+	return api.BadRequestApplicationProblemPlusJSONResponse(problem(http.StatusBadRequest, detail, nil))
+}
+
+// notFound is the body of the 404 responses.
+func notFound(id int64) api.NotFoundJSONResponse {
+	return api.NotFoundJSONResponse{Message: fmt.Sprintf("item %d not found", id)}
+}
+
+// conflict is the body of the 409 responses.
+func conflict(name string) api.ConflictJSONResponse {
+	return api.ConflictJSONResponse{Message: fmt.Sprintf("an item named %q already exists", name)}
+}
 
-	w.WriteHeader(http.StatusNoContent)
+// preconditionFailed is the body of the 412 responses.
+func preconditionFailed() api.PreconditionFailedJSONResponse {
+	return api.PreconditionFailedJSONResponse{Message: errPreconditionFailed.Error()}
 }
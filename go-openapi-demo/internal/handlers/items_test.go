@@ -0,0 +1,637 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	"go-openapi-demo/api"
+	"go-openapi-demo/internal/handlers"
+	"go-openapi-demo/internal/store"
+)
+
+// server serves the items API the way cmd/server does and checks every
+// response against openapi.yaml, so handlers and spec cannot drift apart.
+type server struct {
+	t       *testing.T
+	handler http.Handler
+	routes  routers.Router
+}
+
+func newServer(t *testing.T, s store.ItemStore) *server {
+	t.Helper()
+	return newServiceServer(t, handlers.NewItemsService(s))
+}
+
+// newServiceServer is newServer with a configured service.
+func newServiceServer(t *testing.T, svc *handlers.ItemsService) *server {
+	t.Helper()
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	swagger.Servers = nil
+	routes, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := handlers.NewRouter(handlers.V1("", svc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &server{t: t, handler: h, routes: routes}
+}
+
+// do sends the request with a JSON body, if any, and returns the validated response.
+func (s *server) do(method, path, body string) *httptest.ResponseRecorder {
+	s.t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return s.serve(req)
+}
+
+// serve handles the request, validates the response against the spec and returns it.
+func (s *server) serve(req *http.Request) *httptest.ResponseRecorder {
+	s.t.Helper()
+	method, path := req.Method, req.URL.RequestURI()
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+
+	route, params, err := s.routes.FindRoute(req)
+	if err != nil {
+		s.t.Fatalf("%s %s: no operation in the spec: %v", method, path, err)
+	}
+	err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{Request: req, PathParams: params, Route: route},
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(rec.Body.Bytes())),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	})
+	if err != nil {
+		s.t.Errorf("%s %s: response %d %s violates the spec: %v", method, path, rec.Code, rec.Body, err)
+	}
+	return rec
+}
+
+// expect sends the request and checks the status, decoding a JSON body into out if given.
+func (s *server) expect(method, path, body string, status int, out any) {
+	s.t.Helper()
+	s.check(s.do(method, path, body), method+" "+path, status, out)
+}
+
+// expectHeader sends the request with the header and checks the response like
+// expect. It returns the ETag of the response.
+func (s *server) expectHeader(method, path, body, header, value string, status int) string {
+	s.t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(header, value)
+	rec := s.serve(req)
+	s.check(rec, fmt.Sprintf("%s %s with %s: %s", method, path, header, value), status, nil)
+	return rec.Header().Get("ETag")
+}
+
+// expectPatch sends a PATCH with the content type and checks the response like expect.
+func (s *server) expectPatch(path, contentType, body string, status int, out any) {
+	s.t.Helper()
+	req := httptest.NewRequest("PATCH", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	s.check(s.serve(req), "PATCH "+path+" "+body, status, out)
+}
+
+// check fails the test unless rec has the status, and decodes its body into out if given.
+func (s *server) check(rec *httptest.ResponseRecorder, what string, status int, out any) {
+	s.t.Helper()
+	if rec.Code != status {
+		s.t.Fatalf("%s = %d %s, want %d", what, rec.Code, rec.Body, status)
+	}
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			s.t.Fatalf("%s: %v", what, err)
+		}
+	}
+}
+
+func TestItemsLifecycle(t *testing.T) {
+	s := newServer(t, store.NewMemory())
+
+	var created api.Item
+	s.expect("POST", "/items", `{"name":"first"}`, http.StatusCreated, &created)
+	if created.Id == 0 || created.Name != "first" {
+		t.Fatalf("created %+v", created)
+	}
+
+	var got api.Item
+	s.expect("GET", "/items/1", "", http.StatusOK, &got)
+	if !reflect.DeepEqual(got, created) {
+		t.Fatalf("GET = %+v, want %+v", got, created)
+	}
+
+	s.expect("PUT", "/items/1", `{"name":"renamed"}`, http.StatusOK, &got)
+	if got.Name != "renamed" {
+		t.Fatalf("PUT = %+v", got)
+	}
+
+	var list api.ItemPage
+	s.expect("GET", "/items", "", http.StatusOK, &list)
+	if len(list.Items) != 1 || !reflect.DeepEqual(list.Items[0], got) || list.Total != 1 || list.NextCursor != nil {
+		t.Fatalf("GET /items = %+v", list)
+	}
+
+	s.expect("DELETE", "/items/1", "", http.StatusNoContent, nil)
+	s.expect("GET", "/items", "", http.StatusOK, &list)
+	if len(list.Items) != 0 || list.Total != 0 {
+		t.Fatalf("GET /items after DELETE = %+v", list)
+	}
+}
+
+// fruitServer returns a server holding five items.
+func fruitServer(t *testing.T) *server {
+	s := newServer(t, store.NewMemory())
+	for _, name := range []string{"pear", "apple", "plum", "fig", "peach"} {
+		s.expect("POST", "/items", `{"name":"`+name+`"}`, http.StatusCreated, nil)
+	}
+	return s
+}
+
+func TestItemsPages(t *testing.T) {
+	// pages follows nextCursor from the first page to the last and returns the
+	// names of the fruits. New items are created between pages; whether they show up
+	// depends on the order, but the fruits must be neither repeated nor skipped.
+	pages := func(query string) []string {
+		t.Helper()
+		s := fruitServer(t)
+		var names []string
+		path := "/items?" + query
+		for {
+			var page api.ItemPage
+			s.expect("GET", path, "", http.StatusOK, &page)
+			for _, it := range page.Items {
+				if !strings.HasPrefix(it.Name, "new") {
+					names = append(names, it.Name)
+				}
+			}
+			if page.NextCursor == nil {
+				return names
+			}
+			path = "/items?" + query + "&cursor=" + url.QueryEscape(*page.NextCursor)
+			s.expect("POST", "/items", fmt.Sprintf(`{"name":"new %d"}`, len(names)), http.StatusCreated, nil)
+		}
+	}
+
+	for query, want := range map[string][]string{
+		"limit=2":                {"pear", "apple", "plum", "fig", "peach"},
+		"limit=2&sort=-id":       {"peach", "fig", "plum", "apple", "pear"},
+		"limit=2&sort=name":      {"apple", "fig", "peach", "pear", "plum"},
+		"limit=3&sort=-name&q=p": {"plum", "pear", "peach", "apple"},
+	} {
+		if got := pages(query); !slices.Equal(got, want) {
+			t.Errorf("GET /items?%s = %q, want %q", query, got, want)
+		}
+	}
+
+	s := fruitServer(t)
+	var page api.ItemPage
+	s.expect("GET", "/items?q=pe&limit=1", "", http.StatusOK, &page)
+	if page.Total != 2 || len(page.Items) != 1 || page.NextCursor == nil {
+		t.Fatalf("GET /items?q=pe&limit=1 = %+v", page)
+	}
+
+	// A cursor only continues the listing it came from.
+	cursor := url.QueryEscape(*page.NextCursor)
+	s.expect("GET", "/items?q=pe&limit=1&cursor="+cursor, "", http.StatusOK, nil)
+	s.expect("GET", "/items?q=pl&cursor="+cursor, "", http.StatusBadRequest, nil)
+	s.expect("GET", "/items?q=pe&sort=name&cursor="+cursor, "", http.StatusBadRequest, nil)
+}
+
+const (
+	mergePatch = "application/merge-patch+json"
+	jsonPatch  = "application/json-patch+json"
+)
+
+func TestItemsPatch(t *testing.T) {
+	s := newServer(t, store.NewMemory())
+	s.expect("POST", "/items", `{"name":"first"}`, http.StatusCreated, nil)
+
+	var got api.Item
+	s.expectPatch("/items/1", mergePatch, `{"name":"merged"}`, http.StatusOK, &got)
+	if got.Id != 1 || got.Name != "merged" || got.Version != 2 || got.Status != api.Active {
+		t.Fatalf("merge patch = %+v", got)
+	}
+	s.expectPatch("/items/1", mergePatch, `{}`, http.StatusOK, &got)
+	if got.Name != "merged" {
+		t.Fatalf("empty merge patch = %+v", got)
+	}
+
+	s.expectPatch("/items/1", jsonPatch, `[{"op":"test","path":"/name","value":"merged"},{"op":"replace","path":"/name","value":"patched"}]`, http.StatusOK, &got)
+	if got.Name != "patched" {
+		t.Fatalf("JSON patch = %+v", got)
+	}
+	s.expect("GET", "/items/1", "", http.StatusOK, &got)
+	if got.Name != "patched" {
+		t.Fatalf("GET after PATCH = %+v", got)
+	}
+
+	// Patches that cannot be applied, or whose result is not a valid Item, change nothing.
+	for _, tc := range []struct{ contentType, body string }{
+		{mergePatch, `{"name":null}`},
+		{mergePatch, `{"name":42}`},
+		{mergePatch, `{"id":2}`},
+		{mergePatch, `{"color":"red"}`},
+		{jsonPatch, `[{"op":"test","path":"/name","value":"merged"},{"op":"replace","path":"/name","value":"x"}]`},
+		{jsonPatch, `[{"op":"remove","path":"/name"}]`},
+		{jsonPatch, `[{"op":"test","path":""}]`},
+		{jsonPatch, `[{"op":"replace","path":"/missing","value":"x"}]`},
+		{jsonPatch, `[{"op":"add","path":"/color","value":"red"}]`},
+		{jsonPatch, `[{"op":"replace","path":"/id","value":5}]`},
+		{mergePatch, `{"version":9}`},
+		{mergePatch, `{"createdAt":"2000-01-01T00:00:00Z"}`},
+		{jsonPatch, `[{"op":"replace","path":"/updatedAt","value":"2000-01-01T00:00:00Z"}]`},
+		{mergePatch, `{"status":"gone"}`},
+		{mergePatch, `{"tags":["a","a"]}`},
+		{mergePatch, `{"description":null}`},
+	} {
+		s.expectPatch("/items/1", tc.contentType, tc.body, http.StatusUnprocessableEntity, nil)
+	}
+	s.expect("GET", "/items/1", "", http.StatusOK, &got)
+	if got.Name != "patched" {
+		t.Fatalf("GET after rejected patches = %+v", got)
+	}
+
+	// Bodies that are not patches at all are rejected by the request validator.
+	s.expectPatch("/items/1", mergePatch, `["name"]`, http.StatusBadRequest, nil)
+	s.expectPatch("/items/1", jsonPatch, `[{"op":"rename","path":"/name"}]`, http.StatusBadRequest, nil)
+	s.expectPatch("/items/1", jsonPatch, `{"name":"x"}`, http.StatusBadRequest, nil)
+	s.expectPatch("/items/1", "application/json", `{"name":"x"}`, http.StatusBadRequest, nil)
+
+	s.expectPatch("/items/7", mergePatch, `{"name":"x"}`, http.StatusNotFound, nil)
+}
+
+func TestItemsFields(t *testing.T) {
+	s := newServer(t, store.NewMemory())
+
+	var got api.Item
+	s.expect("POST", "/items", `{"name":"lamp","description":"A desk lamp","tags":["light","desk"],"price":"12.50","status":"draft"}`, http.StatusCreated, &got)
+	if got.Description != "A desk lamp" || !slices.Equal(got.Tags, []string{"light", "desk"}) ||
+		got.Price == nil || *got.Price != "12.50" || got.Status != api.Draft ||
+		got.CreatedAt.IsZero() || !got.UpdatedAt.Equal(got.CreatedAt) {
+		t.Fatalf("POST = %+v", got)
+	}
+	created := got.CreatedAt
+
+	// PUT replaces every field: absent ones are reset as on create.
+	var put api.Item
+	s.expect("PUT", "/items/1", `{"name":"lamp"}`, http.StatusOK, &put)
+	if put.Description != "" || len(put.Tags) != 0 || put.Price != nil || put.Status != api.Active ||
+		!put.CreatedAt.Equal(created) || put.UpdatedAt.Before(created) {
+		t.Fatalf("PUT = %+v", put)
+	}
+	var patched api.Item
+	s.expectPatch("/items/1", mergePatch, `{"price":"0.99","tags":["sale"]}`, http.StatusOK, &patched)
+	if patched.Price == nil || *patched.Price != "0.99" || !slices.Equal(patched.Tags, []string{"sale"}) {
+		t.Fatalf("PATCH = %+v", patched)
+	}
+	var removed api.Item
+	s.expectPatch("/items/1", mergePatch, `{"price":null}`, http.StatusOK, &removed)
+	if removed.Price != nil || !slices.Equal(removed.Tags, []string{"sale"}) {
+		t.Fatalf("PATCH removing the price = %+v", removed)
+	}
+
+	// The request validator enforces the constraints of the spec.
+	for _, body := range []string{
+		`{"name":""}`,
+		`{"name":" lamp"}`,
+		`{"name":"` + strings.Repeat("x", 101) + `"}`,
+		`{"name":"x","description":"` + strings.Repeat("x", 1001) + `"}`,
+		`{"name":"x","tags":["Upper"]}`,
+		`{"name":"x","tags":["a","a"]}`,
+		`{"name":"x","tags":["1","2","3","4","5","6","7","8","9","10","11"]}`,
+		`{"name":"x","price":"1.234"}`,
+		`{"name":"x","price":"-1"}`,
+		`{"name":"x","price":12.5}`,
+		`{"name":"x","status":"gone"}`,
+		`{"name":"x","id":7}`,
+		`{"name":"x","createdAt":"2000-01-01T00:00:00Z"}`,
+	} {
+		s.expect("POST", "/items", body, http.StatusBadRequest, nil)
+		s.expect("PUT", "/items/1", body, http.StatusBadRequest, nil)
+	}
+}
+
+func TestItemsDuplicateNames(t *testing.T) {
+	s := newServer(t, store.NewMemory())
+	s.expect("POST", "/items", `{"name":"a"}`, http.StatusCreated, nil)
+	s.expect("POST", "/items", `{"name":"b"}`, http.StatusCreated, nil)
+
+	var e api.Error
+	s.expect("POST", "/items", `{"name":"a"}`, http.StatusConflict, &e)
+	if e.Message != `an item named "a" already exists` {
+		t.Errorf("message = %q", e.Message)
+	}
+	s.expect("PUT", "/items/2", `{"name":"a"}`, http.StatusConflict, nil)
+	s.expectPatch("/items/2", mergePatch, `{"name":"a"}`, http.StatusConflict, nil)
+
+	// An item keeps its own name; names differing in case are different.
+	s.expect("PUT", "/items/1", `{"name":"a","description":"still a"}`, http.StatusOK, nil)
+	s.expect("POST", "/items", `{"name":"A"}`, http.StatusCreated, nil)
+}
+
+func TestItemsConditionalReads(t *testing.T) {
+	s := newServer(t, store.NewMemory())
+	rec := s.do("POST", "/items", `{"name":"first"}`)
+	if etag := rec.Header().Get("ETag"); etag != `"1"` {
+		t.Fatalf("ETag of the created item = %s, want \"1\"", etag)
+	}
+
+	for _, h := range []string{`"1"`, `W/"1"`, `"7", "1"`, `*`} {
+		if etag := s.expectHeader("GET", "/items/1", "", "If-None-Match", h, http.StatusNotModified); etag != `"1"` {
+			t.Errorf("ETag of 304 = %s", etag)
+		}
+	}
+	s.expectHeader("GET", "/items/1", "", "If-None-Match", `"2"`, http.StatusOK)
+
+	list := s.expectHeader("GET", "/items", "", "If-None-Match", `"stale"`, http.StatusOK)
+	s.expectHeader("GET", "/items", "", "If-None-Match", list, http.StatusNotModified)
+	s.expectHeader("GET", "/items?q=nothing", "", "If-None-Match", list, http.StatusOK)
+
+	s.expect("PUT", "/items/1", `{"name":"renamed"}`, http.StatusOK, nil)
+	s.expectHeader("GET", "/items/1", "", "If-None-Match", `"1"`, http.StatusOK)
+	s.expectHeader("GET", "/items", "", "If-None-Match", list, http.StatusOK)
+}
+
+func TestItemsConditionalWrites(t *testing.T) {
+	s := newServer(t, store.NewMemory())
+	s.expect("POST", "/items", `{"name":"first"}`, http.StatusCreated, nil)
+
+	// Two clients read version 1; the second change is rejected instead of overwriting the first.
+	if etag := s.expectHeader("PUT", "/items/1", `{"name":"alice"}`, "If-Match", `"1"`, http.StatusOK); etag != `"2"` {
+		t.Fatalf("ETag after PUT = %s, want \"2\"", etag)
+	}
+	s.expectHeader("PUT", "/items/1", `{"name":"bob"}`, "If-Match", `"1"`, http.StatusPreconditionFailed)
+	var got api.Item
+	s.expect("GET", "/items/1", "", http.StatusOK, &got)
+	if got.Name != "alice" || got.Version != 2 {
+		t.Fatalf("GET = %+v, want alice's change", got)
+	}
+
+	// Weak tags never match If-Match; * and lists do.
+	s.expectHeader("PUT", "/items/1", `{"name":"x"}`, "If-Match", `W/"2"`, http.StatusPreconditionFailed)
+	s.expectHeader("PUT", "/items/1", `{"name":"x"}`, "If-Match", `"1", "2"`, http.StatusOK)
+	s.expectHeader("PUT", "/items/1", `{"name":"y"}`, "If-Match", `*`, http.StatusOK)
+
+	req := httptest.NewRequest("PATCH", "/items/1", strings.NewReader(`{"name":"z"}`))
+	req.Header.Set("Content-Type", mergePatch)
+	req.Header.Set("If-Match", `"3"`)
+	s.check(s.serve(req), "PATCH with a stale If-Match", http.StatusPreconditionFailed, nil)
+	req = httptest.NewRequest("PATCH", "/items/1", strings.NewReader(`{"name":"z"}`))
+	req.Header.Set("Content-Type", mergePatch)
+	req.Header.Set("If-Match", `"4"`)
+	s.check(s.serve(req), "PATCH with a current If-Match", http.StatusOK, nil)
+
+	s.expectHeader("DELETE", "/items/1", "", "If-Match", `"4"`, http.StatusPreconditionFailed)
+	s.expectHeader("DELETE", "/items/1", "", "If-Match", `"5"`, http.StatusNoContent)
+	s.expectHeader("DELETE", "/items/1", "", "If-Match", `"5"`, http.StatusNotFound)
+	s.expectHeader("PUT", "/items/1", `{"name":"x"}`, "If-Match", `*`, http.StatusNotFound)
+}
+
+func TestItemsConcurrentPatches(t *testing.T) {
+	// Patches without If-Match that lose the race to another change are
+	// applied again to the newer version, so none of them is lost. Each of
+	// n patches loses at most n-1 times, so all of them get through.
+	s := newServer(t, store.NewMemory())
+	s.expect("POST", "/items", `{"name":"first"}`, http.StatusCreated, nil)
+	const n = handlers.PatchAttempts
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest("PATCH", "/items/1", strings.NewReader(`{"name":"patched"}`))
+			req.Header.Set("Content-Type", mergePatch)
+			if rec := s.serve(req); rec.Code != http.StatusOK {
+				t.Errorf("PATCH = %d %s", rec.Code, rec.Body)
+			}
+		}()
+	}
+	wg.Wait()
+	var got api.Item
+	s.expect("GET", "/items/1", "", http.StatusOK, &got)
+	if got.Version != 1+n {
+		t.Fatalf("version after %d patches = %d, want %d", n, got.Version, 1+n)
+	}
+}
+
+// racingStore changes an item before every update of it, like a writer
+// that always wins the race.
+type racingStore struct {
+	store.ItemStore
+	updates int
+}
+
+func (s *racingStore) Update(ctx context.Context, it store.Item) (store.Item, error) {
+	s.updates++
+	other := it
+	other.Version = 0
+	if _, err := s.ItemStore.Update(ctx, other); err != nil {
+		return store.Item{}, err
+	}
+	return s.ItemStore.Update(ctx, it)
+}
+
+func TestItemsPatchGivesUp(t *testing.T) {
+	st := &racingStore{ItemStore: store.NewMemory()}
+	s := newServer(t, st)
+	s.expect("POST", "/items", `{"name":"first"}`, http.StatusCreated, nil)
+
+	// Without If-Match the patch is retried, then answered with 409.
+	s.expectPatch("/items/1", mergePatch, `{"name":"patched"}`, http.StatusConflict, nil)
+	if st.updates != handlers.PatchAttempts {
+		t.Errorf("%d updates, want %d", st.updates, handlers.PatchAttempts)
+	}
+
+	// With If-Match the lost race fails the precondition at once.
+	var it api.Item
+	s.expect("GET", "/items/1", "", http.StatusOK, &it)
+	st.updates = 0
+	req := httptest.NewRequest("PATCH", "/items/1", strings.NewReader(`{"name":"patched"}`))
+	req.Header.Set("Content-Type", mergePatch)
+	req.Header.Set("If-Match", fmt.Sprintf(`"%d"`, it.Version))
+	s.check(s.serve(req), "PATCH with a current If-Match", http.StatusPreconditionFailed, nil)
+	if st.updates != 1 {
+		t.Errorf("%d updates with If-Match, want 1", st.updates)
+	}
+
+	// A request whose client is gone stops retrying.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	st.updates = 0
+	svc := handlers.NewItemsService(st)
+	patch := api.PatchItemApplicationMergePatchPlusJSONRequestBody{}
+	if _, err := svc.PatchItem(ctx, api.PatchItemRequestObject{Id: 1, ApplicationMergePatchPlusJSONBody: &patch}); !errors.Is(err, context.Canceled) || st.updates != 0 {
+		t.Errorf("canceled patch: %v after %d updates, want context.Canceled before any", err, st.updates)
+	}
+}
+
+func TestItemsRequireIfMatch(t *testing.T) {
+	svc := handlers.NewItemsService(store.NewMemory())
+	svc.RequireIfMatch = true
+	s := newServiceServer(t, svc)
+	s.expect("POST", "/items", `{"name":"first"}`, http.StatusCreated, nil)
+
+	s.expect("PUT", "/items/1", `{"name":"x"}`, http.StatusPreconditionRequired, nil)
+	s.expectPatch("/items/1", mergePatch, `{"name":"x"}`, http.StatusPreconditionRequired, nil)
+	s.expect("DELETE", "/items/1", "", http.StatusPreconditionRequired, nil)
+
+	s.expectHeader("PUT", "/items/1", `{"name":"x"}`, "If-Match", `"1"`, http.StatusOK)
+	s.expectHeader("DELETE", "/items/1", "", "If-Match", `"2"`, http.StatusNoContent)
+}
+
+func TestItemsNotFound(t *testing.T) {
+	s := newServer(t, store.NewMemory())
+	var e api.Error
+	s.expect("GET", "/items/7", "", http.StatusNotFound, &e)
+	if e.Message != "item 7 not found" {
+		t.Errorf("message = %q", e.Message)
+	}
+	s.expect("PUT", "/items/7", `{"name":"x"}`, http.StatusNotFound, nil)
+	s.expect("DELETE", "/items/7", "", http.StatusNotFound, nil)
+}
+
+func TestItemsBadRequest(t *testing.T) {
+	s := newServer(t, store.NewMemory())
+	s.expect("POST", "/items", `{}`, http.StatusBadRequest, nil)
+	s.expect("POST", "/items", `{"name":`, http.StatusBadRequest, nil)
+	s.expect("POST", "/items", `{"name":42}`, http.StatusBadRequest, nil)
+	s.expect("GET", "/items/abc", "", http.StatusBadRequest, nil)
+	s.expect("PUT", "/items/1", `{"title":"x"}`, http.StatusBadRequest, nil)
+	s.expect("DELETE", "/items/abc", "", http.StatusBadRequest, nil)
+	s.expect("GET", "/items?limit=0", "", http.StatusBadRequest, nil)
+	s.expect("GET", "/items?limit=101", "", http.StatusBadRequest, nil)
+	s.expect("GET", "/items?sort=color", "", http.StatusBadRequest, nil)
+	s.expect("GET", "/items?cursor=not-a-cursor", "", http.StatusBadRequest, nil)
+}
+
+func TestItemsValidationProblems(t *testing.T) {
+	s := newServer(t, store.NewMemory())
+	s.expect("POST", "/items", `{"name":"first"}`, http.StatusCreated, nil)
+
+	// field is an api.ProblemField without its detail, which is for humans.
+	type field struct{ in, parameter, pointer, constraint string }
+	for _, tc := range []struct {
+		method, path, body string
+		want               []field
+	}{
+		{"POST", "/items", `{}`, []field{{"body", "", "/name", "required"}}},
+		{"POST", "/items", `{"name":`, []field{{"body", "", "", "syntax"}}},
+		{"POST", "/items", `{"name":"","tags":["Upper","Upper"],"price":"1.234","color":"red"}`, []field{
+			{"body", "", "", "additionalProperties"},
+			{"body", "", "/name", "minLength"},
+			{"body", "", "/name", "pattern"},
+			{"body", "", "/price", "pattern"},
+			{"body", "", "/tags", "uniqueItems"},
+			{"body", "", "/tags/0", "pattern"},
+			{"body", "", "/tags/1", "pattern"},
+		}},
+		{"PUT", "/items/1", `{"name":"x","status":"gone"}`, []field{{"body", "", "/status", "enum"}}},
+		{"PATCH", "/items/1", `{"name":"x"}`, []field{{"body", "", "", "contentType"}}},
+		{"GET", "/items?limit=0&sort=color", "", []field{{"query", "limit", "", "minimum"}, {"query", "sort", "", "enum"}}},
+		{"GET", "/items?limit=abc", "", []field{{"query", "limit", "", "type"}}},
+		{"GET", "/items/abc", "", []field{{"path", "id", "", "type"}}},
+	} {
+		rec := s.do(tc.method, tc.path, tc.body)
+		what := tc.method + " " + tc.path + " " + tc.body
+		if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+			t.Errorf("%s: Content-Type %q", what, ct)
+		}
+		var p api.Problem
+		s.check(rec, what, http.StatusBadRequest, &p)
+		if p.Type != "about:blank" || p.Title != "Bad Request" || p.Status != http.StatusBadRequest || p.Instance == nil || *p.Instance != strings.Split(tc.path, "?")[0] {
+			t.Errorf("%s: problem %+v", what, p)
+		}
+		var got []field
+		if p.Errors != nil {
+			for _, f := range *p.Errors {
+				if f.Detail == "" {
+					t.Errorf("%s: %+v has no detail", what, f)
+				}
+				var parameter string
+				if f.Parameter != nil {
+					parameter = *f.Parameter
+				}
+				got = append(got, field{string(f.In), parameter, f.Pointer, f.Constraint})
+			}
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s: fields %+v, want %+v", what, got, tc.want)
+		}
+	}
+
+	// Paths and methods the spec does not have are problems too, without fields.
+	for _, tc := range []struct {
+		method, path string
+		status       int
+	}{
+		{"GET", "/nothing", http.StatusNotFound},
+		{"POST", "/items/1", http.StatusMethodNotAllowed},
+	} {
+		rec := httptest.NewRecorder()
+		s.handler.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		var p api.Problem
+		s.check(rec, tc.method+" "+tc.path, tc.status, &p)
+		if p.Status != tc.status || p.Errors != nil {
+			t.Errorf("%s %s: problem %+v", tc.method, tc.path, p)
+		}
+	}
+}
+
+// brokenStore fails every operation, like a database that went away.
+type brokenStore struct{ store.ItemStore }
+
+var errBroken = errors.New("connection refused")
+
+func (brokenStore) List(context.Context) ([]store.Item, error) { return nil, errBroken }
+func (brokenStore) ListPage(context.Context, store.ListOptions) (store.Page, error) {
+	return store.Page{}, errBroken
+}
+func (brokenStore) Get(context.Context, int64) (store.Item, error) { return store.Item{}, errBroken }
+func (brokenStore) Create(context.Context, store.Item) (store.Item, error) {
+	return store.Item{}, errBroken
+}
+func (brokenStore) Update(context.Context, store.Item) (store.Item, error) {
+	return store.Item{}, errBroken
+}
+func (brokenStore) Delete(context.Context, int64, int64) error { return errBroken }
+
+func TestItemsStoreFailure(t *testing.T) {
+	s := newServer(t, brokenStore{})
+	var e api.Error
+	s.expect("GET", "/items", "", http.StatusInternalServerError, &e)
+	if strings.Contains(e.Message, errBroken.Error()) {
+		t.Errorf("500 leaks the cause: %q", e.Message)
+	}
+	s.expect("GET", "/items/1", "", http.StatusInternalServerError, nil)
+	s.expect("POST", "/items", `{"name":"x"}`, http.StatusInternalServerError, nil)
+	s.expect("PUT", "/items/1", `{"name":"x"}`, http.StatusInternalServerError, nil)
+	s.expectPatch("/items/1", mergePatch, `{"name":"x"}`, http.StatusInternalServerError, nil)
+	s.expect("DELETE", "/items/1", "", http.StatusInternalServerError, nil)
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"go-openapi-demo/api"
+	apiv2 "go-openapi-demo/api/v2"
+)
+
+// ItemsServiceV2 implements version 2 of the API (v2/openapi.yaml) as an
+// adapter over the service of version 1: it converts the requests and the
+// items of the responses, and passes on the other responses, whose bodies
+// both versions share. Both versions thus serve the same store, with the same
+// rules, ETags and cursors.
+//
+// Version 2 prices items in cents (priceCents: 1250) where version 1 has
+// decimal strings (price: "12.50"), and patches them with merge patches only.
+type ItemsServiceV2 struct {
+	v1 *ItemsService
+}
+
+// NewItemsServiceV2 returns a service serving the items of v1.
+func NewItemsServiceV2(v1 *ItemsService) *ItemsServiceV2 {
+	return &ItemsServiceV2{v1: v1}
+}
+
+// Compile-time check that the service implements the generated interface.
+var _ apiv2.StrictServerInterface = (*ItemsServiceV2)(nil)
+
+// GetItems returns one page of the items; see ItemsService.GetItems.
+func (s *ItemsServiceV2) GetItems(ctx context.Context, req apiv2.GetItemsRequestObject) (apiv2.GetItemsResponseObject, error) {
+	p := req.Params
+	rsp, err := s.v1.GetItems(ctx, api.GetItemsRequestObject{Params: api.GetItemsParams{
+		Limit:       p.Limit,
+		Cursor:      p.Cursor,
+		Sort:        (*api.GetItemsParamsSort)(p.Sort),
+		Q:           p.Q,
+		IfNoneMatch: p.IfNoneMatch,
+	}})
+	if ok, isOK := rsp.(api.GetItems200JSONResponse); isOK {
+		page := apiv2.ItemPage{Items: make([]apiv2.Item, len(ok.Body.Items)), NextCursor: ok.Body.NextCursor, Total: ok.Body.Total}
+		for i, it := range ok.Body.Items {
+			if page.Items[i], err = toAPIV2(it); err != nil {
+				return nil, err
+			}
+		}
+		return apiv2.GetItems200JSONResponse{Body: page, Headers: apiv2.GetItems200ResponseHeaders(ok.Headers)}, nil
+	}
+	return rsp, err
+}
+
+// GetItemById returns one item; see ItemsService.GetItemById.
+func (s *ItemsServiceV2) GetItemById(ctx context.Context, req apiv2.GetItemByIdRequestObject) (apiv2.GetItemByIdResponseObject, error) {
+	rsp, err := s.v1.GetItemById(ctx, api.GetItemByIdRequestObject{Id: req.Id, Params: api.GetItemByIdParams(req.Params)})
+	if ok, isOK := rsp.(api.GetItemById200JSONResponse); isOK {
+		it, err := toAPIV2(ok.Body)
+		if err != nil {
+			return nil, err
+		}
+		return apiv2.GetItemById200JSONResponse{Body: it, Headers: apiv2.GetItemById200ResponseHeaders(ok.Headers)}, nil
+	}
+	return rsp, err
+}
+
+// CreateItem creates an item; see ItemsService.CreateItem.
+func (s *ItemsServiceV2) CreateItem(ctx context.Context, req apiv2.CreateItemRequestObject) (apiv2.CreateItemResponseObject, error) {
+	v1 := api.CreateItemRequestObject{}
+	if req.Body != nil {
+		body := api.ItemCreate(fromAPIV2(apiv2.ItemUpdate(*req.Body)))
+		v1.Body = &body
+	}
+	rsp, err := s.v1.CreateItem(ctx, v1)
+	if ok, isOK := rsp.(api.CreateItem201JSONResponse); isOK {
+		it, err := toAPIV2(ok.Body)
+		if err != nil {
+			return nil, err
+		}
+		return apiv2.CreateItem201JSONResponse{Body: it, Headers: apiv2.CreateItem201ResponseHeaders(ok.Headers)}, nil
+	}
+	return rsp, err
+}
+
+// UpdateItem replaces the fields of an item; see ItemsService.UpdateItem.
+func (s *ItemsServiceV2) UpdateItem(ctx context.Context, req apiv2.UpdateItemRequestObject) (apiv2.UpdateItemResponseObject, error) {
+	v1 := api.UpdateItemRequestObject{Id: req.Id, Params: api.UpdateItemParams(req.Params)}
+	if req.Body != nil {
+		body := fromAPIV2(*req.Body)
+		v1.Body = &body
+	}
+	rsp, err := s.v1.UpdateItem(ctx, v1)
+	if ok, isOK := rsp.(api.UpdateItem200JSONResponse); isOK {
+		it, err := toAPIV2(ok.Body)
+		if err != nil {
+			return nil, err
+		}
+		return apiv2.UpdateItem200JSONResponse{Body: it, Headers: apiv2.UpdateItem200ResponseHeaders(ok.Headers)}, nil
+	}
+	return rsp, err
+}
+
+// PatchItem applies a merge patch to an item; see ItemsService.PatchItem.
+func (s *ItemsServiceV2) PatchItem(ctx context.Context, req apiv2.PatchItemRequestObject) (apiv2.PatchItemResponseObject, error) {
+	v1 := api.PatchItemRequestObject{Id: req.Id, Params: api.PatchItemParams(req.Params)}
+	if req.Body != nil {
+		patch, err := mergePatchV1(*req.Body)
+		var pe *patchError
+		if errors.As(err, &pe) {
+			return apiv2.PatchItem422JSONResponse{UnprocessableEntityJSONResponse: apiv2.UnprocessableEntityJSONResponse{Message: pe.Error()}}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		v1.ApplicationMergePatchPlusJSONBody = &patch
+	}
+	rsp, err := s.v1.PatchItem(ctx, v1)
+	if ok, isOK := rsp.(api.PatchItem200JSONResponse); isOK {
+		it, err := toAPIV2(ok.Body)
+		if err != nil {
+			return nil, err
+		}
+		return apiv2.PatchItem200JSONResponse{Body: it, Headers: apiv2.PatchItem200ResponseHeaders(ok.Headers)}, nil
+	}
+	return rsp, err
+}
+
+// DeleteItem removes an item; see ItemsService.DeleteItem.
+func (s *ItemsServiceV2) DeleteItem(ctx context.Context, req apiv2.DeleteItemRequestObject) (apiv2.DeleteItemResponseObject, error) {
+	return s.v1.DeleteItem(ctx, api.DeleteItemRequestObject{Id: req.Id, Params: api.DeleteItemParams(req.Params)})
+}
+
+// toAPIV2 converts an item of version 1 into one of version 2.
+func toAPIV2(it api.Item) (apiv2.Item, error) {
+	out := apiv2.Item{
+		Id:          it.Id,
+		Name:        it.Name,
+		Description: it.Description,
+		Tags:        it.Tags,
+		Status:      apiv2.ItemStatus(it.Status),
+		Version:     it.Version,
+		CreatedAt:   it.CreatedAt,
+		UpdatedAt:   it.UpdatedAt,
+	}
+	if it.Price != nil {
+		cents, err := priceCents(*it.Price)
+		if err != nil {
+			return apiv2.Item{}, fmt.Errorf("item %d: %w", it.Id, err)
+		}
+		out.PriceCents = &cents
+	}
+	return out, nil
+}
+
+// fromAPIV2 converts the client's fields of an item of version 2 into those
+// of version 1. ItemCreate converts to ItemUpdate in both versions.
+func fromAPIV2(body apiv2.ItemUpdate) api.ItemUpdate {
+	out := api.ItemUpdate{
+		Name:        body.Name,
+		Description: body.Description,
+		Tags:        body.Tags,
+		Status:      (*api.ItemStatus)(body.Status),
+	}
+	if body.PriceCents != nil {
+		price := priceDecimal(*body.PriceCents)
+		out.Price = &price
+	}
+	return out
+}
+
+// priceSchema is the ItemPriceCents schema of the embedded v2/openapi.yaml,
+// loaded on first use.
+var priceSchema = sync.OnceValues(func() (*openapi3.Schema, error) {
+	swagger, err := apiv2.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	ref := swagger.Components.Schemas["ItemPriceCents"]
+	if ref == nil || ref.Value == nil {
+		return nil, errors.New("v2/openapi.yaml has no ItemPriceCents schema")
+	}
+	return ref.Value, nil
+})
+
+// mergePatchV1 converts a merge patch of an item of version 2 into one of
+// version 1, which names the price differently. A priceCents that is not
+// a valid price, or a price, returns a *patchError.
+func mergePatchV1(p apiv2.ItemMergePatch) (api.ItemMergePatch, error) {
+	out := maps.Clone(p)
+	if _, ok := out["price"]; ok {
+		return nil, &patchError{errors.New(`patched item is invalid: property "price" is unsupported, prices are priceCents`)}
+	}
+	v, ok := out["priceCents"]
+	if !ok {
+		return out, nil
+	}
+	delete(out, "priceCents")
+	if v == nil {
+		out["price"] = nil // removes the price
+		return out, nil
+	}
+	schema, err := priceSchema()
+	if err != nil {
+		return nil, err
+	}
+	f, isNumber := v.(float64) // as decoded from JSON
+	if err := schema.VisitJSON(v); err != nil || !isNumber {
+		return nil, &patchError{fmt.Errorf("patched item is invalid: priceCents %v is not a price in cents", v)}
+	}
+	out["price"] = priceDecimal(int64(f))
+	return out, nil
+}
+
+// priceDecimal returns the decimal price of version 1, e.g. "12.50", of a
+// price in cents.
+func priceDecimal(cents int64) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+// priceCents returns the price in cents of a decimal price of version 1,
+// e.g. 1250 for "12.5" or "12.50".
+func priceCents(price string) (int64, error) {
+	units, fraction, _ := strings.Cut(price, ".")
+	if len(fraction) > 2 {
+		return 0, fmt.Errorf("price %q has more than 2 decimals", price)
+	}
+	cents, err := strconv.ParseInt(units+(fraction + "00")[:2], 10, 64)
+	if err != nil || cents < 0 {
+		return 0, fmt.Errorf("price %q is not a decimal price", price)
+	}
+	return cents, nil
+}
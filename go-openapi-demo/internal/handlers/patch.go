@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"go-openapi-demo/api"
+)
+
+// itemSchema is the Item schema of the embedded openapi.yaml, loaded on first use.
+var itemSchema = sync.OnceValues(func() (*openapi3.Schema, error) {
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	ref := swagger.Components.Schemas["Item"]
+	if ref == nil || ref.Value == nil {
+		return nil, errors.New("openapi.yaml has no Item schema")
+	}
+	return ref.Value, nil
+})
+
+// patchItem applies the merge patch (RFC 7396) or JSON patch (RFC 6902) of req
+// to the JSON form of it. The result must be a valid Item, with no fields the
+// schema does not know, and keep the fields set by the server: id, version,
+// createdAt and updatedAt. A patch that fails any of this returns a
+// *patchError, answered with 422; other errors are internal.
+func patchItem(it api.Item, req api.PatchItemRequestObject) (api.Item, error) {
+	doc, err := json.Marshal(it)
+	if err != nil {
+		return api.Item{}, err
+	}
+
+	// The bodies were decoded by the generated handler; the patch libraries
+	// take JSON again. Merge patches are maps, so null values survive the round trip.
+	var patched []byte
+	switch {
+	case req.ApplicationMergePatchPlusJSONBody != nil:
+		p, err := json.Marshal(*req.ApplicationMergePatchPlusJSONBody)
+		if err != nil {
+			return api.Item{}, err
+		}
+		if patched, err = jsonpatch.MergePatch(doc, p); err != nil {
+			return api.Item{}, &patchError{err}
+		}
+	case req.ApplicationJSONPatchPlusJSONBody != nil:
+		for _, op := range *req.ApplicationJSONPatchPlusJSONBody {
+			// The library panics testing the whole item against a missing or
+			// null value, which the item never equals.
+			if op.Op == api.Test && op.Path == "" && op.Value == nil {
+				return api.Item{}, &patchError{jsonpatch.ErrTestFailed}
+			}
+		}
+		p, err := json.Marshal(*req.ApplicationJSONPatchPlusJSONBody)
+		if err != nil {
+			return api.Item{}, err
+		}
+		ops, err := jsonpatch.DecodePatch(p)
+		if err != nil {
+			return api.Item{}, &patchError{err}
+		}
+		if patched, err = ops.Apply(doc); err != nil {
+			return api.Item{}, &patchError{err}
+		}
+	default:
+		return api.Item{}, &patchError{errors.New("request body required")}
+	}
+
+	// Validate the result against the schema, which also rejects fields it
+	// does not list, then decode it.
+	schema, err := itemSchema()
+	if err != nil {
+		return api.Item{}, err
+	}
+	var v any
+	if err := json.Unmarshal(patched, &v); err != nil {
+		return api.Item{}, err
+	}
+	if err := schema.VisitJSON(v); err != nil {
+		return api.Item{}, &patchError{fmt.Errorf("patched item is invalid: %w", err)}
+	}
+	var out api.Item
+	if err := json.Unmarshal(patched, &out); err != nil {
+		return api.Item{}, &patchError{fmt.Errorf("patched item is invalid: %w", err)}
+	}
+	switch {
+	case out.Id != it.Id:
+		return api.Item{}, &patchError{errors.New("id cannot be changed")}
+	case out.Version != it.Version:
+		return api.Item{}, &patchError{errors.New("version cannot be changed")}
+	case !out.CreatedAt.Equal(it.CreatedAt), !out.UpdatedAt.Equal(it.UpdatedAt):
+		return api.Item{}, &patchError{errors.New("createdAt and updatedAt cannot be changed")}
+	}
+	return out, nil
+}
+
+// patchError is a patch that cannot be applied, or whose result is not a valid Item.
+type patchError struct{ err error }
+
+func (e *patchError) Error() string { return e.err.Error() }
+func (e *patchError) Unwrap() error { return e.err }
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	oapimw "github.com/oapi-codegen/nethttp-middleware"
+
+	"go-openapi-demo/api"
+)
+
+// ValidationProblem answers requests rejected by the OpenAPI request validator
+// with problem details (RFC 7807) listing every failing field; it matches the
+// validator's ErrorHandlerWithOpts signature. The validator must run with
+// MultiError set, or it stops at the first failing field. Requests for paths
+// or methods the spec does not have are answered with 404 or 405.
+func ValidationProblem(_ context.Context, err error, w http.ResponseWriter, r *http.Request, opts oapimw.ErrorHandlerOpts) {
+	status := opts.StatusCode
+	if errors.Is(err, routers.ErrMethodNotAllowed) {
+		status = http.StatusMethodNotAllowed
+	}
+	if status == http.StatusInternalServerError {
+		// The spec could not be applied to the request; not the client's fault.
+		log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+		WriteProblem(w, r, status, "internal error", nil)
+		return
+	}
+
+	fields := problemFields(err)
+	detail := err.Error()
+	switch len(fields) {
+	case 0:
+	case 1:
+		detail = "1 field is invalid"
+	default:
+		detail = fmt.Sprintf("%d fields are invalid", len(fields))
+	}
+	WriteProblem(w, r, status, detail, fields)
+}
+
+// WriteProblem writes problem details of the status for the request; fields may be nil.
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, detail string, fields []api.ProblemField) {
+	p := problem(status, detail, fields)
+	p.Instance = &r.URL.Path
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// problem returns the problem details of a plain HTTP error with the status.
+func problem(status int, detail string, fields []api.ProblemField) api.Problem {
+	p := api.Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: &detail}
+	if len(fields) > 0 {
+		p.Errors = &fields
+	}
+	return p
+}
+
+// problemFields lists the failing fields of an error returned by
+// openapi3filter.ValidateRequest, in the order the validator found them.
+func problemFields(err error) []api.ProblemField {
+	// Type switches rather than errors.As: a MultiError is "as" any of its errors.
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var fields []api.ProblemField
+		for _, err := range e {
+			fields = append(fields, problemFields(err)...)
+		}
+		return fields
+	case *openapi3filter.RequestError:
+		field := api.ProblemField{In: api.Body}
+		if p := e.Parameter; p != nil {
+			field.In, field.Parameter = api.ProblemFieldIn(p.In), &p.Name
+		}
+		return causeFields(field, e)
+	}
+	return nil
+}
+
+// causeFields lists the failing fields of the body or parameter of field
+// that e rejects.
+func causeFields(field api.ProblemField, e *openapi3filter.RequestError) []api.ProblemField {
+	var fields []api.ProblemField
+	var walk func(err error)
+	walk = func(err error) {
+		f := field
+		var parseErr *openapi3filter.ParseError
+		switch cause := err.(type) {
+		case openapi3.MultiError:
+			for _, err := range cause {
+				walk(err)
+			}
+			return
+		case *openapi3.SchemaError:
+			f.Pointer = jsonPointer(cause.JSONPointer())
+			f.Constraint, f.Detail = cause.SchemaField, cause.Reason
+			if f.Constraint == "properties" { // reported for a property the schema does not list
+				f.Constraint = "additionalProperties"
+			}
+			if f.Detail == "" && cause.Origin != nil {
+				f.Detail = cause.Origin.Error()
+			}
+		case nil: // the body has a content type the operation does not accept
+			f.Constraint, f.Detail = "contentType", e.Reason
+		default:
+			switch {
+			case errors.Is(err, openapi3filter.ErrInvalidRequired):
+				f.Constraint = "required"
+			case errors.Is(err, openapi3filter.ErrInvalidEmptyValue):
+				f.Constraint = "allowEmptyValue"
+			case errors.As(err, &parseErr) && f.In == api.Body:
+				f.Constraint = "syntax" // not JSON at all
+			case errors.As(err, &parseErr):
+				f.Constraint = "type" // e.g. a non-integer ID
+			default:
+				f.Constraint = "schema"
+			}
+			f.Detail = err.Error()
+		}
+		fields = append(fields, f)
+	}
+	walk(e.Err)
+	return fields
+}
+
+// jsonPointer joins the tokens of a path into a JSON pointer (RFC 6901).
+func jsonPointer(tokens []string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(t))
+	}
+	return b.String()
+}
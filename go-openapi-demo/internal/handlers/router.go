@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/go-chi/chi/v5"
+	oapimw "github.com/oapi-codegen/nethttp-middleware"
+
+	"go-openapi-demo/api"
+	apiv2 "go-openapi-demo/api/v2"
+)
+
+// Version is a version of the items API mounted at a base URL, made by V1 or V2.
+type Version struct {
+	baseURL string
+	spec    func() (*openapi3.T, error)        // GetSwagger of the generated package
+	routes  func(r chi.Router, baseURL string) // HandlerWithOptions of the generated package
+}
+
+// V1 serves version 1 of the API (openapi.yaml) from svc under baseURL, e.g.
+// "/v1"; "" serves it at the root, where it was before there were versions.
+func V1(baseURL string, svc api.StrictServerInterface) Version {
+	return Version{baseURL: baseURL, spec: api.GetSwagger, routes: func(r chi.Router, baseURL string) {
+		api.HandlerWithOptions(api.NewStrictHandlerWithOptions(svc, nil, StrictOptions()), api.ChiServerOptions{
+			BaseURL:          baseURL,
+			BaseRouter:       r,
+			ErrorHandlerFunc: BadRequest,
+		})
+	}}
+}
+
+// V2 serves version 2 of the API (v2/openapi.yaml) from svc under baseURL,
+// e.g. "/v2"; see ItemsServiceV2.
+func V2(baseURL string, svc apiv2.StrictServerInterface) Version {
+	return Version{baseURL: baseURL, spec: apiv2.GetSwagger, routes: func(r chi.Router, baseURL string) {
+		apiv2.HandlerWithOptions(apiv2.NewStrictHandlerWithOptions(svc, nil, apiv2.StrictHTTPServerOptions(StrictOptions())), apiv2.ChiServerOptions{
+			BaseURL:          baseURL,
+			BaseRouter:       r,
+			ErrorHandlerFunc: BadRequest,
+		})
+	}}
+}
+
+// NewRouter returns the chi router serving the versions of the items API,
+// each under its base URL:
+//   - every request is validated against the spec of its version (paths,
+//     params, headers, and JSON schema for bodies) and rejected with an
+//     api.Problem listing every failing field if it doesn't match (see
+//     ValidationProblem);
+//   - the routes generated by oapi-codegen decode the request and call the
+//     typed method of the version's service through the strict handler;
+//   - the operations the spec marks deprecated answer with Deprecation and
+//     Sunset headers (see deprecations).
+//
+// Requests for paths or methods no version has are answered with 404 or 405.
+func NewRouter(versions ...Version) (http.Handler, error) {
+	r := chi.NewRouter()
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		WriteProblem(w, r, http.StatusNotFound, "no operation has the path "+r.URL.Path, nil)
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		WriteProblem(w, r, http.StatusMethodNotAllowed, fmt.Sprintf("no operation has the method %s for the path %s", r.Method, r.URL.Path), nil)
+	})
+
+	for _, v := range versions {
+		swagger, err := v.spec()
+		if err != nil {
+			return nil, err
+		}
+		// The validator matches the request paths against the servers of the
+		// spec: the base URL, rather than the public URLs the spec lists.
+		swagger.Servers = nil
+		if v.baseURL != "" {
+			swagger.Servers = openapi3.Servers{{URL: v.baseURL}}
+		}
+		deprecated, err := deprecations(swagger, v.baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("API %s at %q: %w", swagger.Info.Version, v.baseURL, err)
+		}
+
+		r.Group(func(r chi.Router) {
+			r.Use(deprecate(deprecated), oapimw.OapiRequestValidatorWithOptions(swagger, &oapimw.Options{
+				Options:               openapi3filter.Options{MultiError: true},
+				ErrorHandlerWithOpts:  ValidationProblem,
+				SilenceServersWarning: true, // the base URL has no host to check
+			}))
+			v.routes(r, v.baseURL)
+		})
+	}
+	return r, nil
+}
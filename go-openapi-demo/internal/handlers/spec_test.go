@@ -0,0 +1,54 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"go-openapi-demo/api"
+	apiv2 "go-openapi-demo/api/v2"
+	"go-openapi-demo/internal/handlers"
+	"go-openapi-demo/internal/handlers/spectest"
+	"go-openapi-demo/internal/store"
+)
+
+// FuzzSpec sends requests generated from the spec of every version to the
+// router as cmd/server serves it: the chi routes generated by oapi-codegen
+// behind the request validator, under the base URL of the version. Every
+// response must honor the spec. The versions share the store, so each finds
+// the items of the other. go test runs the seeds below and those in
+// testdata; go test -fuzz=FuzzSpec tries others.
+func FuzzSpec(f *testing.F) {
+	for seed := range uint64(4) {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed uint64) {
+		svc := handlers.NewItemsService(store.NewMemory())
+		h, err := handlers.NewRouter(
+			handlers.V1("", svc),
+			handlers.V1("/v1", svc),
+			handlers.V2("/v2", handlers.NewItemsServiceV2(svc)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range []struct {
+			baseURL string
+			spec    func() (*openapi3.T, error)
+		}{
+			{"", api.GetSwagger},
+			{"/v1", api.GetSwagger},
+			{"/v2", apiv2.GetSwagger},
+		} {
+			swagger, err := v.spec()
+			if err != nil {
+				t.Fatal(err)
+			}
+			swagger.Servers = nil
+			if v.baseURL != "" {
+				swagger.Servers = openapi3.Servers{{URL: v.baseURL}}
+			}
+			spectest.Run(t, swagger, h, spectest.Options{Seed: seed})
+		}
+	})
+}
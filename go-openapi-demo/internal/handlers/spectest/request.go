@@ -0,0 +1,233 @@
+package spectest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// request is a generated request for an operation.
+type request struct {
+	op        operation
+	params    map[*openapi3.Parameter]string // raw values of the parameters sent
+	mediaType string                         // of the body
+	body      []byte                         // nil if none is sent
+	broken    string                         // what the request breaks, e.g. "/name: pattern"; "" if valid
+}
+
+// http returns the request to send; it may be called again for a fresh body.
+func (r *request) http() *http.Request {
+	path := r.op.path
+	query := url.Values{}
+	header := http.Header{}
+	for p, v := range r.params {
+		switch p.In {
+		case openapi3.ParameterInPath:
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", url.PathEscape(v))
+		case openapi3.ParameterInQuery:
+			query.Set(p.Name, v)
+		case openapi3.ParameterInHeader:
+			header.Set(p.Name, v)
+		case openapi3.ParameterInCookie:
+			header.Add("Cookie", (&http.Cookie{Name: p.Name, Value: v}).String())
+		}
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	req := httptest.NewRequest(r.op.method, path, bytes.NewReader(r.body))
+	req.Header = header
+	if r.body != nil {
+		req.Header.Set("Content-Type", r.mediaType)
+	}
+	return req
+}
+
+// String describes the request for a report, e.g.
+// PATCH /items/3 If-Match: "2" application/merge-patch+json {"name":"x"}.
+func (r *request) String() string {
+	req := r.http()
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", req.Method, req.URL.RequestURI())
+	for _, k := range slices.Sorted(maps.Keys(req.Header)) {
+		if k != "Content-Type" {
+			fmt.Fprintf(&b, " %s: %s", k, strings.Join(req.Header[k], ", "))
+		}
+	}
+	if r.body != nil {
+		fmt.Fprintf(&b, " %s %s", r.mediaType, r.body)
+	}
+	if r.broken != "" {
+		fmt.Fprintf(&b, " (breaks %s)", r.broken)
+	}
+	return b.String()
+}
+
+// request generates a valid request for op and, if invalid, breaks one
+// constraint of it. Operations without a constraint to break get a valid one.
+func (g *generator) request(op operation, invalid bool) *request {
+	r := &request{op: op, params: map[*openapi3.Parameter]string{}}
+	values := map[*openapi3.Parameter]any{}
+	for _, ref := range op.params {
+		p := ref.Value
+		if p.In != openapi3.ParameterInPath && !p.Required && g.rnd.IntN(2) == 0 {
+			continue
+		}
+		values[p] = g.param(p)
+		r.params[p] = format(values[p])
+	}
+
+	var body any
+	var schema *openapi3.Schema
+	if op.RequestBody != nil {
+		rb := op.RequestBody.Value
+		types := slices.Sorted(maps.Keys(rb.Content))
+		r.mediaType = types[g.rnd.IntN(len(types))]
+		if mt := rb.Content[r.mediaType]; mt.Schema != nil {
+			schema = mt.Schema.Value
+		}
+		if schema != nil && (rb.Required || g.rnd.IntN(2) == 0) {
+			body = g.value(schema, op.ID()+" body")
+			r.body = marshal(body)
+		}
+	}
+	if !invalid {
+		return r
+	}
+
+	// Every way of breaking the request, one of which is picked.
+	var breaks []func()
+	for _, ref := range op.params {
+		p := ref.Value
+		if p.Required && p.In != openapi3.ParameterInPath {
+			breaks = append(breaks, func() {
+				delete(r.params, p)
+				r.broken = fmt.Sprintf("%s %s: required", p.In, p.Name)
+			})
+		}
+		if p.Schema == nil {
+			continue
+		}
+		v, ok := values[p]
+		if !ok {
+			v = g.param(p)
+		}
+		if bad, constraint, ok := g.invalid(p.Schema.Value, v, "", func(v any) bool { return paramValid(p.Schema.Value, v) }); ok {
+			breaks = append(breaks, func() {
+				r.params[p] = format(bad)
+				r.broken = fmt.Sprintf("%s %s%s", p.In, p.Name, constraint)
+			})
+		}
+	}
+	if schema != nil {
+		if op.RequestBody.Value.Required {
+			breaks = append(breaks, func() {
+				r.body = nil
+				r.broken = "body: required"
+			})
+		}
+		if body == nil {
+			body = g.value(schema, op.ID()+" body")
+		}
+		valid := marshal(body)
+		breaks = append(breaks, func() {
+			r.body = valid[:len(valid)-1]
+			r.broken = "body: syntax"
+		})
+		if bad, constraint, ok := g.invalid(schema, body, "", func(v any) bool { return valueValid(schema, v) }); ok {
+			breaks = append(breaks, func() {
+				r.body = marshal(bad)
+				r.broken = "body" + constraint
+			})
+		}
+	}
+	if len(breaks) > 0 {
+		breaks[g.rnd.IntN(len(breaks))]()
+	}
+	return r
+}
+
+// param returns a valid value of the parameter, empty only if the parameter
+// allows empty values. String headers often get an ETag the handler sent, so
+// conditional requests can succeed.
+func (g *generator) param(p *openapi3.Parameter) any {
+	if p.Schema == nil {
+		return g.letters(1, 8)
+	}
+	s := p.Schema.Value
+	if p.In == openapi3.ParameterInHeader && s.Type.Is(openapi3.TypeString) && len(s.Enum) == 0 && len(g.etags) > 0 && g.rnd.IntN(3) > 0 {
+		if v := g.etags[g.rnd.IntN(len(g.etags))]; valueValid(s, v) {
+			return v
+		}
+	}
+	what := fmt.Sprintf("%s %s", p.In, p.Name)
+	for range 50 {
+		if v := g.value(s, what); p.AllowEmptyValue || format(v) != "" {
+			return v
+		}
+	}
+	g.t.Fatalf("spectest: no non-empty value generated for %s", what)
+	return nil
+}
+
+// format returns the raw form of a parameter value, in the simple and form
+// styles: scalars as they are and the elements of arrays joined by commas.
+func format(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	case []any:
+		s := make([]string, len(v))
+		for i, e := range v {
+			s[i] = format(e)
+		}
+		return strings.Join(s, ",")
+	}
+	return string(marshal(v))
+}
+
+// paramValid reports whether the schema accepts the parameter value v as it
+// is sent, its raw form read as the type of the schema.
+func paramValid(s *openapi3.Schema, v any) bool {
+	raw := format(v)
+	switch {
+	case s.Type.Is(openapi3.TypeInteger), s.Type.Is(openapi3.TypeNumber):
+		f, err := strconv.ParseFloat(raw, 64)
+		return err == nil && valueValid(s, f)
+	case s.Type.Is(openapi3.TypeBoolean):
+		b, err := strconv.ParseBool(raw)
+		return err == nil && valueValid(s, b)
+	case s.Type.Is(openapi3.TypeArray), s.Type.Is(openapi3.TypeObject):
+		return true // not generated as invalid
+	}
+	if _, ok := v.(string); !ok {
+		// Sent for a string, a number is its digits: it breaks the type only
+		// in JSON, and the constraint it breaks in the URL is another.
+		return true
+	}
+	return valueValid(s, raw)
+}
+
+// marshal returns the JSON of a generated value, which always has one.
+func marshal(v any) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
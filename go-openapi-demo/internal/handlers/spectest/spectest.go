@@ -0,0 +1,164 @@
+// Package spectest checks that an HTTP handler honors its OpenAPI document.
+// It sends the handler requests generated from the schemas of every operation,
+// valid ones and ones breaking a single constraint, and checks every response
+// against the document:
+//
+//	spectest.Run(t, swagger, handler, spectest.Options{Seed: seed})
+//
+// A response fails the check if its status is not declared by the operation,
+// it does not match the declared response, it is a server error (5xx), or it
+// accepts a request breaking the spec.
+package spectest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+// DefaultRequests is the number of requests Run sends when Options.Requests is 0.
+const DefaultRequests = 500
+
+// Options configure Run; the zero value is ready to use.
+type Options struct {
+	// Seed picks the requests: the same seed sends the same requests.
+	Seed uint64
+
+	// Requests is the number of requests sent, DefaultRequests if 0.
+	Requests int
+}
+
+// Run sends h requests for the operations of swagger, in a random order, and
+// reports every response breaking the contract once per operation, status
+// and kind of failure. h keeps its state between the requests, so later ones
+// find what earlier ones created. The paths of the requests start with the
+// path of the first server of swagger, e.g. /v2 for https://example.com/v2,
+// or with / if it has none.
+func Run(t testing.TB, swagger *openapi3.T, h http.Handler, opts Options) {
+	t.Helper()
+	routes, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		t.Fatalf("spectest: %v", err)
+	}
+	ops := operations(swagger)
+	if len(ops) == 0 {
+		t.Fatal("spectest: the spec has no operations")
+	}
+	n := opts.Requests
+	if n == 0 {
+		n = DefaultRequests
+	}
+
+	g := &generator{t: t, rnd: rand.New(rand.NewPCG(opts.Seed, 0))}
+	failures := map[string]bool{}
+	for range n {
+		op := ops[g.rnd.IntN(len(ops))]
+		req := g.request(op, g.rnd.IntN(2) == 0)
+
+		httpReq := req.http()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httpReq)
+		if etag := rec.Header().Get("ETag"); etag != "" && !slices.Contains(g.etags, etag) {
+			g.etags = append(g.etags, etag)
+		}
+
+		kind, reason := check(op, req, rec, routes.FindRoute)
+		key := fmt.Sprintf("%s %d %s", op.ID(), rec.Code, kind)
+		if kind == "" || failures[key] {
+			continue
+		}
+		failures[key] = true
+		t.Errorf("%s: %s\n\trequest (seed %d): %s\n\tresponse: %d %s", op.ID(), reason, opts.Seed, req, rec.Code, strings.TrimSpace(rec.Body.String()))
+	}
+}
+
+// operation is an operation of the spec with the parameters it inherits
+// from its path.
+type operation struct {
+	method, path string // path under the path of the server, e.g. /v2/items/{id}
+	*openapi3.Operation
+	params openapi3.Parameters
+}
+
+// ID names the operation in reports, by its operationId if it has one.
+func (op operation) ID() string {
+	if op.OperationID != "" {
+		return op.OperationID
+	}
+	return op.method + " " + op.path
+}
+
+// operations lists the operations of swagger sorted by path and method.
+func operations(swagger *openapi3.T) []operation {
+	var ops []operation
+	base := ""
+	if len(swagger.Servers) > 0 {
+		if u, err := url.Parse(swagger.Servers[0].URL); err == nil {
+			base = strings.TrimSuffix(u.Path, "/")
+		}
+	}
+	paths := swagger.Paths.Map()
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		item := paths[path]
+		byMethod := item.Operations()
+		for _, method := range slices.Sorted(maps.Keys(byMethod)) {
+			op := byMethod[method]
+			params := slices.Clone(op.Parameters)
+			for _, p := range item.Parameters {
+				if op.Parameters.GetByInAndName(p.Value.In, p.Value.Name) == nil {
+					params = append(params, p)
+				}
+			}
+			ops = append(ops, operation{method: method, path: base + path, Operation: op, params: params})
+		}
+	}
+	return ops
+}
+
+// findRoute matches a request to the operation of the spec it is for.
+type findRoute = func(*http.Request) (*routers.Route, map[string]string, error)
+
+// check returns the kind of failure of the response to req and why, or ""
+// if the response honors the spec.
+func check(op operation, req *request, rec *httptest.ResponseRecorder, find findRoute) (kind, reason string) {
+	if op.Responses.Status(rec.Code) == nil && op.Responses.Default() == nil {
+		return "undeclared", fmt.Sprintf("undeclared status %d", rec.Code)
+	}
+	if rec.Code >= 500 {
+		return "server error", fmt.Sprintf("server error %d", rec.Code)
+	}
+	if req.broken != "" && rec.Code < 400 {
+		return "accepted", fmt.Sprintf("accepted a request breaking %s with %d", req.broken, rec.Code)
+	}
+
+	httpReq := req.http()
+	route, params, err := find(httpReq)
+	if err != nil {
+		return "route", fmt.Sprintf("request matches no operation: %v", err)
+	}
+	err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{Request: httpReq, PathParams: params, Route: route},
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(rec.Body.Bytes())),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	})
+	if err != nil {
+		return "invalid response", fmt.Sprintf("response violates the spec: %v", err)
+	}
+	return "", ""
+}
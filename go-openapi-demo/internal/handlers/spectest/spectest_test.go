@@ -0,0 +1,104 @@
+package spectest_test
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"go-openapi-demo/internal/handlers/spectest"
+)
+
+const spec = `
+openapi: 3.0.3
+info: { title: things, version: "1" }
+paths:
+  /things/{id}:
+    parameters:
+      - { name: id, in: path, required: true, schema: { type: integer, minimum: 1 } }
+    get:
+      operationId: getThing
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties: { name: { type: string } }
+        '400': { description: Bad Request }
+    delete:
+      operationId: deleteThing
+      responses:
+        '204': { description: No Content }
+        '400': { description: Bad Request }
+`
+
+// recorder is a testing.TB recording the errors it gets.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRun(t *testing.T) {
+	swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A handler honoring the spec passes.
+	good := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/things/"), 10, 64); err != nil || id < 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name": "box"}`)
+	})
+	rec := &recorder{TB: t}
+	spectest.Run(rec, swagger, good, spectest.Options{Requests: 100})
+	if len(rec.errors) > 0 {
+		t.Errorf("a handler honoring the spec fails:\n%s", strings.Join(rec.errors, "\n"))
+	}
+
+	// One breaking it fails, once for each operation, status and failure.
+	bad := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name": 7}`)
+	})
+	rec = &recorder{TB: t}
+	spectest.Run(rec, swagger, bad, spectest.Options{Requests: 100})
+	want := []string{
+		"deleteThing: undeclared status 404",
+		"getThing: accepted a request breaking path id: ",
+		"getThing: response violates the spec",
+	}
+	if len(rec.errors) != len(want) {
+		t.Fatalf("a handler breaking the spec fails %d times, want %d:\n%s", len(rec.errors), len(want), strings.Join(rec.errors, "\n"))
+	}
+	for _, w := range want {
+		found := false
+		for _, e := range rec.errors {
+			found = found || strings.HasPrefix(e, w)
+		}
+		if !found {
+			t.Errorf("no failure %q in:\n%s", w, strings.Join(rec.errors, "\n"))
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package spectest
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// generator makes random JSON values (nil, bool, float64, string, []any and
+// map[string]any) valid for a schema, and variants of them that are not.
+type generator struct {
+	t     testing.TB
+	rnd   *rand.Rand
+	etags []string // ETags of the responses so far
+}
+
+// value returns a valid value of the schema of what, e.g. "query limit".
+// Generated values are checked against the schema and generated again until
+// one passes; a schema that none passes ends the test.
+func (g *generator) value(s *openapi3.Schema, what string) any {
+	for range 50 {
+		if v := g.guess(s, what); valueValid(s, v) {
+			return v
+		}
+	}
+	g.t.Fatalf("spectest: no valid value generated for %s", what)
+	return nil
+}
+
+// guess returns a value that is likely valid for the schema.
+func (g *generator) guess(s *openapi3.Schema, what string) any {
+	if len(s.Enum) > 0 {
+		return s.Enum[g.rnd.IntN(len(s.Enum))]
+	}
+	if s.Nullable && g.rnd.IntN(10) == 0 {
+		return nil
+	}
+	types := s.Type.Slice()
+	if len(types) == 0 {
+		types = []string{openapi3.TypeString, openapi3.TypeNumber, openapi3.TypeBoolean, openapi3.TypeNull}
+	}
+	switch types[g.rnd.IntN(len(types))] {
+	case openapi3.TypeString:
+		if s.Pattern != "" {
+			return g.matching(s.Pattern)
+		}
+		hi := int(s.MinLength) + 12
+		if s.MaxLength != nil {
+			hi = min(hi, int(*s.MaxLength))
+		}
+		return g.letters(int(s.MinLength), hi)
+	case openapi3.TypeInteger:
+		lo, hi := bounds(s, 1)
+		return float64(lo + g.rnd.Int64N(hi-lo+1))
+	case openapi3.TypeNumber:
+		lo, hi := bounds(s, 0)
+		return float64(lo) + g.rnd.Float64()*float64(hi-lo)
+	case openapi3.TypeBoolean:
+		return g.rnd.IntN(2) == 0
+	case openapi3.TypeArray:
+		hi := int(s.MinItems) + 3
+		if s.MaxItems != nil {
+			hi = min(hi, int(*s.MaxItems))
+		}
+		a := make([]any, int(s.MinItems)+g.rnd.IntN(max(hi-int(s.MinItems), 0)+1))
+		for i := range a {
+			if s.Items == nil {
+				a[i] = g.letters(1, 8)
+			} else {
+				a[i] = g.value(s.Items.Value, fmt.Sprintf("%s/%d", what, i))
+			}
+		}
+		return a
+	case openapi3.TypeObject:
+		o := map[string]any{}
+		for _, k := range slices.Sorted(maps.Keys(s.Properties)) {
+			if slices.Contains(s.Required, k) || g.rnd.IntN(2) == 0 {
+				o[k] = g.value(s.Properties[k].Value, what+"/"+k)
+			}
+		}
+		if len(s.Properties) == 0 && (s.AdditionalProperties.Has == nil || *s.AdditionalProperties.Has) {
+			// A free-form object, e.g. a merge patch: a few properties of any kind.
+			extra := &openapi3.Schema{}
+			if ref := s.AdditionalProperties.Schema; ref != nil {
+				extra = ref.Value
+			}
+			for range g.rnd.IntN(3) {
+				k := g.letters(1, 8)
+				o[k] = g.value(extra, what+"/"+k)
+			}
+		}
+		return o
+	}
+	return nil
+}
+
+// bounds returns the range of the integers the schema accepts, at most ten
+// wide: small integers find the items earlier requests created.
+func bounds(s *openapi3.Schema, step int64) (lo, hi int64) {
+	lo, hi = 0, 10
+	if s.Min != nil {
+		lo = int64(*s.Min)
+		if s.ExclusiveMin {
+			lo += step
+		}
+		hi = lo + 10
+	}
+	if s.Max != nil {
+		hi = int64(*s.Max)
+		if s.ExclusiveMax {
+			hi -= step
+		}
+		if s.Min == nil {
+			lo = hi - 10
+		}
+	}
+	return lo, min(hi, lo+10)
+}
+
+// letters returns between lo and hi random lower-case letters.
+func (g *generator) letters(lo, hi int) string {
+	b := make([]byte, lo+g.rnd.IntN(max(hi-lo, 0)+1))
+	for i := range b {
+		b[i] = byte('a' + g.rnd.IntN(26))
+	}
+	return string(b)
+}
+
+// matching returns a random string matching the regular expression, or ""
+// if the pattern does not compile (and the schema will reject it).
+func (g *generator) matching(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	g.match(re.Simplify(), &b)
+	return b.String()
+}
+
+// match appends to b a random string matched by re, preferring printable
+// ASCII where re allows it.
+func (g *generator) match(re *syntax.Regexp, b *strings.Builder) {
+	repeat := func(lo, hi int) {
+		if hi < 0 {
+			hi = lo + 3
+		}
+		for range lo + g.rnd.IntN(hi-lo+1) {
+			g.match(re.Sub[0], b)
+		}
+	}
+	switch re.Op {
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		b.WriteRune(g.rune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteRune(g.rune([]rune{' ', '~'}))
+	case syntax.OpCapture:
+		g.match(re.Sub[0], b)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.match(sub, b)
+		}
+	case syntax.OpAlternate:
+		g.match(re.Sub[g.rnd.IntN(len(re.Sub))], b)
+	case syntax.OpStar:
+		repeat(0, 3)
+	case syntax.OpPlus:
+		repeat(1, 4)
+	case syntax.OpQuest:
+		repeat(0, 1)
+	case syntax.OpRepeat:
+		repeat(re.Min, re.Max)
+	}
+	// Anchors, word boundaries and empty matches add nothing.
+}
+
+// rune returns a random rune of the ranges, given as pairs of first and last
+// rune, from their printable ASCII part if they have one.
+func (g *generator) rune(ranges []rune) rune {
+	var ascii []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		if lo, hi := max(ranges[i], ' '), min(ranges[i+1], '~'); lo <= hi {
+			ascii = append(ascii, lo, hi)
+		}
+	}
+	if len(ascii) > 0 {
+		ranges = ascii
+	}
+	if len(ranges) < 2 {
+		return 'x'
+	}
+	i := 2 * g.rnd.IntN(len(ranges)/2)
+	lo, hi := ranges[i], ranges[i+1]
+	return lo + rune(g.rnd.Int32N(int32(hi-lo)+1))
+}
+
+// invalid returns a variant of the valid value v that valid rejects, the
+// JSON pointer below at of the part it changed and the schema keyword it
+// breaks, e.g. "/tags/0: pattern". It returns false if it finds none.
+func (g *generator) invalid(s *openapi3.Schema, v any, at string, valid func(any) bool) (bad any, broken string, ok bool) {
+	type variant struct {
+		value  any
+		broken string
+	}
+	var variants []variant
+	add := func(keyword string, bad any) {
+		variants = append(variants, variant{bad, at + ": " + keyword})
+	}
+	// Variants of a part of v, checked as part of the whole of v.
+	addPart := func(part *openapi3.Schema, pv any, pat string, set func(any) any) {
+		if bad, broken, ok := g.invalid(part, pv, pat, func(p any) bool { return valid(set(p)) }); ok {
+			variants = append(variants, variant{set(bad), broken})
+		}
+	}
+
+	if s.Type.Permits(openapi3.TypeString) {
+		add("type", 42.0)
+	} else {
+		add("type", "x")
+	}
+	if len(s.Enum) > 0 {
+		add("enum", "not-in-enum")
+	}
+	switch v := v.(type) {
+	case string:
+		if s.MinLength > 0 {
+			add("minLength", string([]rune(v)[:s.MinLength-1]))
+		}
+		if s.MaxLength != nil {
+			add("maxLength", strings.Repeat("x", int(*s.MaxLength)+1))
+		}
+		if s.Pattern != "" {
+			for _, c := range []string{"", " ", " x", "x ", "A B", "?", "-", "1.234"} {
+				if !valid(c) {
+					add("pattern", c)
+					break
+				}
+			}
+		}
+	case float64:
+		if s.Min != nil {
+			add("minimum", *s.Min-1)
+		}
+		if s.Max != nil {
+			add("maximum", *s.Max+1)
+		}
+		if s.Type.Is(openapi3.TypeInteger) {
+			add("type", v+0.5)
+		}
+	case []any:
+		if s.MinItems > 0 {
+			add("minItems", v[:s.MinItems-1])
+		}
+		if s.MaxItems != nil && len(v) > 0 {
+			long := slices.Clone(v)
+			for len(long) <= int(*s.MaxItems) {
+				long = append(long, v[len(long)%len(v)])
+			}
+			add("maxItems", long)
+		}
+		if s.UniqueItems && len(v) > 0 {
+			add("uniqueItems", append(slices.Clone(v), v[0]))
+		}
+		if s.Items != nil && len(v) > 0 {
+			i := g.rnd.IntN(len(v))
+			addPart(s.Items.Value, v[i], fmt.Sprintf("%s/%d", at, i), func(e any) any {
+				a := slices.Clone(v)
+				a[i] = e
+				return a
+			})
+		}
+	case map[string]any:
+		for _, k := range s.Required {
+			if _, ok := v[k]; ok {
+				o := maps.Clone(v)
+				delete(o, k)
+				variants = append(variants, variant{o, at + "/" + k + ": required"})
+			}
+		}
+		if s.AdditionalProperties.Has != nil && !*s.AdditionalProperties.Has {
+			o := maps.Clone(v)
+			o["unexpected"] = "x"
+			variants = append(variants, variant{o, at + "/unexpected: additionalProperties"})
+		}
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			if p := s.Properties[k]; p != nil {
+				addPart(p.Value, v[k], at+"/"+k, func(pv any) any {
+					o := maps.Clone(v)
+					o[k] = pv
+					return o
+				})
+			}
+		}
+	}
+
+	variants = slices.DeleteFunc(variants, func(c variant) bool { return valid(c.value) })
+	if len(variants) == 0 {
+		return nil, "", false
+	}
+	c := variants[g.rnd.IntN(len(variants))]
+	return c.value, c.broken, true
+}
+
+// valueValid reports whether the schema accepts v, read from its JSON like
+// a body is.
+func valueValid(s *openapi3.Schema, v any) bool {
+	var decoded any
+	if err := json.Unmarshal(marshal(v), &decoded); err != nil {
+		return false
+	}
+	return s.VisitJSON(decoded) == nil
+}
@@ -0,0 +1,192 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	"go-openapi-demo/api"
+	apiv2 "go-openapi-demo/api/v2"
+	"go-openapi-demo/internal/handlers"
+	"go-openapi-demo/internal/store"
+)
+
+// anyRoutes finds the route of a request in the first of its routers having it.
+type anyRoutes []routers.Router
+
+func (rs anyRoutes) FindRoute(req *http.Request) (*routers.Route, map[string]string, error) {
+	var err error
+	for _, r := range rs {
+		route, params, e := r.FindRoute(req)
+		if e == nil {
+			return route, params, nil
+		}
+		err = e
+	}
+	return nil, nil, err
+}
+
+// newVersionsServer serves every version of the API the way cmd/server does,
+// and checks the responses against the spec of their version.
+func newVersionsServer(t *testing.T) *server {
+	t.Helper()
+	svc := handlers.NewItemsService(store.NewMemory())
+	h, err := handlers.NewRouter(
+		handlers.V1("", svc),
+		handlers.V1("/v1", svc),
+		handlers.V2("/v2", handlers.NewItemsServiceV2(svc)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var routes anyRoutes
+	for _, v := range []struct {
+		baseURL string
+		spec    func() (*openapi3.T, error)
+	}{{"", api.GetSwagger}, {"/v1", api.GetSwagger}, {"/v2", apiv2.GetSwagger}} {
+		swagger, err := v.spec()
+		if err != nil {
+			t.Fatal(err)
+		}
+		swagger.Servers = nil
+		if v.baseURL != "" {
+			swagger.Servers = openapi3.Servers{{URL: v.baseURL}}
+		}
+		r, err := gorillamux.NewRouter(swagger)
+		if err != nil {
+			t.Fatal(err)
+		}
+		routes = append(routes, r)
+	}
+	return &server{t: t, handler: h, routes: routes}
+}
+
+func TestVersions(t *testing.T) {
+	s := newVersionsServer(t)
+
+	// Version 2 prices in cents, version 1 in decimals, of the same items.
+	var v2 apiv2.Item
+	s.expect("POST", "/v2/items", `{"name":"lamp","priceCents":1250}`, http.StatusCreated, &v2)
+	if v2.Id != 1 || v2.PriceCents == nil || *v2.PriceCents != 1250 {
+		t.Fatalf("POST /v2/items = %+v", v2)
+	}
+	var v1 api.Item
+	for _, path := range []string{"/v1/items/1", "/items/1"} {
+		s.expect("GET", path, "", http.StatusOK, &v1)
+		if v1.Name != "lamp" || v1.Price == nil || *v1.Price != "12.50" || v1.Version != v2.Version {
+			t.Fatalf("GET %s = %+v", path, v1)
+		}
+	}
+	s.expect("PUT", "/v1/items/1", `{"name":"lamp","price":"7.5"}`, http.StatusOK, nil)
+	s.expect("GET", "/v2/items/1", "", http.StatusOK, &v2)
+	if v2.PriceCents == nil || *v2.PriceCents != 750 {
+		t.Fatalf("GET /v2/items/1 after a PUT of version 1 = %+v", v2)
+	}
+	var page apiv2.ItemPage
+	s.expect("GET", "/v2/items?q=lamp", "", http.StatusOK, &page)
+	if page.Total != 1 || *page.Items[0].PriceCents != 750 {
+		t.Fatalf("GET /v2/items = %+v", page)
+	}
+
+	// Both versions of an item have its ETag.
+	etag := s.expectHeader("GET", "/v2/items/1", "", "If-None-Match", `"1"`, http.StatusOK)
+	s.expectHeader("GET", "/v1/items/1", "", "If-None-Match", etag, http.StatusNotModified)
+	s.expectHeader("PUT", "/v2/items/1", `{"name":"lamp"}`, "If-Match", `"1"`, http.StatusPreconditionFailed)
+
+	// Merge patches convert priceCents, and reject the price of version 1.
+	s.expectPatch("/v2/items/1", mergePatch, `{"priceCents":5}`, http.StatusOK, &v2)
+	if *v2.PriceCents != 5 {
+		t.Fatalf("merge patch of priceCents = %+v", v2)
+	}
+	s.expect("GET", "/v1/items/1", "", http.StatusOK, &v1)
+	if *v1.Price != "0.05" {
+		t.Fatalf("price after a merge patch of priceCents = %q", *v1.Price)
+	}
+	for _, body := range []string{`{"price":"1.00"}`, `{"priceCents":1.5}`, `{"priceCents":-1}`, `{"priceCents":"5"}`} {
+		s.expectPatch("/v2/items/1", mergePatch, body, http.StatusUnprocessableEntity, nil)
+	}
+	var removed apiv2.Item
+	s.expectPatch("/v2/items/1", mergePatch, `{"priceCents":null}`, http.StatusOK, &removed)
+	if removed.PriceCents != nil {
+		t.Fatalf("merge patch removing priceCents = %+v", removed)
+	}
+
+	// Each version validates against its own spec.
+	s.expect("POST", "/v2/items", `{"name":"desk","price":"1.00"}`, http.StatusBadRequest, nil)
+	s.expect("POST", "/v1/items", `{"name":"desk","priceCents":100}`, http.StatusBadRequest, nil)
+	s.expectPatch("/v2/items/1", jsonPatch, `[{"op":"replace","path":"/name","value":"x"}]`, http.StatusBadRequest, nil)
+	s.expect("POST", "/v2/items", `{"name":"lamp"}`, http.StatusConflict, nil)
+	s.expect("DELETE", "/v2/items/1", "", http.StatusNoContent, nil)
+	s.expect("GET", "/v1/items/1", "", http.StatusNotFound, nil)
+
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, httptest.NewRequest("GET", "/v3/items", nil))
+	s.check(rec, "GET /v3/items", http.StatusNotFound, nil)
+}
+
+func TestVersionsDeprecation(t *testing.T) {
+	s := newVersionsServer(t)
+
+	// Version 1 is deprecated, at the root and under /v1, even for bad requests.
+	for _, path := range []string{"/items", "/v1/items", "/v1/items/abc", "/v1/items?limit=0"} {
+		rec := s.do("GET", path, "")
+		if got := rec.Header().Get("Deprecation"); got != "@1792281600" {
+			t.Errorf("GET %s: Deprecation = %q, want 2026-10-18", path, got)
+		}
+		if got := rec.Header().Get("Sunset"); got != "Fri, 30 Apr 2027 00:00:00 GMT" {
+			t.Errorf("GET %s: Sunset = %q", path, got)
+		}
+	}
+	for _, path := range []string{"/v2/items", "/v2/items/1", "/nothing"} {
+		rec := httptest.NewRecorder()
+		s.handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if got := rec.Header().Values("Deprecation"); got != nil {
+			t.Errorf("GET %s: Deprecation = %q, want none", path, got)
+		}
+	}
+}
+
+func TestDeprecations(t *testing.T) {
+	load := func(info, op string) *openapi3.T {
+		t.Helper()
+		spec := `
+openapi: 3.0.3
+info: { title: things, version: "1"` + info + ` }
+paths:
+  /things:
+    get:
+      deprecated: true` + op + `
+      responses: { '200': { description: OK } }
+    post:
+      responses: { '201': { description: Created } }
+`
+		swagger, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return swagger
+	}
+
+	// The dates of an operation take precedence over those of the spec.
+	got, err := handlers.Deprecations(load(`, x-deprecated-at: "2026-01-01", x-sunset: "2026-12-31"`, `
+      x-sunset: "2026-06-30"`), "/v1")
+	want := map[string][2]string{"GET /v1/things": {"@1767225600", "Tue, 30 Jun 2026 00:00:00 GMT"}}
+	if err != nil || len(got) != 1 || got["GET /v1/things"] != want["GET /v1/things"] {
+		t.Errorf("Deprecations = %v, %v; want %v", got, err, want)
+	}
+	// The sunset is optional.
+	if got, err := handlers.Deprecations(load(`, x-deprecated-at: "2026-01-01"`, ""), ""); err != nil || got["GET /things"] != [2]string{"@1767225600", ""} {
+		t.Errorf("Deprecations without sunset = %v, %v", got, err)
+	}
+	// The deprecation date is not.
+	for _, info := range []string{"", `, x-deprecated-at: "soon"`, `, x-deprecated-at: "2026-01-01", x-sunset: 2027`} {
+		if _, err := handlers.Deprecations(load(info, ""), ""); err == nil || !strings.Contains(err.Error(), "GET /things") {
+			t.Errorf("Deprecations with info%s = %v, want an error", info, err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package store
+
+import (
+	"context"
+	"errors"
+)
+
+// Reset recreates the items table, so tests against a shared database start empty.
+func (s *SQL) Reset(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, "DROP TABLE IF EXISTS items"); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, schema[s.d])
+	return err
+}
+
+// failingSync is a log whose writes reach the file but whose syncs fail.
+type failingSync struct{ logFile }
+
+func (failingSync) Sync() error { return errors.New("sync failed") }
+
+// BreakSync makes syncs of the log fail until the returned function is called.
+func (f *File) BreakSync() (restore func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	lf := f.log
+	f.log = failingSync{lf}
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.log = lf
+	}
+}
@@ -0,0 +1,302 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SnapshotEvery is the number of log records after which File writes a
+// snapshot and truncates its log.
+const SnapshotEvery = 1000
+
+// File keeps items in memory and makes every change durable in an append-only
+// log (items.log, one JSON record per line, synced before the change is
+// applied). Every SnapshotEvery records the items are written to
+// items.snapshot.json and the log starts over, so reopening the store replays
+// at most that many records.
+type File struct {
+	*Memory // current items; its mutex also serializes log writes
+
+	dir     string
+	log     logFile
+	records int       // records in the log since the last snapshot
+	opened  time.Time // when OpenFile was called; see upgrade
+	broken  error     // why the log can no longer be appended to, if it cannot
+}
+
+// logFile is the part of *os.File the log is written through.
+type logFile interface {
+	io.WriteSeeker
+	Sync() error
+	Truncate(size int64) error
+	Close() error
+	Name() string
+}
+
+// snapshot is the content of items.snapshot.json.
+type snapshot struct {
+	LastID int64  `json:"lastId"`
+	Items  []Item `json:"items"`
+}
+
+// record is one line of items.log: "put" stores Item, "delete" removes ID.
+type record struct {
+	Op   string `json:"op"`
+	Item *Item  `json:"item,omitempty"`
+	ID   int64  `json:"id,omitempty"`
+}
+
+// OpenFile opens the store in dir, creating the directory if needed, and
+// loads the snapshot and log found there. A record cut short by a crash at
+// the end of the log is dropped.
+func OpenFile(dir string) (*File, error) {
+	if dir == "" {
+		return nil, errors.New("file store: directory required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	f := &File{Memory: NewMemory(), dir: dir, opened: now()}
+
+	// Load the last snapshot, if any.
+	data, err := os.ReadFile(f.path("items.snapshot.json"))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		var snap snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return nil, fmt.Errorf("file store: snapshot: %w", err)
+		}
+		f.lastID = snap.LastID
+		for _, it := range snap.Items {
+			f.put(f.upgrade(it))
+		}
+	}
+
+	// Replay the changes made since.
+	lf, err := os.OpenFile(f.path("items.log"), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	f.log = lf
+	if err := f.replay(); err != nil {
+		return nil, errors.Join(err, f.log.Close())
+	}
+	return f, nil
+}
+
+// replay applies the records of the log and leaves it positioned for appending.
+func (f *File) replay() error {
+	data, err := os.ReadFile(f.log.Name())
+	if err != nil {
+		return err
+	}
+	var good int64 // length of the log up to the last complete record
+	for len(data) > 0 {
+		line, rest, complete := bytes.Cut(data, []byte("\n"))
+		var rec record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			if !complete {
+				// A crash interrupted the last append; that change was never acknowledged.
+				log.Printf("file store: dropping incomplete last record of %s", f.log.Name())
+				break
+			}
+			return fmt.Errorf("file store: %s at offset %d: %w", f.log.Name(), good, err)
+		}
+		f.apply(rec)
+		f.records++
+		good += int64(len(line)) + 1
+		data = rest
+	}
+	if err := f.log.Truncate(good); err != nil {
+		return err
+	}
+	_, err = f.log.Seek(good, 0)
+	return err
+}
+
+// apply changes the items in memory. Applying a record twice has no further effect,
+// so a log that was not truncated after a snapshot can be replayed over it.
+func (f *File) apply(rec record) {
+	switch rec.Op {
+	case "put":
+		f.put(f.upgrade(*rec.Item))
+		f.lastID = max(f.lastID, rec.Item.ID)
+	case "delete":
+		f.remove(rec.ID)
+	}
+}
+
+// upgrade fills in the fields of items written before items had them:
+// version 1, DefaultStatus, and the time the store was opened.
+func (f *File) upgrade(it Item) Item {
+	it.Version = max(it.Version, 1)
+	if it.Status == "" {
+		it.Status = DefaultStatus
+	}
+	if it.CreatedAt.IsZero() {
+		it.CreatedAt, it.UpdatedAt = f.opened, f.opened
+	}
+	return it
+}
+
+// Create logs and stores the item under the next ID.
+func (f *File) Create(_ context.Context, it Item) (Item, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.nameTaken(it.Name, 0) {
+		return Item{}, ErrDuplicateName
+	}
+	it = accepted(it)
+	it.ID, it.Version = f.lastID+1, 1
+	it.CreatedAt = now()
+	it.UpdatedAt = it.CreatedAt
+	return clone(it), f.write(record{Op: "put", Item: &it})
+}
+
+// Update logs and replaces an existing item of the expected version.
+func (f *File) Update(_ context.Context, it Item) (Item, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, err := f.updatable(it)
+	if err != nil {
+		return Item{}, err
+	}
+	it = updated(stored, accepted(it))
+	return clone(it), f.write(record{Op: "put", Item: &it})
+}
+
+// Delete logs and removes an existing item of the expected version.
+func (f *File) Delete(_ context.Context, id, version int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stored, ok := f.items[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if err := checkVersion(stored, version); err != nil {
+		return err
+	}
+	return f.write(record{Op: "delete", ID: id})
+}
+
+// write appends the record to the log, syncs it and applies it; every
+// SnapshotEvery records it compacts the log into a snapshot. f.mu must be held.
+//
+// A record that fails to be written or synced is cut off the log again, so
+// that neither a partial line ends up in the middle of the log nor a change
+// reported as failed reappears when the log is replayed. If even that fails,
+// the store refuses further changes.
+func (f *File) write(rec record) error {
+	if f.broken != nil {
+		return f.broken
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	offset, err := f.log.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	_, err = f.log.Write(append(line, '\n'))
+	if err == nil {
+		err = f.log.Sync()
+	}
+	if err != nil {
+		if rerr := f.rollback(offset); rerr != nil {
+			f.broken = fmt.Errorf("file store: %s unusable after a failed write: %w", f.log.Name(), rerr)
+			return errors.Join(err, f.broken)
+		}
+		return err
+	}
+	f.apply(rec)
+
+	if f.records++; f.records >= SnapshotEvery {
+		if err := f.snapshot(); err != nil {
+			// The change is durable in the log; compaction is retried after the next write.
+			log.Printf("file store: snapshot: %v", err)
+		}
+	}
+	return nil
+}
+
+// snapshot writes all items to items.snapshot.json (atomically, through a
+// temporary file) and truncates the log. f.mu must be held.
+func (f *File) snapshot() error {
+	snap := snapshot{LastID: f.lastID, Items: make([]Item, 0, len(f.items))}
+	for _, it := range f.items {
+		snap.Items = append(snap.Items, it)
+	}
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(f.dir, "items.snapshot-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+	if _, err := tmp.Write(data); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+	if err := errors.Join(tmp.Sync(), tmp.Close()); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), f.path("items.snapshot.json")); err != nil {
+		return err
+	}
+	if err := syncDir(f.dir); err != nil {
+		return err
+	}
+
+	// The snapshot holds every change of the log now.
+	if err := f.log.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.log.Seek(0, 0); err != nil {
+		return err
+	}
+	f.records = 0
+	return nil
+}
+
+// rollback truncates the log to offset and positions it there. The next
+// successful Sync makes the truncation durable along with the next record.
+func (f *File) rollback(offset int64) error {
+	if err := f.log.Truncate(offset); err != nil {
+		return err
+	}
+	_, err := f.log.Seek(offset, io.SeekStart)
+	return err
+}
+
+// Close closes the log.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.log.Close()
+}
+
+// path returns the path of a file of the store.
+func (f *File) path(name string) string { return filepath.Join(f.dir, name) }
+
+// syncDir makes a rename in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	return errors.Join(d.Sync(), d.Close())
+}
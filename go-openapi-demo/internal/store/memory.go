@@ -0,0 +1,179 @@
+package store
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Memory keeps items in a map. Everything is lost when the process exits.
+type Memory struct {
+	mu     sync.RWMutex     // guards the fields below
+	items  map[int64]Item   // items by ID
+	names  map[string]int64 // IDs by name, to keep names unique
+	lastID int64            // highest ID assigned so far
+}
+
+// NewMemory returns an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{items: map[int64]Item{}, names: map[string]int64{}}
+}
+
+// List returns all items ordered by ID.
+func (m *Memory) List(context.Context) ([]Item, error) {
+	m.mu.RLock()
+	out := make([]Item, 0, len(m.items))
+	for _, it := range m.items {
+		out = append(out, clone(it))
+	}
+	m.mu.RUnlock()
+
+	slices.SortFunc(out, func(a, b Item) int { return cmp.Compare(a.ID, b.ID) })
+	return out, nil
+}
+
+// ListPage filters, sorts and pages a copy of the items taken under the read lock.
+func (m *Memory) ListPage(_ context.Context, opts ListOptions) (Page, error) {
+	if err := opts.validate(); err != nil {
+		return Page{}, err
+	}
+	m.mu.RLock()
+	var out []Item
+	for _, it := range m.items {
+		if strings.Contains(it.Name, opts.Contains) {
+			out = append(out, clone(it))
+		}
+	}
+	m.mu.RUnlock()
+
+	total := len(out)
+	slices.SortFunc(out, opts.compare)
+	if opts.After != nil {
+		// Skip to the first item after opts.After, which may itself be gone by now.
+		i, _ := slices.BinarySearchFunc(out, *opts.After, func(it, after Item) int {
+			if opts.compare(it, after) <= 0 {
+				return -1
+			}
+			return 1
+		})
+		out = out[i:]
+	}
+	if opts.Limit > 0 && len(out) > opts.Limit {
+		out = out[:opts.Limit]
+	}
+	return Page{Items: append([]Item{}, out...), Total: total}, nil
+}
+
+// Get returns the item with the ID, or ErrNotFound.
+func (m *Memory) Get(_ context.Context, id int64) (Item, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	it, ok := m.items[id]
+	if !ok {
+		return Item{}, ErrNotFound
+	}
+	return clone(it), nil
+}
+
+// Create stores the item under the next ID.
+func (m *Memory) Create(_ context.Context, it Item) (Item, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.nameTaken(it.Name, 0) {
+		return Item{}, ErrDuplicateName
+	}
+	m.lastID++
+	it = accepted(it)
+	it.ID, it.Version = m.lastID, 1
+	it.CreatedAt = now()
+	it.UpdatedAt = it.CreatedAt
+	m.put(it)
+	return clone(it), nil
+}
+
+// Update replaces an existing item of the expected version.
+func (m *Memory) Update(_ context.Context, it Item) (Item, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, err := m.updatable(it)
+	if err != nil {
+		return Item{}, err
+	}
+	it = updated(stored, accepted(it))
+	m.put(it)
+	return clone(it), nil
+}
+
+// Delete removes an existing item of the expected version.
+func (m *Memory) Delete(_ context.Context, id, version int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.items[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if err := checkVersion(stored, version); err != nil {
+		return err
+	}
+	m.remove(id)
+	return nil
+}
+
+// Close does nothing; it exists to satisfy ItemStore.
+func (m *Memory) Close() error { return nil }
+
+// updatable returns the stored item it replaces, or the error that keeps it
+// from replacing the item. m.mu must be held.
+func (m *Memory) updatable(it Item) (Item, error) {
+	stored, ok := m.items[it.ID]
+	if !ok {
+		return Item{}, ErrNotFound
+	}
+	if err := checkVersion(stored, it.Version); err != nil {
+		return Item{}, err
+	}
+	if m.nameTaken(it.Name, it.ID) {
+		return Item{}, ErrDuplicateName
+	}
+	return stored, nil
+}
+
+// nameTaken reports whether an item other than the one with the ID has the name. m.mu must be held.
+func (m *Memory) nameTaken(name string, id int64) bool {
+	owner, ok := m.names[name]
+	return ok && owner != id
+}
+
+// put stores a copy of the item, replacing the one with its ID. m.mu must be held.
+func (m *Memory) put(it Item) {
+	if old, ok := m.items[it.ID]; ok && m.names[old.Name] == it.ID {
+		delete(m.names, old.Name)
+	}
+	m.items[it.ID] = clone(it)
+	m.names[it.Name] = it.ID
+}
+
+// remove deletes the item with the ID. m.mu must be held.
+func (m *Memory) remove(id int64) {
+	if old, ok := m.items[id]; ok && m.names[old.Name] == id {
+		delete(m.names, old.Name)
+	}
+	delete(m.items, id)
+}
+
+// updated returns it as the update of stored: with the next version and the
+// creation time of stored.
+func updated(stored, it Item) Item {
+	it.Version = stored.Version + 1
+	it.CreatedAt, it.UpdatedAt = stored.CreatedAt, now()
+	return it
+}
+
+// clone returns a copy of it that shares no memory with it, so that callers
+// cannot change stored items through the tags they get or give.
+func clone(it Item) Item {
+	it.Tags = slices.Clone(it.Tags)
+	return it
+}
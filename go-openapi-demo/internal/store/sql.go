@@ -0,0 +1,382 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" database/sql driver
+	"github.com/mattn/go-sqlite3"      // registers the "sqlite3" database/sql driver
+)
+
+// Dialect names a database supported by SQL.
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// drivers maps each dialect onto its database/sql driver.
+var drivers = map[Dialect]string{
+	Postgres: "pgx",
+	SQLite:   "sqlite3",
+}
+
+// schema creates the items table. Both dialects number the placeholders of
+// the queries below ($1, $2, ...) and support RETURNING. Tags are stored as a
+// JSON array, which neither dialect needs to look into.
+var schema = map[Dialect]string{
+	Postgres: `CREATE TABLE IF NOT EXISTS items (
+		id          BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+		name        TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		tags        TEXT NOT NULL DEFAULT '[]',
+		price       TEXT NOT NULL DEFAULT '',
+		status      TEXT NOT NULL DEFAULT '` + DefaultStatus + `',
+		version     BIGINT NOT NULL DEFAULT 1,
+		created_at  TIMESTAMPTZ,
+		updated_at  TIMESTAMPTZ
+	)`,
+	// AUTOINCREMENT keeps SQLite from reusing the ID of the last deleted row.
+	// DATETIME makes the driver return time.Time for the timestamps.
+	SQLite: `CREATE TABLE IF NOT EXISTS items (
+		id          INTEGER PRIMARY KEY AUTOINCREMENT,
+		name        TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		tags        TEXT NOT NULL DEFAULT '[]',
+		price       TEXT NOT NULL DEFAULT '',
+		status      TEXT NOT NULL DEFAULT '` + DefaultStatus + `',
+		version     INTEGER NOT NULL DEFAULT 1,
+		created_at  DATETIME,
+		updated_at  DATETIME
+	)`,
+}
+
+// timestamp is the column type of created_at and updated_at.
+var timestamp = map[Dialect]string{
+	Postgres: "TIMESTAMPTZ",
+	SQLite:   "DATETIME",
+}
+
+// contains is the condition "name contains $n" of each dialect, with %d
+// standing for n. Unlike LIKE it needs no escaping and is case-sensitive in both.
+var contains = map[Dialect]string{
+	Postgres: "strpos(name, $%d) > 0",
+	SQLite:   "instr(name, $%d) > 0",
+}
+
+// byName is the name column compared byte by byte, as Memory compares names.
+// SQLite compares TEXT that way by default; Postgres would use the locale.
+var byName = map[Dialect]string{
+	Postgres: `name COLLATE "C"`,
+	SQLite:   "name",
+}
+
+// SQL stores items in the "items" table of a Postgres or SQLite database.
+type SQL struct {
+	db *sql.DB
+	d  Dialect
+}
+
+// OpenSQL connects to the database, creates the items table if it does not
+// exist and returns the store. For SQLite the DSN is the database file; a busy
+// timeout is added so concurrent writers wait for each other.
+func OpenSQL(ctx context.Context, d Dialect, dsn string) (*SQL, error) {
+	drv, ok := drivers[d]
+	if !ok {
+		return nil, fmt.Errorf("sql store: unknown dialect %q", d)
+	}
+	if dsn == "" {
+		return nil, errors.New("sql store: dsn required")
+	}
+	if d == SQLite {
+		dsn = "file:" + dsn + "?_busy_timeout=5000&_journal_mode=WAL"
+	}
+	db, err := sql.Open(drv, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, schema[d]); err != nil {
+		return nil, errors.Join(fmt.Errorf("sql store: create table: %w", err), db.Close())
+	}
+	if err := upgrade(ctx, db, d); err != nil {
+		return nil, errors.Join(fmt.Errorf("sql store: upgrade table: %w", err), db.Close())
+	}
+	return &SQL{db: db, d: d}, nil
+}
+
+// upgrade adds the columns that tables created by earlier versions of schema
+// lack, and the index keeping names unique, in one transaction. Items from
+// before timestamps existed get the time of the upgrade.
+func upgrade(ctx context.Context, db *sql.DB, d Dialect) error {
+	rows, err := db.QueryContext(ctx, "SELECT * FROM items LIMIT 0")
+	if err != nil {
+		return err
+	}
+	cols, err := rows.Columns()
+	if err := errors.Join(err, rows.Close()); err != nil {
+		return err
+	}
+
+	var stmts []string
+	if !slices.Contains(cols, "version") {
+		stmts = append(stmts, "ALTER TABLE items ADD COLUMN version BIGINT NOT NULL DEFAULT 1")
+	}
+	addTimes := !slices.Contains(cols, "created_at")
+	if addTimes {
+		stmts = append(stmts,
+			"ALTER TABLE items ADD COLUMN description TEXT NOT NULL DEFAULT ''",
+			"ALTER TABLE items ADD COLUMN tags TEXT NOT NULL DEFAULT '[]'",
+			"ALTER TABLE items ADD COLUMN price TEXT NOT NULL DEFAULT ''",
+			"ALTER TABLE items ADD COLUMN status TEXT NOT NULL DEFAULT '"+DefaultStatus+"'",
+			"ALTER TABLE items ADD COLUMN created_at "+timestamp[d],
+			"ALTER TABLE items ADD COLUMN updated_at "+timestamp[d],
+		)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // no-op after Commit
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	if addTimes {
+		if _, err := tx.ExecContext(ctx, "UPDATE items SET created_at = $1, updated_at = $1", now()); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "CREATE UNIQUE INDEX IF NOT EXISTS items_name ON items (name)"); err != nil {
+		return fmt.Errorf("names must be unique; rename the items sharing a name: %w", err)
+	}
+	return tx.Commit()
+}
+
+// List returns all items ordered by ID.
+func (s *SQL) List(ctx context.Context) ([]Item, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+columns+" FROM items ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []Item{}
+	for rows.Next() {
+		it, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+	return out, rows.Err()
+}
+
+// ListPage runs two queries: one counting the matching items, one selecting
+// the page. The order and the position after opts.After become ORDER BY and
+// WHERE clauses, so only the page is read from the database.
+func (s *SQL) ListPage(ctx context.Context, opts ListOptions) (Page, error) {
+	if err := opts.validate(); err != nil {
+		return Page{}, err
+	}
+
+	var where []string
+	var args []any
+	arg := func(v any) int {
+		args = append(args, v)
+		return len(args)
+	}
+	if opts.Contains != "" {
+		where = append(where, fmt.Sprintf(contains[s.d], arg(opts.Contains)))
+	}
+
+	var page Page
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM items"+clause(where), args...).Scan(&page.Total); err != nil {
+		return Page{}, err
+	}
+
+	key, op, dir := "id", ">", "ASC"
+	if opts.SortBy == SortByName {
+		key = byName[s.d]
+	}
+	if opts.Desc {
+		op, dir = "<", "DESC"
+	}
+	if a := opts.After; a != nil {
+		if opts.SortBy == SortByName {
+			n, id := arg(a.Name), arg(a.ID)
+			where = append(where, fmt.Sprintf("(%[1]s %[2]s $%[3]d OR (%[1]s = $%[3]d AND id %[2]s $%[4]d))", key, op, n, id))
+		} else {
+			where = append(where, fmt.Sprintf("id %s $%d", op, arg(a.ID)))
+		}
+	}
+	query := "SELECT " + columns + " FROM items" + clause(where) + " ORDER BY "
+	if opts.SortBy == SortByName {
+		query += key + " " + dir + ", "
+	}
+	query += "id " + dir
+	if opts.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", arg(opts.Limit))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return Page{}, err
+	}
+	defer rows.Close()
+	page.Items = []Item{}
+	for rows.Next() {
+		it, err := scan(rows)
+		if err != nil {
+			return Page{}, err
+		}
+		page.Items = append(page.Items, it)
+	}
+	return page, rows.Err()
+}
+
+// clause joins conditions into a WHERE clause, or returns "" without conditions.
+func clause(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conds, " AND ")
+}
+
+// Get returns the item with the ID, or ErrNotFound.
+func (s *SQL) Get(ctx context.Context, id int64) (Item, error) {
+	it, err := scan(s.db.QueryRowContext(ctx, "SELECT "+columns+" FROM items WHERE id = $1", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Item{}, ErrNotFound
+	}
+	return it, err
+}
+
+// Create inserts the item; the database assigns the ID and version.
+// The unique index on name rejects a duplicate name.
+func (s *SQL) Create(ctx context.Context, it Item) (Item, error) {
+	it = accepted(it)
+	it.CreatedAt = now()
+	it.UpdatedAt = it.CreatedAt
+	err := s.db.QueryRowContext(ctx,
+		`INSERT INTO items (name, description, tags, price, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6) RETURNING id, version`,
+		it.Name, it.Description, encodeTags(it.Tags), it.Price, it.Status, it.CreatedAt,
+	).Scan(&it.ID, &it.Version)
+	if err != nil {
+		return Item{}, s.mapErr(err)
+	}
+	return it, nil
+}
+
+// Update replaces an existing item of the expected version. The version is
+// checked in the WHERE clause, so checking and replacing is one statement.
+func (s *SQL) Update(ctx context.Context, it Item) (Item, error) {
+	it = accepted(it)
+	it.UpdatedAt = now()
+	query := `UPDATE items SET name = $1, description = $2, tags = $3, price = $4, status = $5,
+		updated_at = $6, version = version + 1 WHERE id = $7`
+	args := []any{it.Name, it.Description, encodeTags(it.Tags), it.Price, it.Status, it.UpdatedAt, it.ID}
+	if it.Version != 0 {
+		query, args = query+" AND version = $8", append(args, it.Version)
+	}
+	err := s.db.QueryRowContext(ctx, query+" RETURNING version, created_at", args...).Scan(&it.Version, &it.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Item{}, s.unchanged(ctx, it.ID)
+	}
+	if err != nil {
+		return Item{}, s.mapErr(err)
+	}
+	it.CreatedAt = it.CreatedAt.UTC()
+	return it, nil
+}
+
+// Delete removes an existing item of the expected version.
+func (s *SQL) Delete(ctx context.Context, id, version int64) error {
+	query, args := "DELETE FROM items WHERE id = $1", []any{id}
+	if version != 0 {
+		query, args = query+" AND version = $2", append(args, version)
+	}
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err := affectedOne(res, err); !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return s.unchanged(ctx, id)
+}
+
+// Close closes the database.
+func (s *SQL) Close() error { return s.db.Close() }
+
+// unchanged explains why a statement on the item with the ID changed no row:
+// ErrNotFound if the item does not exist, ErrVersionMismatch if it has another version.
+func (s *SQL) unchanged(ctx context.Context, id int64) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+	return ErrVersionMismatch
+}
+
+// columns are the columns read by scan, in its order.
+const columns = "id, name, description, tags, price, status, version, created_at, updated_at"
+
+// scan reads an item selected as columns from a row.
+func scan(row interface{ Scan(dest ...any) error }) (Item, error) {
+	var it Item
+	var tags string
+	err := row.Scan(&it.ID, &it.Name, &it.Description, &tags, &it.Price, &it.Status, &it.Version, &it.CreatedAt, &it.UpdatedAt)
+	if err != nil {
+		return Item{}, err
+	}
+	if err := json.Unmarshal([]byte(tags), &it.Tags); err != nil {
+		return Item{}, fmt.Errorf("sql store: tags of item %d: %w", it.ID, err)
+	}
+	if len(it.Tags) == 0 {
+		it.Tags = nil
+	}
+	it.CreatedAt, it.UpdatedAt = it.CreatedAt.UTC(), it.UpdatedAt.UTC()
+	return it, nil
+}
+
+// encodeTags returns the JSON array stored in the tags column.
+func encodeTags(tags []string) string {
+	if len(tags) == 0 {
+		return "[]"
+	}
+	b, _ := json.Marshal(tags) // cannot fail: strings only
+	return string(b)
+}
+
+// mapErr maps a violation of the unique index on name onto ErrDuplicateName.
+func (s *SQL) mapErr(err error) error {
+	var pgErr *pgconn.PgError
+	var liteErr sqlite3.Error
+	switch {
+	case errors.As(err, &pgErr) && pgErr.Code == "23505", // unique_violation
+		errors.As(err, &liteErr) && liteErr.ExtendedCode == sqlite3.ErrConstraintUnique:
+		return ErrDuplicateName
+	}
+	return err
+}
+
+// affectedOne maps a statement that changed no row onto ErrNotFound.
+func affectedOne(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package store_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-openapi-demo/internal/store"
+	"go-openapi-demo/internal/store/storetest"
+)
+
+func TestMemory(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.ItemStore { return store.NewMemory() })
+}
+
+func TestFile(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.ItemStore {
+		s, err := store.OpenFile(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+}
+
+func TestSQLite(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.ItemStore {
+		s, err := store.OpenSQL(context.Background(), store.SQLite, filepath.Join(t.TempDir(), "items.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+}
+
+// TestPostgres runs against the database in STORE_TEST_POSTGRES_DSN; the items table is dropped before each subtest.
+func TestPostgres(t *testing.T) {
+	dsn := os.Getenv("STORE_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("STORE_TEST_POSTGRES_DSN not set")
+	}
+	storetest.Run(t, func(t *testing.T) store.ItemStore {
+		ctx := context.Background()
+		s, err := store.OpenSQL(ctx, store.Postgres, dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Reset(ctx); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+}
+
+// reopen checks that a store opened again sees the items, and keeps counting IDs, of the store it replaces.
+func reopen(t *testing.T, open func() (store.ItemStore, error)) {
+	ctx := context.Background()
+	s, err := open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := s.Create(ctx, store.Item{Name: "a"})
+	b, _ := s.Create(ctx, store.Item{Name: "b"})
+	a.Name = "renamed"
+	if a, err = s.Update(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, b.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err = open(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	items, err := s.List(ctx)
+	if err != nil || len(items) != 1 || !items[0].Equal(a) {
+		t.Fatalf("List after reopening = %+v, %v; want [%+v]", items, err, a)
+	}
+	if c, _ := s.Create(ctx, store.Item{Name: "c"}); c.ID <= b.ID {
+		t.Fatalf("ID %d reused after reopening", c.ID)
+	}
+}
+
+func TestFileReopen(t *testing.T) {
+	dir := t.TempDir()
+	reopen(t, func() (store.ItemStore, error) { return store.OpenFile(dir) })
+}
+
+func TestSQLiteReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.db")
+	reopen(t, func() (store.ItemStore, error) { return store.OpenSQL(context.Background(), store.SQLite, path) })
+}
+
+func TestFileSnapshot(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := store.OpenFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range store.SnapshotEvery + 10 {
+		if _, err := s.Create(ctx, store.Item{Name: fmt.Sprintf("x%d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The log was compacted into the snapshot and holds only the last changes.
+	if _, err := os.Stat(filepath.Join(dir, "items.snapshot.json")); err != nil {
+		t.Fatalf("no snapshot: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "items.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := countLines(data); lines != 10 {
+		t.Fatalf("log holds %d records after the snapshot, want 10", lines)
+	}
+
+	if s, err = store.OpenFile(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if items, _ := s.List(ctx); len(items) != store.SnapshotEvery+10 {
+		t.Fatalf("reopened store has %d items, want %d", len(items), store.SnapshotEvery+10)
+	}
+}
+
+func TestFileIncompleteLastRecord(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := store.OpenFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := s.Create(ctx, store.Item{Name: "a"})
+	s.Close()
+
+	// Simulate a crash in the middle of appending a record.
+	f, err := os.OpenFile(filepath.Join(dir, "items.log"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"op":"put","item":{"id":2,"na`)
+	f.Close()
+
+	if s, err = store.OpenFile(dir); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+	if items, _ := s.List(ctx); len(items) != 1 || !items[0].Equal(a) {
+		t.Fatalf("List = %+v, want [%+v]", items, a)
+	}
+	if b, err := s.Create(ctx, store.Item{Name: "b"}); err != nil || b.ID != a.ID+1 {
+		t.Fatalf("Create after recovery = %+v, %v", b, err)
+	}
+	if s2, err := store.OpenFile(dir); err != nil {
+		t.Fatalf("log unreadable after recovery: %v", err)
+	} else {
+		s2.Close()
+	}
+}
+
+// TestFileFailedWrite checks that a change whose record could not be synced
+// neither stays in the store nor reappears when the log is replayed.
+func TestFileFailedWrite(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := store.OpenFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := s.Create(ctx, store.Item{Name: "a"})
+
+	restore := s.BreakSync()
+	if _, err := s.Create(ctx, store.Item{Name: "b"}); err == nil {
+		t.Fatal("Create succeeded although the log could not be synced")
+	}
+	restore()
+	c, err := s.Create(ctx, store.Item{Name: "c"})
+	if err != nil || c.ID != a.ID+1 {
+		t.Fatalf("Create after the failure = %+v, %v", c, err)
+	}
+	s.Close()
+
+	if s, err = store.OpenFile(dir); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+	if items, _ := s.List(ctx); len(items) != 2 || !items[0].Equal(a) || !items[1].Equal(c) {
+		t.Fatalf("List = %+v, want [%+v %+v]", items, a, c)
+	}
+}
+
+// TestFileWithoutVersions opens a log written before items had versions,
+// statuses or timestamps.
+func TestFileWithoutVersions(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "items.log"), []byte(`{"op":"put","item":{"id":1,"name":"a"}}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := store.OpenFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	it, err := s.Get(context.Background(), 1)
+	if err != nil || it.Version != 1 || it.Status != store.DefaultStatus || it.CreatedAt.IsZero() || !it.UpdatedAt.Equal(it.CreatedAt) {
+		t.Fatalf("Get = %+v, %v; want version 1, the default status and times", it, err)
+	}
+}
+
+// TestSQLiteWithoutVersions opens a database created before items had
+// versions or any of the fields beside the name.
+func TestSQLiteWithoutVersions(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "items.db")
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL);
+		INSERT INTO items (name) VALUES ('a')`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := store.OpenSQL(ctx, store.SQLite, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	it, err := s.Get(ctx, 1)
+	if err != nil || it.Version != 1 || it.Status != store.DefaultStatus || it.CreatedAt.IsZero() || !it.UpdatedAt.Equal(it.CreatedAt) {
+		t.Fatalf("Get = %+v, %v; want version 1, the default status and times", it, err)
+	}
+	if it, err = s.Update(ctx, it); err != nil || it.Version != 2 {
+		t.Fatalf("Update = %+v, %v; want version 2", it, err)
+	}
+	if _, err := s.Create(ctx, store.Item{Name: "a"}); !errors.Is(err, store.ErrDuplicateName) {
+		t.Fatalf("Create of a taken name = %v, want ErrDuplicateName", err)
+	}
+}
+
+// TestSQLiteDuplicateNames refuses to open a database whose names are not
+// unique, rather than serving items that can never be updated.
+func TestSQLiteDuplicateNames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.db")
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL);
+		INSERT INTO items (name) VALUES ('a'), ('a')`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := store.OpenSQL(context.Background(), store.SQLite, path); err == nil {
+		s.Close()
+		t.Fatal("OpenSQL succeeded")
+	}
+}
+
+func countLines(data []byte) int {
+	n := 0
+	for _, c := range data {
+		if c == '\n' {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,434 @@
+// Package storetest holds the contract every store.ItemStore must satisfy.
+// Each implementation runs it from its own test:
+//
+//	storetest.Run(t, func(t *testing.T) store.ItemStore { return store.NewMemory() })
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-openapi-demo/internal/store"
+)
+
+// Run checks the behavior shared by all stores. open must return an empty
+// store; Run closes it at the end of each subtest.
+func Run(t *testing.T, open func(t *testing.T) store.ItemStore) {
+	ctx := context.Background()
+	fresh := func(t *testing.T) store.ItemStore {
+		s := open(t)
+		t.Cleanup(func() {
+			if err := s.Close(); err != nil {
+				t.Errorf("close: %v", err)
+			}
+		})
+		return s
+	}
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		s := fresh(t)
+		a := mustCreate(t, s, "a")
+		b := mustCreate(t, s, "b")
+		if a.ID <= 0 || b.ID <= a.ID {
+			t.Fatalf("IDs %d, %d: want positive and increasing", a.ID, b.ID)
+		}
+		if a.Version != 1 || b.Version != 1 {
+			t.Fatalf("versions %d, %d: want 1", a.Version, b.Version)
+		}
+		got, err := s.Get(ctx, b.ID)
+		if err != nil || !got.Equal(b) {
+			t.Fatalf("Get(%d) = %+v, %v; want %+v", b.ID, got, err, b)
+		}
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		s := fresh(t)
+		if _, err := s.Get(ctx, 42); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("Get = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		s := fresh(t)
+		if items, err := s.List(ctx); err != nil || len(items) != 0 {
+			t.Fatalf("List on empty store = %v, %v", items, err)
+		}
+		var want []store.Item
+		for _, name := range []string{"c", "a", "b"} {
+			want = append(want, mustCreate(t, s, name))
+		}
+		got, err := s.List(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("List = %+v, want %+v", got, want)
+		}
+		for i := range want {
+			if !got[i].Equal(want[i]) {
+				t.Fatalf("List = %+v, want %+v (ordered by ID)", got, want)
+			}
+		}
+	})
+
+	t.Run("ListPage", func(t *testing.T) {
+		s := fresh(t)
+		b1 := mustCreate(t, s, "banana")
+		a := mustCreate(t, s, "apple")
+		b2 := mustCreate(t, s, "banana split")
+		c := mustCreate(t, s, "cherry")
+		B := mustCreate(t, s, "Banana") // upper case sorts first, byte-wise
+
+		for _, tc := range []struct {
+			opts  store.ListOptions
+			want  []store.Item
+			total int
+		}{
+			{store.ListOptions{}, []store.Item{b1, a, b2, c, B}, 5},
+			{store.ListOptions{Desc: true}, []store.Item{B, c, b2, a, b1}, 5},
+			{store.ListOptions{SortBy: store.SortByName}, []store.Item{B, a, b1, b2, c}, 5},
+			{store.ListOptions{SortBy: store.SortByName, Desc: true}, []store.Item{c, b2, b1, a, B}, 5},
+			{store.ListOptions{Contains: "an"}, []store.Item{b1, b2, B}, 3},
+			{store.ListOptions{Contains: "ban"}, []store.Item{b1, b2}, 2},
+			{store.ListOptions{Contains: "%"}, []store.Item{}, 0},
+			{store.ListOptions{Limit: 2}, []store.Item{b1, a}, 5},
+			{store.ListOptions{After: &a}, []store.Item{b2, c, B}, 5},
+			{store.ListOptions{SortBy: store.SortByName, After: &b1, Limit: 2}, []store.Item{b2, c}, 5},
+			{store.ListOptions{SortBy: store.SortByName, Desc: true, After: &b2}, []store.Item{b1, a, B}, 5},
+			{store.ListOptions{SortBy: store.SortByName, Contains: "an", After: &B}, []store.Item{b1, b2}, 3},
+		} {
+			page, err := s.ListPage(ctx, tc.opts)
+			if err != nil {
+				t.Fatalf("ListPage(%+v): %v", tc.opts, err)
+			}
+			if !slices.EqualFunc(page.Items, tc.want, store.Item.Equal) || page.Total != tc.total {
+				t.Errorf("ListPage(%+v) = %+v, total %d; want %+v, total %d", tc.opts, page.Items, page.Total, tc.want, tc.total)
+			}
+		}
+
+		if _, err := s.ListPage(ctx, store.ListOptions{SortBy: "color"}); err == nil {
+			t.Error("ListPage with an unknown sort key succeeded")
+		}
+	})
+
+	t.Run("ListPageStable", func(t *testing.T) {
+		// Paging by position neither repeats nor skips the items that existed
+		// when paging started, whatever is created or deleted between pages.
+		s := fresh(t)
+		var want []store.Item
+		for _, name := range []string{"e", "c", "a", "d", "b", "f"} {
+			want = append(want, mustCreate(t, s, name))
+		}
+		slices.SortFunc(want, func(x, y store.Item) int { return strings.Compare(x.Name, y.Name) })
+
+		opts := store.ListOptions{SortBy: store.SortByName, Limit: 2}
+		var got []store.Item
+		for {
+			page, err := s.ListPage(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, page.Items...)
+			if len(page.Items) < opts.Limit {
+				break
+			}
+			opts.After = &page.Items[len(page.Items)-1]
+
+			// Items before the position and the item at the position itself change.
+			mustCreate(t, s, fmt.Sprintf("0 before %s", opts.After.Name))
+			if err := s.Delete(ctx, opts.After.ID, 0); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if !slices.EqualFunc(got, want, store.Item.Equal) {
+			t.Fatalf("pages = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		s := fresh(t)
+		it := mustCreate(t, s, "before")
+		it.Name = "after"
+		want, err := s.Update(ctx, it)
+		if err != nil || want.ID != it.ID || want.Name != "after" || want.Version != 2 {
+			t.Fatalf("Update = %+v, %v; want %q at version 2", want, err, "after")
+		}
+		if got, _ := s.Get(ctx, it.ID); !got.Equal(want) {
+			t.Fatalf("Get after Update = %+v, want %+v", got, want)
+		}
+		// Writing the same values again still finds the item, and still counts as a change.
+		if got, err := s.Update(ctx, want); err != nil || got.Version != 3 {
+			t.Fatalf("Update without changes = %+v, %v", got, err)
+		}
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		s := fresh(t)
+		it := mustCreate(t, s, "v1")
+
+		// Two writers read version 1; only the first update wins.
+		first, second := it, it
+		first.Name, second.Name = "first", "second"
+		if got, err := s.Update(ctx, first); err != nil || got.Version != 2 {
+			t.Fatalf("first Update = %+v, %v", got, err)
+		}
+		if _, err := s.Update(ctx, second); !errors.Is(err, store.ErrVersionMismatch) {
+			t.Fatalf("second Update = %v, want ErrVersionMismatch", err)
+		}
+		if got, _ := s.Get(ctx, it.ID); got.Name != "first" || got.Version != 2 {
+			t.Fatalf("Get = %+v, want the first update", got)
+		}
+
+		// Version 0 updates whatever the version.
+		second.Version = 0
+		if got, err := s.Update(ctx, second); err != nil || got.Version != 3 {
+			t.Fatalf("unconditional Update = %+v, %v", got, err)
+		}
+
+		if err := s.Delete(ctx, it.ID, 2); !errors.Is(err, store.ErrVersionMismatch) {
+			t.Fatalf("Delete of version 2 = %v, want ErrVersionMismatch", err)
+		}
+		if err := s.Delete(ctx, it.ID, 3); err != nil {
+			t.Fatalf("Delete of version 3 = %v", err)
+		}
+		if _, err := s.Update(ctx, store.Item{ID: it.ID, Name: "x", Version: 3}); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("Update of a deleted item = %v, want ErrNotFound", err)
+		}
+		if err := s.Delete(ctx, it.ID, 3); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("Delete of a deleted item = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("ConcurrentUpdates", func(t *testing.T) {
+		// Writers that all read the same version race to update it; exactly one wins.
+		s := fresh(t)
+		it := mustCreate(t, s, "v1")
+		const n = 10
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		wins := 0
+		for range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := s.Update(ctx, it)
+				switch {
+				case err == nil:
+					mu.Lock()
+					wins++
+					mu.Unlock()
+				case !errors.Is(err, store.ErrVersionMismatch):
+					t.Errorf("Update: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if wins != 1 {
+			t.Fatalf("%d of %d updates of version 1 succeeded, want 1", wins, n)
+		}
+	})
+
+	t.Run("UpdateMissing", func(t *testing.T) {
+		s := fresh(t)
+		if _, err := s.Update(ctx, store.Item{ID: 42, Name: "x"}); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("Update = %v, want ErrNotFound", err)
+		}
+		if items, _ := s.List(ctx); len(items) != 0 {
+			t.Fatalf("Update of a missing item created %+v", items)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		s := fresh(t)
+		it := mustCreate(t, s, "a")
+		if err := s.Delete(ctx, it.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Get(ctx, it.ID); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("Get after Delete = %v, want ErrNotFound", err)
+		}
+		if err := s.Delete(ctx, it.ID, 0); !errors.Is(err, store.ErrNotFound) {
+			t.Fatalf("second Delete = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("IDsNotReused", func(t *testing.T) {
+		s := fresh(t)
+		it := mustCreate(t, s, "a")
+		if err := s.Delete(ctx, it.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+		if next := mustCreate(t, s, "b"); next.ID <= it.ID {
+			t.Fatalf("ID %d reused after deleting %d", next.ID, it.ID)
+		}
+	})
+
+	t.Run("ConcurrentCreates", func(t *testing.T) {
+		s := fresh(t)
+		const n = 20
+		ids := make(chan int64, n)
+		var wg sync.WaitGroup
+		for i := range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				it, err := s.Create(ctx, store.Item{Name: fmt.Sprintf("item %d", i)})
+				if err != nil {
+					t.Errorf("create %d: %v", i, err)
+					return
+				}
+				ids <- it.ID
+			}()
+		}
+		wg.Wait()
+		close(ids)
+		seen := map[int64]bool{}
+		for id := range ids {
+			if seen[id] {
+				t.Fatalf("ID %d assigned twice", id)
+			}
+			seen[id] = true
+		}
+		if items, err := s.List(ctx); err != nil || len(items) != n {
+			t.Fatalf("List = %d items, %v; want %d", len(items), err, n)
+		}
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		s := fresh(t)
+		in := store.Item{
+			Name:        "lamp",
+			Description: "A desk lamp",
+			Tags:        []string{"light", "desk"},
+			Price:       "12.50",
+			Status:      "draft",
+		}
+		created, err := s.Create(ctx, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		in.Tags[0] = "changed by the caller"
+		want := store.Item{
+			ID: created.ID, Name: "lamp", Description: "A desk lamp", Tags: []string{"light", "desk"},
+			Price: "12.50", Status: "draft", Version: 1, CreatedAt: created.CreatedAt, UpdatedAt: created.UpdatedAt,
+		}
+		if !created.Equal(want) {
+			t.Fatalf("Create = %+v, want %+v", created, want)
+		}
+		got, err := s.Get(ctx, created.ID)
+		if err != nil || !got.Equal(want) {
+			t.Fatalf("Get = %+v, %v; want %+v", got, err, want)
+		}
+
+		got.Tags, got.Price, got.Description, got.Status = nil, "", "", "archived"
+		updated, err := s.Update(ctx, got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := s.Get(ctx, created.ID); !got.Equal(updated) || len(got.Tags) != 0 || got.Price != "" || got.Status != "archived" {
+			t.Fatalf("Get after Update = %+v, want %+v", got, updated)
+		}
+	})
+
+	t.Run("Timestamps", func(t *testing.T) {
+		s := fresh(t)
+		before := time.Now().Add(-time.Second)
+		it := mustCreate(t, s, "a")
+		if it.CreatedAt.Before(before) || !it.UpdatedAt.Equal(it.CreatedAt) || it.CreatedAt.Location() != time.UTC {
+			t.Fatalf("Create = %+v, want both times now, in UTC", it)
+		}
+		// Times given to the store are ignored.
+		time.Sleep(2 * time.Millisecond)
+		it.CreatedAt, it.UpdatedAt = time.Time{}, time.Time{}
+		got, err := s.Update(ctx, it)
+		if err != nil {
+			t.Fatal(err)
+		}
+		created, _ := s.Get(ctx, it.ID)
+		if !got.CreatedAt.Equal(created.CreatedAt) || !got.UpdatedAt.After(got.CreatedAt) {
+			t.Fatalf("Update = %+v, want CreatedAt kept and UpdatedAt advanced", got)
+		}
+		if !created.Equal(got) {
+			t.Fatalf("Get after Update = %+v, want %+v", created, got)
+		}
+	})
+
+	t.Run("DuplicateNames", func(t *testing.T) {
+		s := fresh(t)
+		a := mustCreate(t, s, "a")
+		b := mustCreate(t, s, "b")
+		if _, err := s.Create(ctx, store.Item{Name: "a"}); !errors.Is(err, store.ErrDuplicateName) {
+			t.Fatalf("Create of a taken name = %v, want ErrDuplicateName", err)
+		}
+		b.Name = "a"
+		if _, err := s.Update(ctx, b); !errors.Is(err, store.ErrDuplicateName) {
+			t.Fatalf("Update to a taken name = %v, want ErrDuplicateName", err)
+		}
+		if got, _ := s.Get(ctx, b.ID); got.Name != "b" || got.Version != 1 {
+			t.Fatalf("Get after a failed Update = %+v", got)
+		}
+		// An item keeps its own name, and names are case-sensitive.
+		a.Description = "still a"
+		if _, err := s.Update(ctx, a); err != nil {
+			t.Fatalf("Update keeping the name = %v", err)
+		}
+		mustCreate(t, s, "A")
+		// Renaming or deleting an item frees its name.
+		b.Name = "c"
+		if _, err := s.Update(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+		mustCreate(t, s, "b")
+		if err := s.Delete(ctx, a.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+		mustCreate(t, s, "a")
+	})
+
+	t.Run("ConcurrentDuplicateNames", func(t *testing.T) {
+		// Creators racing for the same name; exactly one gets it.
+		s := fresh(t)
+		const n = 10
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		wins := 0
+		for range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := s.Create(ctx, store.Item{Name: "same"})
+				switch {
+				case err == nil:
+					mu.Lock()
+					wins++
+					mu.Unlock()
+				case !errors.Is(err, store.ErrDuplicateName):
+					t.Errorf("Create: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if wins != 1 {
+			t.Fatalf("%d of %d creates of the same name succeeded, want 1", wins, n)
+		}
+	})
+}
+
+// mustCreate creates an item with the name or fails the test.
+func mustCreate(t *testing.T, s store.ItemStore, name string) store.Item {
+	t.Helper()
+	it, err := s.Create(context.Background(), store.Item{Name: name})
+	if err != nil {
+		t.Fatalf("Create(%q): %v", name, err)
+	}
+	if it.Name != name {
+		t.Fatalf("Create(%q) returned %+v", name, it)
+	}
+	return it
+}
@@ -0,0 +1,218 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	ApiKeyScopes = "ApiKey.Scopes"
+	BearerScopes = "Bearer.Scopes"
+)
+
+// Brand defines model for Brand.
+type Brand struct {
+	CompanyId *CompanyRef `json:"companyId,omitempty"`
+	Id        int64       `json:"id"`
+	Name      string      `json:"name"`
+}
+
+// BrandInput defines model for BrandInput.
+type BrandInput struct {
+	CompanyId *CompanyRef `json:"companyId,omitempty"`
+	Name      string      `json:"name"`
+}
+
+// Company defines model for Company.
+type Company struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// CompanyInput defines model for CompanyInput.
+type CompanyInput struct {
+	Name string `json:"name"`
+}
+
+// CompanyRef defines model for CompanyRef.
+type CompanyRef = int64
+
+// CreatedId defines model for CreatedId.
+type CreatedId struct {
+	Id int64 `json:"id"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Error string `json:"error"`
+}
+
+// User defines model for User.
+type User struct {
+	CompanyId *CompanyRef `json:"companyId,omitempty"`
+	Id        int64       `json:"id"`
+	LastName  string      `json:"lastName"`
+	Name      string      `json:"name"`
+}
+
+// UserInput defines model for UserInput.
+type UserInput struct {
+	CompanyId *CompanyRef `json:"companyId,omitempty"`
+	LastName  string      `json:"lastName"`
+	Name      string      `json:"name"`
+}
+
+// Id defines model for Id.
+type Id = int64
+
+// BadRequest defines model for BadRequest.
+type BadRequest = Error
+
+// Created defines model for Created.
+type Created = CreatedId
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
+// InternalError defines model for InternalError.
+type InternalError = Error
+
+// NotFound defines model for NotFound.
+type NotFound = Error
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// Unavailable defines model for Unavailable.
+type Unavailable = Error
+
+// CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
+type CreateUserJSONRequestBody = UserInput
+
+// UpdateUserJSONRequestBody defines body for UpdateUser for application/json ContentType.
+type UpdateUserJSONRequestBody = UserInput
+
+// CreateCompanyJSONRequestBody defines body for CreateCompany for application/json ContentType.
+type CreateCompanyJSONRequestBody = CompanyInput
+
+// UpdateCompanyJSONRequestBody defines body for UpdateCompany for application/json ContentType.
+type UpdateCompanyJSONRequestBody = CompanyInput
+
+// CreateBrandJSONRequestBody defines body for CreateBrand for application/json ContentType.
+type CreateBrandJSONRequestBody = BrandInput
+
+// UpdateBrandJSONRequestBody defines body for UpdateBrand for application/json ContentType.
+type UpdateBrandJSONRequestBody = BrandInput
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xa32/bNhD+VwhuDw2gWEqcDaj2lKbt4LZJu6RBB6R+oMWzzVYiVfLkxQv0vw8kZVlO",
+	"/CMp7MxB/BLY1vH48e7j3UcxNzRRWa4kSDQ0vqE50ywDBO2+dbj9KySNac5wSAMqWQY0poLTgGr4UQgN",
+	"nMaoCwioSYaQMTuir3TG0NpJ/P2IBjQTUmRFRuODgOI4B/8IBqBpWZbWlcmVNOBmfcX4OfwowKD9liiJ",
+	"IN1HluepSBgKJcNvRkn723TSXzX0aUx/CacrCv1TE77RWlVTcTCJFrl1QmPakSOWCk60n5C8yFhq0QMn",
+	"7y4+ngWkz0QKnDgrNzNRmvQYJ53Xe7QM6IkGhsDXhrTy1+Hz0E4mKwP6Vume4Bzk5mN0ooGDRMFSQ1KW",
+	"fCc4BDJJPjGJysEi6kgELVnq/Wwc1aWE6xwStBBAj0DbxHCGrMcMEPDDAnqm8K0qJN88oDcSBY4JV2CI",
+	"VEjgWhi0ED4rdcrkuOK02TySc4ZAUpEJCyIB4MD/IAbAJe4cUI/3j/sImgyBcdA0oP6Dg9Z4Pgvl7r4t",
+	"A3opWYFDpcW/8AghPhXGCDmwmRbVxk2m5KQez4iJlPVS2Dyc1wyZUYVOgKgR6FQxDpy86BXpdxtQ0i/S",
+	"dG8zkS8n5dZXTM08w3OtctAooGJZljM57vBVKzzxhufQtzEUfG4Nv41i0gxqfAa1kAPq6/mkNVz5XuFM",
+	"u7UT1fsGidscDnpH5gWuDf8EVsauP4Ac4JDGB1HkmlD9PVgBeiHeaqq7YB81ahWKBXGbE4CjaN0RsMF+",
+	"aKuv22WH/3QA78ZpHsi6Cc3OAZOfl6/dm83ze2lAP/5GS5nBs/m0eTifGu4WrXC9+7GJ/kF7co1beemi",
+	"bTGFpNACxxd2BX61x7l4D+NaANcV22Oif+8ff+rsW4vaH/MjbFUDpj1Reu7T20mO3335TKvSbUf4p1MP",
+	"Q8Tcdxoh+8qOvyV6DGhDUjECIiQ5HV/89YG8CFkuwtHBXkB8ggQY+/STMjjQ0DA53CNM8q+yZ2uus/mo",
+	"WZLC5Hl7r0W+DEES29NtU/XtkghDQNqWygOiVYFW4ADwr9J2Naf/iA0Ktx5xCEKTBurWV2nXJ9B2ZHpa",
+	"pCj2G43z+FOHBnQE2vgVHrSiVmRDqHKQLBc0pu1W1GrTwB1BXGaqBYeFqTpnrvxZYTZYFxZZTJxV/I8W",
+	"CNS51W5VlsVVOXKb2pMGDL5SfLw27TDdTWVZ3j4y3T74HEYHi/zVdmHjDHAURavtG6cpN+QeU8yIOjeo",
+	"vXrQ9EBiRxy+XD3iti4uA/rbfVY0e9Bwo9r3WlStDG0qkA2MLQ+eRF370wyvwhvBS0+qFBB+il6v3dCa",
+	"XjOpPrrr8UyRk4p0253d6Gj1iPrg9TTpENABrKopGhi/k/M/AecnPFprUZl3Hvn4fseb/583zVdoV/Pd",
+	"TU1C+6KnG9BKbz24wlzmfKsa2I7kz4Hkda88DGvJuVKH1ZZLtdjkfL0ZNs+cm3eKbDtZNqXUfKbdV5mt",
+	"IpxXZ03C7QTa02LHcpFWWy4UagtzH6274uw62TazaO2SbVXp8bJt23rdjvXPiPV1Z22H/n3gSgHnzZaq",
+	"N38RtRk+N26KdsptO/lVEWkOue6r2ZZyzAu2Kcd2cu0JUWK5VqvyvkioLch5tN7ismtXW8udtSu0pYXG",
+	"y7NtamY7pj8Ppjfvoh3TJ7fQV90yuKnvla+6lt/+/978jih0Wt0fx2GYqoSlQ2UwfhlFES275X8DAMzP",
+	"UjfoKQAA",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,18 @@
+// Package api holds the OpenAPI contract of the /api/v1, /api/v2 and /api/v3
+// endpoints and the Go types generated from it. The gRPC API lives in
+// proto/entities/v1 (messages and stubs generated with buf, see buf.gen.yaml).
+//
+// Regenerate gen.go and the *.pb.go files after editing openapi.yaml or the .proto files:
+//
+//	go generate ./api
+package api
+
+import _ "embed"
+
+//go:generate oapi-codegen -config oapi-codegen.yaml openapi.yaml
+//go:generate buf generate
+
+// SpecYAML is openapi.yaml as written, served at /openapi.yaml.
+//
+//go:embed openapi.yaml
+var SpecYAML []byte
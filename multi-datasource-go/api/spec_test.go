@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// TestGeneratedSpecUpToDate fails when openapi.yaml was edited without
+// running `go generate ./api`, i.e. gen.go embeds an outdated contract.
+func TestGeneratedSpecUpToDate(t *testing.T) {
+	generated, err := GetSwagger()
+	if err != nil {
+		t.Fatalf("GetSwagger: %v", err)
+	}
+	written, err := openapi3.NewLoader().LoadFromData(SpecYAML)
+	if err != nil {
+		t.Fatalf("load openapi.yaml: %v", err)
+	}
+	if err := written.Validate(openapi3.NewLoader().Context); err != nil {
+		t.Fatalf("openapi.yaml is invalid: %v", err)
+	}
+
+	// oapi-codegen capitalizes operation IDs in the embedded copy; compare them case-insensitively.
+	for _, doc := range []*openapi3.T{generated, written} {
+		for _, item := range doc.Paths.Map() {
+			for _, op := range item.Operations() {
+				op.OperationID = strings.ToLower(op.OperationID)
+			}
+		}
+	}
+
+	a, _ := json.Marshal(generated)
+	b, _ := json.Marshal(written)
+	if string(a) != string(b) {
+		t.Fatal("gen.go is out of date with openapi.yaml; run `go generate ./api`")
+	}
+}
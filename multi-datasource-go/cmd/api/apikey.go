@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"multi-datasource-go/internal/auth"
+	"multi-datasource-go/internal/config"
+)
+
+// runAPIKey implements the `apikey` subcommand, which manages API keys
+// in the datasource configured under auth.apiKeys, e.g.:
+//
+//	go run ./cmd/api apikey create -name ci -scopes users:write,brands:read
+//	go run ./cmd/api apikey revoke -name ci
+//
+// The plain key is printed once on creation; only its hash is stored.
+func runAPIKey(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatalf("apikey: expected create or revoke")
+	}
+	fs := flag.NewFlagSet("apikey "+args[0], flag.ExitOnError)
+	name := fs.String("name", "", "key name, reported as the caller identity")
+	scopes := fs.String("scopes", "", "comma separated scopes, e.g. users:write,brands:read (create only)")
+	_ = fs.Parse(args[1:])
+	if *name == "" {
+		log.Fatalf("apikey: -name is required")
+	}
+
+	store := mustAPIKeyStore(cfg, mustDatasources(cfg))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	switch args[0] {
+	case "create":
+		key, hash, err := auth.GenerateAPIKey()
+		if err != nil {
+			log.Fatalf("apikey: %v", err)
+		}
+		k := &auth.APIKey{Name: *name, Scopes: splitScopes(*scopes), KeyHash: hash, CreatedAt: time.Now().UTC()}
+		if err := store.Create(ctx, k); err != nil {
+			log.Fatalf("apikey: %v", err)
+		}
+		fmt.Printf("created key %d for %s (scopes: %s)\n", k.ID, k.Name, strings.Join(k.Scopes, " "))
+		fmt.Printf("%s\n", key)
+		fmt.Println("store this key now; it cannot be shown again")
+	case "revoke":
+		n, err := store.Revoke(ctx, *name)
+		if err != nil {
+			log.Fatalf("apikey: %v", err)
+		}
+		fmt.Printf("revoked %d key(s) for %s\n", n, *name)
+	default:
+		log.Fatalf("apikey: unknown command %q (expected create or revoke)", args[0])
+	}
+}
+
+// splitScopes parses a comma separated scope list, dropping empty entries.
+func splitScopes(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+
+	entitiesv1 "multi-datasource-go/api/proto/entities/v1"
+	"multi-datasource-go/internal/config"
+	"multi-datasource-go/internal/grpc"
+
+	"google.golang.org/grpc/health"
+)
+
+// startGRPC serves the entity services over gRPC on the configured port.
+// Health statuses follow the datasource of each service, which is pinged every
+// healthIntervalSec. It terminates the program if the port cannot be bound.
+func startGRPC(cfg *config.Config, srv *grpc.Server, ds datasources) {
+	checks := map[string]grpc.HealthCheck{}
+	for service, datasource := range map[string]string{
+		entitiesv1.UserService_ServiceDesc.ServiceName:    cfg.Entities.Users,
+		entitiesv1.CompanyService_ServiceDesc.ServiceName: cfg.Entities.Companies,
+		entitiesv1.BrandService_ServiceDesc.ServiceName:   cfg.Entities.Brands,
+	} {
+		if check := ds.ping(datasource); check != nil {
+			checks[service] = check
+		}
+	}
+	srv.Health = health.NewServer()
+	go grpc.WatchHealth(context.Background(), srv.Health, time.Duration(cfg.GRPC.HealthIntervalSec)*time.Second, checks)
+
+	lis, err := net.Listen("tcp", ":"+itoa(cfg.GRPC.Port))
+	if err != nil {
+		log.Fatalf("grpc: %v", err)
+	}
+	g := srv.NewServer()
+	go func() {
+		log.Printf("grpc listening on %s (reflection: %t)", lis.Addr(), cfg.GRPC.Reflection)
+		if err := g.Serve(lis); err != nil {
+			log.Fatalf("grpc: %v", err)
+		}
+	}()
+}
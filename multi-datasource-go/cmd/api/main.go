@@ -5,12 +5,25 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net/netip"
+	"os"
+	"strings"
 	"time"
 
+	"multi-datasource-go/api"
+	"multi-datasource-go/internal/audit"
+	"multi-datasource-go/internal/auth"
+	"multi-datasource-go/internal/cache"
 	"multi-datasource-go/internal/config"
 	"multi-datasource-go/internal/db"
+	"multi-datasource-go/internal/domain"
+	"multi-datasource-go/internal/graphql"
+	"multi-datasource-go/internal/grpc"
 	"multi-datasource-go/internal/http"
+	"multi-datasource-go/internal/outbox"
+	"multi-datasource-go/internal/ratelimit"
 	"multi-datasource-go/internal/repo"
+	"multi-datasource-go/internal/webhook"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -25,56 +38,219 @@ func main() {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
+	// Subcommands: `api sync ...` copies an entity table between datasources and exits.
+	if len(os.Args) > 1 && os.Args[1] == "sync" {
+		runSync(cfg, os.Args[2:])
+		return
+	}
+	// `api apikey ...` creates or revokes API keys and exits.
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		runAPIKey(cfg, os.Args[2:])
+		return
+	}
+
 	// Open connection pools for each enabled datasource.
-	var (
-		mysqlDB  = mustMySQL(cfg)  // MySQL (users)
-		pgPool   = mustPG(cfg)     // PostgreSQL (companies)
-		oracleDB = mustOracle(cfg) // Oracle (brands)
-	)
+	ds := mustDatasources(cfg)
 
 	// Create tables for local development/demo if they don't already exist.
 	// This is a convenience for quick starts; remove in production.
-	createTables(mysqlDB, pgPool, oracleDB)
+	createTables(ds)
+
+	// Authentication middleware (nil when auth is disabled); the gRPC server
+	// uses the same authenticators.
+	var (
+		authenticators []auth.Authenticator
+		authn          gin.HandlerFunc
+	)
+	if cfg.Auth.Enabled {
+		authenticators = mustAuth(cfg, ds)
+		authn = auth.Middleware(authenticators...)
+	}
+
+	// Rate limits and datasource bulkheads per route group.
+	limits := mustGroupLimits(cfg, ds)
+
+	// Webhook subscriptions: deliveries are fed by the outbox relay below.
+	var webhooks *http.WebhookHandlers
+	if cfg.Webhooks.Enabled {
+		webhooks = mustWebhooks(cfg, ds)
+		webhooks.Auth = authn
+		gl := limits("webhooks", cfg.Webhooks.Datasource)
+		webhooks.PreAuth, webhooks.Middleware = gl.preAuth, gl.middleware
+		go webhooks.Dispatcher.Run(context.Background())
+	}
+
+	// Audit trail of entity changes. The repositories write the audit facts of a
+	// change into its outbox event, in the transaction of the change, and the
+	// relay hands them to the recorder, so no entry is lost.
+	var auditStore *audit.Store
+	if cfg.Audit.Enabled {
+		if !cfg.Outbox.Enabled {
+			log.Fatal("audit: audit.enabled requires outbox.enabled")
+		}
+		auditStore = mustAuditStore(cfg, ds)
+	}
+
+	// Publish entity change events written to the outbox tables.
+	if cfg.Outbox.Enabled {
+		publisher := mustPublisher(cfg)
+		if webhooks != nil {
+			publisher = outbox.MultiPublisher{publisher, webhooks.Dispatcher}
+		}
+		if auditStore != nil {
+			// First, so that an event is not delivered again when recording fails.
+			publisher = outbox.MultiPublisher{audit.NewRecorder(auditStore), publisher}
+		}
+		relay := &outbox.Relay{
+			Sources:     outboxSources(ds),
+			Publisher:   publisher,
+			Interval:    time.Duration(cfg.Outbox.PollIntervalMs) * time.Millisecond,
+			BatchSize:   cfg.Outbox.BatchSize,
+			MaxAttempts: cfg.Outbox.MaxAttempts,
+		}
+		go relay.Run(context.Background())
+		log.Printf("outbox relay started (publisher: %s)", cfg.Outbox.Publisher)
+	}
+
+	timeout := time.Duration(cfg.App.RequestTimeoutSec) * time.Second
+
+	// Requests to /api/v1..v3 are validated against the OpenAPI contract (api/openapi.yaml)
+	// before per-caller rate limits and bulkheads apply, so malformed requests cost no capacity.
+	validate := mustOpenAPIValidator()
+	userLimits := limits("users", cfg.Entities.Users)
+	companyLimits := limits("companies", cfg.Entities.Companies)
+	brandLimits := limits("brands", cfg.Entities.Brands)
+
+	// Repositories on the datasource of each entity, optionally decorated with read-through caches.
+	userRepo, companyRepo, brandRepo := mustRepos(cfg, ds)
+	var caches []cache.StatsProvider
+	if c := entityCache[domain.User](cfg, "users", timeout); c != nil {
+		userRepo, caches = cache.NewUserRepo(userRepo, c), append(caches, c)
+	}
+	if c := entityCache[domain.Company](cfg, "companies", timeout); c != nil {
+		companyRepo, caches = cache.NewCompanyRepo(companyRepo, c), append(caches, c)
+	}
+	if c := entityCache[domain.Brand](cfg, "brands", timeout); c != nil {
+		brandRepo, caches = cache.NewBrandRepo(brandRepo, c), append(caches, c)
+	}
+
+	// Services (validation over repositories) shared by the HTTP and gRPC transports.
+	var (
+		users     = domain.NewUserService(userRepo, timeout)
+		companies = domain.NewCompanyService(companyRepo, timeout)
+		brands    = domain.NewBrandService(brandRepo, timeout)
+	)
 
-	// Build HTTP handlers with repositories and request timeout.
+	// Build HTTP handlers with services and request timeout.
 	h := &http.Handlers{
-		Users:     repo.NewMySQLUserRepo(mysqlDB),
-		Companies: repo.NewPGCompanyRepo(pgPool),
-		Brands:    repo.NewOracleBrandRepo(oracleDB),
-		Timeout:   time.Duration(cfg.App.RequestTimeoutSec) * time.Second,
+		Users:     users,
+		Companies: companies,
+		Brands:    brands,
+		Timeout:   timeout,
+		Auth:      authn,
+		PreAuth: map[string][]gin.HandlerFunc{
+			"users":     userLimits.preAuth,
+			"companies": companyLimits.preAuth,
+			"brands":    brandLimits.preAuth,
+		},
+		Middleware: map[string][]gin.HandlerFunc{
+			"users":     append([]gin.HandlerFunc{validate}, userLimits.middleware...),
+			"companies": append([]gin.HandlerFunc{validate}, companyLimits.middleware...),
+			"brands":    append([]gin.HandlerFunc{validate}, brandLimits.middleware...),
+		},
 	}
 
 	// Initialize Gin router and register routes.
-	r := gin.New()
-	// Add recovery middleware; consider adding gin.Logger() for request logs.
-	r.Use(gin.Recovery())
+	r, err := http.NewEngine(cfg.App.TrustedProxies)
+	if err != nil {
+		log.Fatalf("app.trustedProxies: %v", err)
+	}
 	h.Register(r)
+	(&http.OpenAPIHandlers{Spec: api.SpecYAML}).Register(r)
+	if webhooks != nil {
+		webhooks.Register(r)
+	}
+	if len(caches) > 0 {
+		(&http.CacheHandlers{Caches: caches, Auth: authn}).Register(r)
+	}
+	if auditStore != nil {
+		gl := limits("audit", cfg.Audit.Datasource)
+		(&http.AuditHandlers{
+			Store:      auditStore,
+			Timeout:    timeout,
+			Auth:       authn,
+			PreAuth:    gl.preAuth,
+			Middleware: gl.middleware,
+		}).Register(r)
+	}
 
-	// Start HTTP server on configured port.
-	addr := ":" + itoa(cfg.App.HTTPPort)
-	log.Printf("listening on %s", addr)
-	if err := r.Run(addr); err != nil {
-		log.Fatal(err)
+	if cfg.GraphQL.Enabled {
+		gl := limits("graphql", "")
+		(&http.GraphQLHandlers{
+			Server:     mustGraphQL(cfg, users, companies, brands),
+			Timeout:    timeout,
+			Auth:       authn,
+			PreAuth:    gl.preAuth,
+			Middleware: gl.middleware,
+		}).Register(r)
 	}
+
+	// Serve the same services over gRPC on their own port.
+	if cfg.GRPC.Enabled {
+		startGRPC(cfg, &grpc.Server{
+			Users:          users,
+			Companies:      companies,
+			Brands:         brands,
+			Timeout:        timeout,
+			Authenticators: authenticators,
+			Limits: map[string]grpc.Limits{
+				"users":     userLimits.grpc,
+				"companies": companyLimits.grpc,
+				"brands":    brandLimits.grpc,
+			},
+			Reflection: cfg.GRPC.Reflection,
+		}, ds)
+	}
+
+	// Start the HTTP(S) server on the configured port.
+	serveHTTP(mustHTTPServer(cfg, r))
 }
 
 // itoa converts an int to string using fmt.Sprintf.
 // Small helper to avoid importing strconv explicitly.
 func itoa(i int) string { return fmt.Sprintf("%d", i) }
 
+// dbOptions converts the configuration of a datasource into connection pool options.
+func dbOptions(c config.DB) db.Options {
+	return db.Options{
+		MaxOpenConns:       c.MaxOpenConns,
+		MaxIdleConns:       c.MaxIdleConns,
+		MinConns:           c.MinConns,
+		ConnMaxLifetime:    c.ConnMaxLifetime,
+		ConnMaxIdleTime:    c.ConnMaxIdleTime,
+		HealthCheckPeriod:  c.HealthCheckPeriod,
+		ConnectTimeout:     c.ConnectTimeout,
+		StatementCacheMode: c.StatementCacheMode,
+		TLS: db.TLSOptions{
+			Mode:           c.TLS.Mode,
+			CAFile:         c.TLS.CAFile,
+			CertFile:       c.TLS.CertFile,
+			KeyFile:        c.TLS.KeyFile,
+			ServerName:     c.TLS.ServerName,
+			Wallet:         c.TLS.Wallet,
+			WalletPassword: c.TLS.WalletPassword,
+		},
+		InitStatements: c.InitStatements,
+	}
+}
+
 // mustMySQL opens a MySQL *sql.DB pool using configuration values.
 // It terminates the program if the connection cannot be established.
 func mustMySQL(cfg *config.Config) *sql.DB {
 	if !cfg.MySQL.Enabled {
 		return nil
 	}
-	dbx, err := db.OpenMySQL(
-		cfg.MySQL.DSN,
-		cfg.MySQL.MaxOpenConns,
-		cfg.MySQL.MaxIdleConns,
-		cfg.MySQL.ConnMaxLifetimeMin,
-		cfg.MySQL.ConnMaxIdleMin,
-	)
+	dbx, err := db.OpenMySQL(cfg.MySQL.DSN, dbOptions(cfg.MySQL))
 	if err != nil {
 		log.Fatalf("mysql: %v", err)
 	}
@@ -87,15 +263,7 @@ func mustPG(cfg *config.Config) *pgxpool.Pool {
 	if !cfg.Postgres.Enabled {
 		return nil
 	}
-	// OpenPGPool signature: (ctx, dsn, maxConns, minConns, lifeMin, idleMin)
-	pool, err := db.OpenPGPool(
-		context.Background(),
-		cfg.Postgres.DSN,
-		cfg.Postgres.MaxOpenConns,       // maxConns
-		cfg.Postgres.MaxIdleConns,       // minConns (reasonable proxy from idle)
-		cfg.Postgres.ConnMaxLifetimeMin, // lifeMin
-		cfg.Postgres.ConnMaxIdleMin,     // idleMin
-	)
+	pool, err := db.OpenPGPool(context.Background(), cfg.Postgres.DSN, dbOptions(cfg.Postgres))
 	if err != nil {
 		log.Fatalf("postgres: %v", err)
 	}
@@ -108,69 +276,455 @@ func mustOracle(cfg *config.Config) *sql.DB {
 	if !cfg.Oracle.Enabled {
 		return nil
 	}
-	dbx, err := db.OpenOracle(
-		cfg.Oracle.DSN,
-		cfg.Oracle.MaxOpenConns,
-		cfg.Oracle.MaxIdleConns,
-		cfg.Oracle.ConnMaxLifetimeMin,
-		cfg.Oracle.ConnMaxIdleMin,
-	)
+	dbx, err := db.OpenOracle(cfg.Oracle.DSN, dbOptions(cfg.Oracle))
 	if err != nil {
 		log.Fatalf("oracle: %v", err)
 	}
 	return dbx
 }
 
+// mustSQLite opens a SQLite *sql.DB pool on a local database file using configuration values.
+// It terminates the program if the file cannot be opened.
+func mustSQLite(cfg *config.Config) *sql.DB {
+	if !cfg.SQLite.Enabled {
+		return nil
+	}
+	dbx, err := db.OpenSQLite(cfg.SQLite.DSN, dbOptions(cfg.SQLite))
+	if err != nil {
+		log.Fatalf("sqlite: %v", err)
+	}
+	return dbx
+}
+
+// datasources holds the connection pools of all datasources; disabled ones are nil.
+type datasources struct {
+	mysql  *sql.DB       // MySQL (users)
+	pg     *pgxpool.Pool // PostgreSQL (companies)
+	oracle *sql.DB       // Oracle (brands)
+	sqlite *sql.DB       // SQLite (any entity, for local development)
+}
+
+// mustDatasources opens a connection pool for each enabled datasource.
+func mustDatasources(cfg *config.Config) datasources {
+	return datasources{
+		mysql:  mustMySQL(cfg),
+		pg:     mustPG(cfg),
+		oracle: mustOracle(cfg),
+		sqlite: mustSQLite(cfg),
+	}
+}
+
+// sql returns the dialect and *sql.DB of a named, enabled datasource.
+// Postgres is exposed through database/sql on top of the shared pgx pool.
+func (ds datasources) sql(name string) (db.Dialect, *sql.DB, error) {
+	d, err := db.ParseDialect(name)
+	if err != nil {
+		return "", nil, err
+	}
+	var dbx *sql.DB
+	switch d {
+	case db.MySQL:
+		dbx = ds.mysql
+	case db.Postgres:
+		if ds.pg != nil {
+			dbx = db.SQLFromPool(ds.pg)
+		}
+	case db.Oracle:
+		dbx = ds.oracle
+	case db.SQLite:
+		dbx = ds.sqlite
+	}
+	if dbx == nil {
+		return "", nil, fmt.Errorf("datasource %s is disabled in application.yaml", name)
+	}
+	return d, dbx, nil
+}
+
+// ping returns the health check of a named datasource, or nil when it is disabled.
+func (ds datasources) ping(name string) grpc.HealthCheck {
+	switch db.Dialect(name) {
+	case db.MySQL:
+		if ds.mysql != nil {
+			return ds.mysql.PingContext
+		}
+	case db.Postgres:
+		if ds.pg != nil {
+			return ds.pg.Ping
+		}
+	case db.Oracle:
+		if ds.oracle != nil {
+			return ds.oracle.PingContext
+		}
+	case db.SQLite:
+		if ds.sqlite != nil {
+			return ds.sqlite.PingContext
+		}
+	}
+	return nil
+}
+
+// mustRepos builds the repository of each entity on the datasource named in
+// the entities section: the entity's own database or SQLite.
+// It terminates the program if that datasource is disabled or unsupported.
+func mustRepos(cfg *config.Config, ds datasources) (domain.UserRepo, domain.CompanyRepo, domain.BrandRepo) {
+	resolve := func(entity, name string, own db.Dialect) (db.Dialect, *sql.DB) {
+		d, dbx, err := ds.sql(name)
+		if err == nil && d != own && d != db.SQLite {
+			err = fmt.Errorf("%s are stored in %s or sqlite, not %s", entity, own, d)
+		}
+		if err != nil {
+			log.Fatalf("entities: %s: %v", entity, err)
+		}
+		log.Printf("%s are stored in %s", entity, d)
+		return d, dbx
+	}
+
+	var (
+		users     domain.UserRepo
+		companies domain.CompanyRepo
+		brands    domain.BrandRepo
+	)
+	if d, dbx := resolve("users", cfg.Entities.Users, db.MySQL); d == db.SQLite {
+		users = repo.NewSQLiteUserRepo(dbx)
+	} else {
+		users = repo.NewMySQLUserRepo(dbx)
+	}
+	if d, dbx := resolve("companies", cfg.Entities.Companies, db.Postgres); d == db.SQLite {
+		companies = repo.NewSQLiteCompanyRepo(dbx)
+	} else {
+		companies = repo.NewPGCompanyRepo(ds.pg) // pgx natively, not through database/sql
+	}
+	if d, dbx := resolve("brands", cfg.Entities.Brands, db.Oracle); d == db.SQLite {
+		brands = repo.NewSQLiteBrandRepo(dbx)
+	} else {
+		brands = repo.NewOracleBrandRepo(dbx)
+	}
+	return users, companies, brands
+}
+
+// mustWebhooks builds the webhook store, dispatcher and handlers on the configured datasource.
+// It terminates the program if the datasource is unavailable.
+func mustWebhooks(cfg *config.Config, ds datasources) *http.WebhookHandlers {
+	d, dbx, err := ds.sql(cfg.Webhooks.Datasource)
+	if err != nil {
+		log.Fatalf("webhooks: %v", err)
+	}
+	store := webhook.NewStore(d, dbx)
+	var allowed []netip.Prefix
+	for _, s := range cfg.Webhooks.AllowedNetworks {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			log.Fatalf("webhooks.allowedNetworks: %v", err)
+		}
+		allowed = append(allowed, p)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := store.EnsureTables(ctx); err != nil {
+		log.Printf("webhooks create tables: %v", err)
+	} else {
+		log.Printf("✅ ensured %s tables: webhook_subscriptions, webhook_deliveries", d)
+	}
+
+	return &http.WebhookHandlers{
+		Store: store,
+		Dispatcher: &webhook.Dispatcher{
+			Store:          store,
+			Client:         webhook.NewClient(time.Duration(cfg.Webhooks.TimeoutSec)*time.Second, allowed),
+			MaxAttempts:    cfg.Webhooks.MaxAttempts,
+			InitialBackoff: time.Duration(cfg.Webhooks.InitialBackoffSec) * time.Second,
+			MaxBackoff:     time.Duration(cfg.Webhooks.MaxBackoffSec) * time.Second,
+			Interval:       time.Duration(cfg.Webhooks.PollIntervalMs) * time.Millisecond,
+		},
+		Timeout: time.Duration(cfg.App.RequestTimeoutSec) * time.Second,
+	}
+}
+
+// groupLimits is the rate limiting and load shedding middleware of a route group.
+type groupLimits struct {
+	preAuth    []gin.HandlerFunc // per-IP rate limit, run before authentication
+	middleware []gin.HandlerFunc // per-caller rate limit and datasource bulkhead, run after it
+	grpc       grpc.Limits       // the same limits for the gRPC methods of the group
+}
+
+// mustGroupLimits builds the rate limiter and the per-datasource bulkheads.
+// The returned function yields the middleware of a route group served by the
+// named datasource; it is empty when both features are disabled.
+// It terminates the program if the limiter backend is misconfigured.
+func mustGroupLimits(cfg *config.Config, ds datasources) func(group, datasource string) groupLimits {
+	var limiter ratelimit.Limiter
+	if cfg.RateLimit.Enabled {
+		switch cfg.RateLimit.Backend {
+		case "memory":
+			limiter = ratelimit.NewMemoryLimiter()
+		case "sql":
+			d, dbx, err := ds.sql(cfg.RateLimit.Datasource)
+			if err != nil {
+				log.Fatalf("ratelimit: %v", err)
+			}
+			l := ratelimit.NewSQLLimiter(d, dbx)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := l.EnsureTable(ctx); err != nil {
+				log.Printf("ratelimit create table: %v", err)
+			} else {
+				log.Printf("✅ ensured %s table: rate_limit_buckets", d)
+			}
+			limiter = l
+		default:
+			log.Fatalf("ratelimit: unknown backend %q (expected memory or sql)", cfg.RateLimit.Backend)
+		}
+		log.Printf("rate limiting enabled (backend: %s)", cfg.RateLimit.Backend)
+	}
+
+	bulkheads := map[string]*ratelimit.Bulkhead{}
+	if cfg.Bulkhead.Enabled {
+		for name, dbc := range map[string]config.DB{"mysql": cfg.MySQL, "postgres": cfg.Postgres, "oracle": cfg.Oracle, "sqlite": cfg.SQLite} {
+			if dbc.Enabled {
+				bulkheads[name] = ratelimit.NewBulkhead(name, dbc.MaxConcurrent,
+					time.Duration(cfg.Bulkhead.MaxWaitMs)*time.Millisecond,
+					time.Duration(cfg.Bulkhead.RetryAfterSec)*time.Second)
+			}
+		}
+	}
+
+	return func(group, datasource string) groupLimits {
+		var gl groupLimits
+		if limiter != nil {
+			rule := cfg.RateLimit.Rule(group)
+			rate := ratelimit.Rate{PerSecond: rule.RequestsPerSec, Burst: rule.Burst}
+			gl.preAuth = append(gl.preAuth, ratelimit.ClientIPMiddleware(limiter, group, rate))
+			gl.middleware = append(gl.middleware, ratelimit.Middleware(limiter, group, rate))
+			gl.grpc.Limiter, gl.grpc.Rate = limiter, rate
+		}
+		if b, ok := bulkheads[strings.ToLower(datasource)]; ok {
+			gl.middleware = append(gl.middleware, b.Middleware())
+			gl.grpc.Bulkhead = b
+		}
+		return gl
+	}
+}
+
+// mustGraphQL builds the GraphQL server over the services.
+// It terminates the program if the schema cannot be bound to the resolvers.
+func mustGraphQL(cfg *config.Config, users domain.UserService, companies domain.CompanyService, brands domain.BrandService) *graphql.Server {
+	srv, err := graphql.New(users, companies, brands, graphql.Options{
+		MaxDepth:      cfg.GraphQL.MaxDepth,
+		MaxComplexity: cfg.GraphQL.MaxComplexity,
+		ListCost:      cfg.GraphQL.ListCost,
+		BatchWait:     time.Duration(cfg.GraphQL.BatchWaitMs) * time.Millisecond,
+		Authorize:     cfg.Auth.Enabled,
+	})
+	if err != nil {
+		log.Fatalf("graphql: %v", err)
+	}
+	return srv
+}
+
+// mustOpenAPIValidator builds the request validation middleware from the embedded spec.
+// It terminates the program if the spec cannot be loaded.
+func mustOpenAPIValidator() gin.HandlerFunc {
+	swagger, err := api.GetSwagger()
+	if err != nil {
+		log.Fatalf("openapi: load spec: %v", err)
+	}
+	validate, err := http.OpenAPIValidator(swagger)
+	if err != nil {
+		log.Fatalf("openapi: %v", err)
+	}
+	return validate
+}
+
+// entityCache returns the in-memory cache of the named entity, loading
+// entities for at most loadTimeout, or nil when caching is disabled for it.
+func entityCache[T any](cfg *config.Config, name string, loadTimeout time.Duration) *cache.Cache[T] {
+	rule, ok := cfg.Cache.Entities[name]
+	if !cfg.Cache.Enabled || !ok || !rule.Enabled {
+		return nil
+	}
+	log.Printf("caching %s (ttl %ds, max %d entries)", name, rule.TTLSec, rule.MaxEntries)
+	return cache.New[T](name, cache.NewLRU(rule.MaxEntries), time.Duration(rule.TTLSec)*time.Second, loadTimeout)
+}
+
+// mustAuditStore opens the audit store on the configured datasource and ensures its table.
+// It terminates the program if the datasource is unavailable.
+func mustAuditStore(cfg *config.Config, ds datasources) *audit.Store {
+	d, dbx, err := ds.sql(cfg.Audit.Datasource)
+	if err != nil {
+		log.Fatalf("audit: %v", err)
+	}
+	store := audit.NewStore(d, dbx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := store.EnsureTable(ctx); err != nil {
+		log.Printf("audit create table: %v", err)
+	} else {
+		log.Printf("✅ ensured %s table: audit_log", d)
+	}
+	return store
+}
+
+// mustAPIKeyStore opens the API key store on the configured datasource and ensures its table.
+// It terminates the program if the datasource is unavailable.
+func mustAPIKeyStore(cfg *config.Config, ds datasources) *auth.APIKeyStore {
+	d, dbx, err := ds.sql(cfg.Auth.APIKeys.Datasource)
+	if err != nil {
+		log.Fatalf("auth: api keys: %v", err)
+	}
+	store := auth.NewAPIKeyStore(d, dbx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := store.EnsureTable(ctx); err != nil {
+		log.Printf("auth create table: %v", err)
+	} else {
+		log.Printf("✅ ensured %s table: api_keys", d)
+	}
+	return store
+}
+
+// mustAuth builds the enabled authenticators.
+// It terminates the program if no authenticator is enabled or one is misconfigured.
+func mustAuth(cfg *config.Config, ds datasources) []auth.Authenticator {
+	var authenticators []auth.Authenticator
+	if cfg.Auth.APIKeys.Enabled {
+		authenticators = append(authenticators, &auth.APIKeyAuthenticator{
+			Store:   mustAPIKeyStore(cfg, ds),
+			Timeout: time.Duration(cfg.App.RequestTimeoutSec) * time.Second,
+		})
+	}
+	if cfg.Auth.JWT.Enabled {
+		jwtAuth, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+			HS256Secret: cfg.Auth.JWT.HS256Secret,
+			JWKSFile:    cfg.Auth.JWT.JWKSFile,
+			Issuer:      cfg.Auth.JWT.Issuer,
+			Audience:    cfg.Auth.JWT.Audience,
+			Leeway:      time.Duration(cfg.Auth.JWT.LeewaySec) * time.Second,
+		})
+		if err != nil {
+			log.Fatalf("auth: %v", err)
+		}
+		authenticators = append(authenticators, jwtAuth)
+	}
+	if len(authenticators) == 0 {
+		log.Fatalf("auth: enabled but neither apiKeys nor jwt is enabled")
+	}
+	log.Printf("authentication enabled (%d authenticators)", len(authenticators))
+	return authenticators
+}
+
+// outboxSources lists the enabled datasources whose outbox tables are relayed.
+func outboxSources(ds datasources) []outbox.Source {
+	var srcs []outbox.Source
+	if ds.mysql != nil {
+		srcs = append(srcs, outbox.Source{Name: "mysql", Dialect: db.MySQL, DB: ds.mysql})
+	}
+	if ds.pg != nil {
+		srcs = append(srcs, outbox.Source{Name: "postgres", Dialect: db.Postgres, DB: db.SQLFromPool(ds.pg)})
+	}
+	if ds.oracle != nil {
+		srcs = append(srcs, outbox.Source{Name: "oracle", Dialect: db.Oracle, DB: ds.oracle})
+	}
+	if ds.sqlite != nil {
+		srcs = append(srcs, outbox.Source{Name: "sqlite", Dialect: db.SQLite, DB: ds.sqlite})
+	}
+	return srcs
+}
+
+// mustPublisher builds the configured outbox publisher.
+// It terminates the program if the publisher cannot be created.
+func mustPublisher(cfg *config.Config) outbox.Publisher {
+	switch cfg.Outbox.Publisher {
+	case "memory":
+		return outbox.NewMemoryPublisher(1000)
+	case "file":
+		p, err := outbox.NewFilePublisher(cfg.Outbox.FilePath)
+		if err != nil {
+			log.Fatalf("outbox: %v", err)
+		}
+		return p
+	case "webhook":
+		return outbox.NewWebhookPublisher(cfg.Outbox.WebhookURL, time.Duration(cfg.Outbox.WebhookTimeoutSec)*time.Second)
+	default:
+		log.Fatalf("outbox: unknown publisher %q (expected memory, file or webhook)", cfg.Outbox.Publisher)
+		return nil
+	}
+}
+
 // createTables ensures demo/dev tables exist across all configured databases.
 // For production deployments, prefer migrations managed by a tool (e.g., goose, migrate, flyway).
-func createTables(mysqlDB *sql.DB, pgPool *pgxpool.Pool, oracleDB *sql.DB) {
+func createTables(ds datasources) {
 	// Use a bounded context so DDLs don't hang indefinitely.
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// --- MySQL (users table) ---
-	if mysqlDB != nil {
+	if ds.mysql != nil {
 		// Create the database (no-op if it already exists); helpful for local dev.
-		if _, err := mysqlDB.ExecContext(ctx, `
+		if _, err := ds.mysql.ExecContext(ctx, `
 			CREATE DATABASE IF NOT EXISTS test_db;
 		`); err != nil {
 			log.Printf("mysql create db: %v", err)
 		}
 		// Create users table if missing.
-		if _, err := mysqlDB.ExecContext(ctx, `
+		if _, err := ds.mysql.ExecContext(ctx, `
 			CREATE TABLE IF NOT EXISTS users (
 				id BIGINT AUTO_INCREMENT PRIMARY KEY,
 				name VARCHAR(100) NOT NULL,
-				last_name VARCHAR(100) NOT NULL
+				last_name VARCHAR(100) NOT NULL,
+				company_id BIGINT NULL,
+				updated_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6)
 			);
 		`); err != nil {
 			log.Printf("mysql create table: %v", err)
 		}
-		log.Println("✅ ensured MySQL table: users")
+		// Add the columns users tables created before them lack: the company reference,
+		// and the change time sync --watermark updated_at and the updates rely on.
+		addColumns(ctx, db.MySQL, ds.mysql, "users",
+			column{"company_id", `ALTER TABLE users ADD COLUMN company_id BIGINT NULL`},
+			column{"updated_at", `ALTER TABLE users ADD COLUMN updated_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6)`})
+		if err := outbox.EnsureTable(ctx, db.MySQL, ds.mysql); err != nil {
+			log.Printf("mysql create outbox table: %v", err)
+		}
+		log.Println("✅ ensured MySQL tables: users, outbox_events")
 	}
 
 	// --- PostgreSQL (companies table) ---
-	if pgPool != nil {
-		if _, err := pgPool.Exec(ctx, `
+	if ds.pg != nil {
+		pg := db.SQLFromPool(ds.pg)
+		if _, err := ds.pg.Exec(ctx, `
 			CREATE TABLE IF NOT EXISTS companies (
 				id BIGSERIAL PRIMARY KEY,
-				name TEXT NOT NULL
+				name TEXT NOT NULL,
+				updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
 			);
 		`); err != nil {
 			log.Printf("postgres create table: %v", err)
 		} else {
 			log.Println("✅ ensured Postgres table: companies")
 		}
+		// Add the change time to companies tables created before it existed.
+		addColumns(ctx, db.Postgres, pg, "companies",
+			column{"updated_at", `ALTER TABLE companies ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now()`})
+		if err := outbox.EnsureTable(ctx, db.Postgres, pg); err != nil {
+			log.Printf("postgres create outbox table: %v", err)
+		} else {
+			log.Println("✅ ensured Postgres table: outbox_events")
+		}
 	}
 
 	// --- Oracle (brands table) ---
-	if oracleDB != nil {
+	if ds.oracle != nil {
 		// Use PL/SQL block to create table only if it doesn't exist (ignore ORA-00955).
-		if _, err := oracleDB.ExecContext(ctx, `
+		if _, err := ds.oracle.ExecContext(ctx, `
 			BEGIN
 				EXECUTE IMMEDIATE 'CREATE TABLE brands (
 					id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
-					name VARCHAR2(100) NOT NULL
+					name VARCHAR2(100) NOT NULL,
+					company_id NUMBER(19) NULL,
+					updated_at TIMESTAMP DEFAULT SYSTIMESTAMP NOT NULL
 				)';
 			EXCEPTION
 				WHEN OTHERS THEN
@@ -181,5 +735,81 @@ func createTables(mysqlDB *sql.DB, pgPool *pgxpool.Pool, oracleDB *sql.DB) {
 		} else {
 			log.Println("✅ ensured Oracle table: brands")
 		}
+		// Add the company reference and the change time to brands tables created before them.
+		addColumns(ctx, db.Oracle, ds.oracle, "brands",
+			column{"company_id", `ALTER TABLE brands ADD (company_id NUMBER(19) NULL)`},
+			column{"updated_at", `ALTER TABLE brands ADD (updated_at TIMESTAMP DEFAULT SYSTIMESTAMP NOT NULL)`})
+		// Index the company reference for the brands-by-company lookups of the GraphQL API.
+		if _, err := ds.oracle.ExecContext(ctx, `
+			BEGIN
+				EXECUTE IMMEDIATE 'CREATE INDEX brands_company_idx ON brands (company_id)';
+			EXCEPTION
+				WHEN OTHERS THEN
+					IF SQLCODE != -955 THEN RAISE; END IF;
+			END;
+		`); err != nil {
+			log.Printf("oracle create brands_company_idx: %v", err)
+		}
+		if err := outbox.EnsureTable(ctx, db.Oracle, ds.oracle); err != nil {
+			log.Printf("oracle create outbox table: %v", err)
+		} else {
+			log.Println("✅ ensured Oracle table: outbox_events")
+		}
+	}
+
+	// --- SQLite (entities pointed at it for local development) ---
+	// The tables of all entities are created, so entities can be moved over one at a time.
+	// updated_at is written in the one text layout of db.SQLiteNow, so it sorts in time order.
+	if ds.sqlite != nil {
+		if _, err := ds.sqlite.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS users (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL,
+				last_name TEXT NOT NULL,
+				company_id INTEGER NULL,
+				updated_at TIMESTAMP NOT NULL DEFAULT (`+db.SQLiteNow+`)
+			);
+			CREATE TABLE IF NOT EXISTS companies (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL,
+				updated_at TIMESTAMP NOT NULL DEFAULT (`+db.SQLiteNow+`)
+			);
+			CREATE TABLE IF NOT EXISTS brands (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name TEXT NOT NULL,
+				company_id INTEGER NULL,
+				updated_at TIMESTAMP NOT NULL DEFAULT (`+db.SQLiteNow+`)
+			);
+			CREATE INDEX IF NOT EXISTS brands_company_idx ON brands (company_id);
+		`); err != nil {
+			log.Printf("sqlite create tables: %v", err)
+		} else {
+			log.Println("✅ ensured SQLite tables: users, companies, brands")
+		}
+		if err := outbox.EnsureTable(ctx, db.SQLite, ds.sqlite); err != nil {
+			log.Printf("sqlite create outbox table: %v", err)
+		} else {
+			log.Println("✅ ensured SQLite table: outbox_events")
+		}
+	}
+}
+
+// column is a column added to an entity table after the table was first created.
+type column struct{ name, ddl string }
+
+// addColumns runs the DDL of the columns the table lacks.
+func addColumns(ctx context.Context, d db.Dialect, dbx *sql.DB, table string, cols ...column) {
+	for _, col := range cols {
+		has, err := d.HasColumn(ctx, dbx, table, col.name)
+		if err != nil {
+			log.Printf("%s inspect %s: %v", d, table, err)
+			return
+		}
+		if has {
+			continue
+		}
+		if _, err := dbx.ExecContext(ctx, col.ddl); err != nil {
+			log.Printf("%s add %s.%s: %v", d, table, col.name, err)
+		}
 	}
 }
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"log"
+	nethttp "net/http"
+
+	"multi-datasource-go/internal/certs"
+	"multi-datasource-go/internal/config"
+)
+
+// mustHTTPServer builds the HTTP server for the router with the configured
+// timeouts and header limit. With TLS enabled it serves HTTPS and negotiates
+// HTTP/2; the certificate files are re-read when they change. It terminates
+// the program if the TLS configuration is invalid.
+func mustHTTPServer(cfg *config.Config, h nethttp.Handler) *nethttp.Server {
+	app := cfg.App
+	srv := &nethttp.Server{
+		Addr:              ":" + itoa(app.HTTPPort),
+		Handler:           h,
+		ReadHeaderTimeout: app.ReadHeaderTimeout,
+		ReadTimeout:       app.ReadTimeout,
+		WriteTimeout:      app.WriteTimeout,
+		IdleTimeout:       app.IdleTimeout,
+		MaxHeaderBytes:    app.MaxHeaderBytes,
+		Protocols:         new(nethttp.Protocols),
+	}
+	srv.Protocols.SetHTTP1(true)
+	srv.Protocols.SetHTTP2(app.TLS.Enabled)
+	srv.Protocols.SetUnencryptedHTTP2(app.UnencryptedHTTP2 && !app.TLS.Enabled)
+
+	if app.TLS.Enabled {
+		auth, err := certs.ParseClientAuth(app.TLS.ClientAuth)
+		if err != nil {
+			log.Fatalf("http tls: %v", err)
+		}
+		files := &certs.Files{CAFile: app.TLS.ClientCAFile, CertFile: app.TLS.CertFile, KeyFile: app.TLS.KeyFile}
+		if srv.TLSConfig, err = certs.ServerConfig(files, auth, "h2", "http/1.1"); err != nil {
+			log.Fatalf("http tls: %v", err)
+		}
+		log.Printf("https: certificate %s, client auth %s", app.TLS.CertFile, auth)
+	}
+	return srv
+}
+
+// serveHTTP runs the server until it fails, over TLS when it has a TLS configuration.
+func serveHTTP(srv *nethttp.Server) {
+	var err error
+	if srv.TLSConfig != nil {
+		log.Printf("listening on %s (https)", srv.Addr)
+		err = srv.ListenAndServeTLS("", "")
+	} else {
+		log.Printf("listening on %s", srv.Addr)
+		err = srv.ListenAndServe()
+	}
+	log.Fatal(err)
+}
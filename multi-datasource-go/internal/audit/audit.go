@@ -0,0 +1,140 @@
+// Package audit keeps a compliance trail of entity changes.
+//
+// Repositories write every create, update and delete with its outbox event,
+// in the transaction of the change, together with the caller and the previous
+// state read in that transaction (outbox.Audit). The outbox Relay hands the
+// events to a Recorder, which turns each into an Entry (who, what, when, from
+// which request and datasource, and a before/after diff of the changed fields)
+// and writes it to the audit_log table of the configured audit datasource.
+// A change thus cannot commit without its entry following, at least once; the
+// Recorder skips events it already recorded. Failures of the audit datasource
+// are returned as outbox.Retry errors, so the Relay never dead-letters an
+// event before its entry is written.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"multi-datasource-go/internal/outbox"
+)
+
+// Entry is one recorded change.
+type Entry struct {
+	ID         int64           `json:"id"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Actor      string          `json:"actor"`      // authenticated caller, or "anonymous"
+	Action     string          `json:"action"`     // create, update or delete
+	EntityType string          `json:"entityType"` // user, company or brand
+	EntityID   int64           `json:"entityId"`
+	Changes    json.RawMessage `json:"changes"`    // {"field": {"before": ..., "after": ...}}
+	RequestID  string          `json:"requestId"`  // X-Request-ID of the originating request
+	Datasource string          `json:"datasource"` // datasource the entity lives in
+	EventKey   string          `json:"-"`          // key of the outbox event recorded (outbox.Event.Key)
+}
+
+// FieldChange holds the old and new value of one changed field.
+type FieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Diff compares the JSON representations of two entity states and returns the
+// changed fields as {"field": {"before": old, "after": new}}. A nil state
+// (before a create, after a delete) contributes null for every field.
+func Diff(before, after any) (json.RawMessage, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]FieldChange{}
+	for k, v := range b {
+		if av, ok := a[k]; !ok || !reflect.DeepEqual(v, av) {
+			changes[k] = FieldChange{Before: v, After: a[k]}
+		}
+	}
+	for k, v := range a {
+		if _, ok := b[k]; !ok {
+			changes[k] = FieldChange{After: v}
+		}
+	}
+	return json.Marshal(changes) // map keys are sorted, so the output is stable
+}
+
+// fields decodes the JSON object form of v; nil yields no fields.
+func fields(v any) (map[string]any, error) {
+	if v == nil || reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil() {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// actions maps the suffix of an event type onto the audit action.
+var actions = map[string]string{"created": "create", "updated": "update", "deleted": "delete"}
+
+// Recorder is the outbox.Publisher writing the audit trail.
+type Recorder struct {
+	store *Store
+}
+
+// NewRecorder returns a publisher recording the events it receives in store.
+func NewRecorder(store *Store) *Recorder {
+	return &Recorder{store: store}
+}
+
+// Publish records the change of an event, unless it was recorded already.
+// Events written without audit facts, before the trail was fed by the outbox,
+// were recorded when they happened and are skipped.
+func (r *Recorder) Publish(ctx context.Context, e outbox.Event) error {
+	if e.Audit == nil {
+		return nil
+	}
+	_, suffix, _ := strings.Cut(e.Type, ".")
+	action, ok := actions[suffix]
+	if !ok {
+		return nil // not an entity change
+	}
+	if done, err := r.store.Recorded(ctx, e.Key()); err != nil || done {
+		return outbox.Retry(err)
+	}
+
+	var before, after any
+	if len(e.Audit.Before) > 0 {
+		before = e.Audit.Before
+	}
+	if action != "delete" {
+		after = e.Payload
+	}
+	changes, err := Diff(before, after)
+	if err != nil {
+		return fmt.Errorf("event %s: %w", e.Key(), err)
+	}
+	return outbox.Retry(r.store.Insert(ctx, &Entry{
+		OccurredAt: e.CreatedAt,
+		Actor:      e.Audit.Actor,
+		Action:     action,
+		EntityType: e.AggregateType,
+		EntityID:   e.AggregateID,
+		Changes:    changes,
+		RequestID:  e.Audit.RequestID,
+		Datasource: e.Source,
+		EventKey:   e.Key(),
+	}))
+}
@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"multi-datasource-go/internal/db"
+	"multi-datasource-go/internal/outbox"
+)
+
+func TestRecorderPublish(t *testing.T) {
+	ctx := t.Context()
+	dbx, err := db.OpenSQLite(filepath.Join(t.TempDir(), "audit.db"), db.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbx.Close()
+	store := NewStore(db.SQLite, dbx)
+	for range 2 {
+		if err := store.EnsureTable(ctx); err != nil {
+			t.Fatalf("EnsureTable: %v", err)
+		}
+	}
+	r := NewRecorder(store)
+
+	event := func(id int64, typ string, before, after any) outbox.Event {
+		t.Helper()
+		e, err := outbox.NewEvent("user", 7, typ, after)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e.ID, e.Source = id, "mysql"
+		e.Audit = &outbox.Audit{Actor: "ci", RequestID: "req-1"}
+		if before != nil {
+			if e.Audit.Before, err = json.Marshal(before); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return e
+	}
+	type user struct {
+		ID       int64  `json:"id"`
+		LastName string `json:"lastName"`
+	}
+	created := event(1, "user.created", nil, user{7, "Lovelace"})
+	updated := event(2, "user.updated", user{7, "Lovelace"}, user{7, "Byron"})
+	unaudited := event(4, "user.updated", nil, user{7, "King"})
+	unaudited.Audit = nil
+	for _, e := range []outbox.Event{
+		created,
+		updated,
+		updated, // redelivered by the Relay
+		event(3, "user.deleted", user{7, "Byron"}, user{ID: 7}),
+		unaudited,
+	} {
+		if err := r.Publish(ctx, e); err != nil {
+			t.Fatalf("publish %s: %v", e.Key(), err)
+		}
+	}
+
+	entries, err := store.List(ctx, Filter{EntityType: "user", EntityID: 7, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct{ action, changes string }{ // newest first
+		{"delete", `{"id":{"before":7,"after":null},"lastName":{"before":"Byron","after":null}}`},
+		{"update", `{"lastName":{"before":"Lovelace","after":"Byron"}}`},
+		{"create", `{"id":{"before":null,"after":7},"lastName":{"before":null,"after":"Lovelace"}}`},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("%d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Action != w.action || string(e.Changes) != w.changes || e.Actor != "ci" || e.RequestID != "req-1" || e.Datasource != "mysql" {
+			t.Errorf("entry %d = %+v (changes %s), want %s by ci in req-1 on mysql with %s", i, e, e.Changes, w.action, w.changes)
+		}
+	}
+}
+
+// An audit datasource failing longer than the outbox retries for must not
+// lose the entry: the event stays pending until the entry is written.
+func TestRecorderOutlastsDeadLetters(t *testing.T) {
+	ctx := t.Context()
+	open := func(name string) *sql.DB {
+		dbx, err := db.OpenSQLite(filepath.Join(t.TempDir(), name), db.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { dbx.Close() })
+		return dbx
+	}
+	src, auditDB := open("outbox.db"), open("audit.db")
+	if err := outbox.EnsureTable(ctx, db.SQLite, src); err != nil {
+		t.Fatal(err)
+	}
+	e, err := outbox.NewEvent("user", 7, "user.created", map[string]any{"id": 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Audit = &outbox.Audit{Actor: "ci"}
+	q, args := outbox.InsertStmt(db.SQLite, e)
+	if _, err := src.ExecContext(ctx, q, args...); err != nil {
+		t.Fatal(err)
+	}
+
+	// The audit_log table is missing, so every attempt to record fails.
+	store := NewStore(db.SQLite, auditDB)
+	downstream := outbox.NewMemoryPublisher(0)
+	r := &outbox.Relay{Publisher: outbox.MultiPublisher{NewRecorder(store), downstream}, BatchSize: 10, MaxAttempts: 3}
+	source := outbox.Source{Name: "sqlite", Dialect: db.SQLite, DB: src}
+	for i := range 5 {
+		if _, err := r.Drain(ctx, source); err != nil {
+			t.Fatalf("drain %d: %v", i+1, err)
+		}
+	}
+	var (
+		attempts int
+		dead     bool
+	)
+	if err := src.QueryRowContext(ctx, "SELECT attempts, dead_at IS NOT NULL FROM outbox_events").Scan(&attempts, &dead); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 5 || dead || len(downstream.Events()) != 0 {
+		t.Fatalf("%d attempts, dead %v, %d published; want 5 attempts, pending, none published", attempts, dead, len(downstream.Events()))
+	}
+
+	// Once the audit datasource is back, the entry is written and the event goes out.
+	if err := store.EnsureTable(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.Drain(ctx, source); err != nil || n != 1 {
+		t.Fatalf("drain after recovery: %d published, %v; want 1", n, err)
+	}
+	entries, err := store.List(ctx, Filter{EntityType: "user", EntityID: 7, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Action != "create" || entries[0].Actor != "ci" {
+		t.Fatalf("entries %+v, want the create by ci", entries)
+	}
+	if len(downstream.Events()) != 1 {
+		t.Fatalf("%d events published downstream, want 1", len(downstream.Events()))
+	}
+}
@@ -0,0 +1,240 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"multi-datasource-go/internal/db"
+)
+
+// ddl holds the CREATE statements of the audit_log table per dialect.
+var ddl = map[db.Dialect][]string{
+	db.MySQL: {`
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			occurred_at TIMESTAMP(6) NOT NULL,
+			actor VARCHAR(200) NOT NULL,
+			action VARCHAR(20) NOT NULL,
+			entity_type VARCHAR(50) NOT NULL,
+			entity_id BIGINT NOT NULL,
+			changes TEXT NOT NULL,
+			request_id VARCHAR(100) NULL,
+			datasource VARCHAR(20) NOT NULL,
+			event_key VARCHAR(100) NULL,
+			INDEX idx_audit_entity (entity_type, entity_id),
+			INDEX idx_audit_time (occurred_at)
+		)`},
+	db.Postgres: {`
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id BIGSERIAL PRIMARY KEY,
+			occurred_at TIMESTAMPTZ NOT NULL,
+			actor TEXT NOT NULL,
+			action TEXT NOT NULL,
+			entity_type TEXT NOT NULL,
+			entity_id BIGINT NOT NULL,
+			changes TEXT NOT NULL,
+			request_id TEXT NULL,
+			datasource TEXT NOT NULL,
+			event_key TEXT NULL
+		)`, `
+		CREATE INDEX IF NOT EXISTS idx_audit_entity ON audit_log (entity_type, entity_id)`, `
+		CREATE INDEX IF NOT EXISTS idx_audit_time ON audit_log (occurred_at)`},
+	db.Oracle: {`
+		BEGIN
+			EXECUTE IMMEDIATE 'CREATE TABLE audit_log (
+				id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+				occurred_at TIMESTAMP NOT NULL,
+				actor VARCHAR2(200) NOT NULL,
+				action VARCHAR2(20) NOT NULL,
+				entity_type VARCHAR2(50) NOT NULL,
+				entity_id NUMBER(19) NOT NULL,
+				changes CLOB NOT NULL,
+				request_id VARCHAR2(100) NULL,
+				datasource VARCHAR2(20) NOT NULL,
+				event_key VARCHAR2(100) NULL
+			)';
+			EXECUTE IMMEDIATE 'CREATE INDEX idx_audit_entity ON audit_log (entity_type, entity_id)';
+			EXECUTE IMMEDIATE 'CREATE INDEX idx_audit_time ON audit_log (occurred_at)';
+		EXCEPTION
+			WHEN OTHERS THEN
+				IF SQLCODE != -955 THEN RAISE; END IF; -- ORA-00955 = name is already used by an existing object
+		END;`},
+	db.SQLite: {`
+		CREATE TABLE IF NOT EXISTS audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			occurred_at TIMESTAMP NOT NULL,
+			actor TEXT NOT NULL,
+			action TEXT NOT NULL,
+			entity_type TEXT NOT NULL,
+			entity_id INTEGER NOT NULL,
+			changes TEXT NOT NULL,
+			request_id TEXT NULL,
+			datasource TEXT NOT NULL,
+			event_key TEXT NULL
+		)`, `
+		CREATE INDEX IF NOT EXISTS idx_audit_entity ON audit_log (entity_type, entity_id)`, `
+		CREATE INDEX IF NOT EXISTS idx_audit_time ON audit_log (occurred_at)`},
+}
+
+// eventKey holds, per dialect, the statement adding the event_key column to
+// audit_log tables created before it existed, and the unique index on it that
+// keeps an event from being recorded twice. Entries recorded before have none.
+var eventKey = map[db.Dialect][2]string{
+	db.MySQL: {
+		`ALTER TABLE audit_log ADD COLUMN event_key VARCHAR(100) NULL`,
+		`CREATE UNIQUE INDEX idx_audit_event ON audit_log (event_key)`, // fails if it exists; see EnsureTable
+	},
+	db.Postgres: {
+		`ALTER TABLE audit_log ADD COLUMN event_key TEXT NULL`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_event ON audit_log (event_key)`,
+	},
+	db.Oracle: {
+		`ALTER TABLE audit_log ADD (event_key VARCHAR2(100) NULL)`,
+		`BEGIN
+			EXECUTE IMMEDIATE 'CREATE UNIQUE INDEX idx_audit_event ON audit_log (event_key)';
+		EXCEPTION
+			WHEN OTHERS THEN
+				IF SQLCODE != -955 THEN RAISE; END IF;
+		END;`,
+	},
+	db.SQLite: {
+		`ALTER TABLE audit_log ADD COLUMN event_key TEXT NULL`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_audit_event ON audit_log (event_key)`,
+	},
+}
+
+// entryColumns is the column list shared by all audit_log SELECTs.
+const entryColumns = "id, occurred_at, actor, action, entity_type, entity_id, changes, request_id, datasource"
+
+// Store persists audit entries in one of the configured datasources.
+type Store struct {
+	dialect db.Dialect
+	db      *sql.DB
+}
+
+// NewStore creates a Store on top of the given connection pool.
+func NewStore(d db.Dialect, dbx *sql.DB) *Store {
+	return &Store{dialect: d, db: dbx}
+}
+
+// EnsureTable creates the audit_log table and its indexes if they do not exist,
+// and adds the event_key column to tables created before it existed.
+func (s *Store) EnsureTable(ctx context.Context) error {
+	for _, stmt := range ddl[s.dialect] {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	has, err := s.dialect.HasColumn(ctx, s.db, "audit_log", "event_key")
+	if err != nil {
+		return fmt.Errorf("inspect audit_log: %w", err)
+	}
+	if !has {
+		if _, err := s.db.ExecContext(ctx, eventKey[s.dialect][0]); err != nil {
+			return fmt.Errorf("add audit_log.event_key: %w", err)
+		}
+	}
+	if s.dialect == db.MySQL {
+		// MySQL has no CREATE INDEX IF NOT EXISTS.
+		var n int
+		if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM information_schema.statistics
+			WHERE table_schema = DATABASE() AND table_name = 'audit_log' AND index_name = 'idx_audit_event'`).Scan(&n); err != nil || n > 0 {
+			return err
+		}
+	}
+	_, err = s.db.ExecContext(ctx, eventKey[s.dialect][1])
+	return err
+}
+
+// Insert appends an entry and sets its ID.
+func (s *Store) Insert(ctx context.Context, e *Entry) error {
+	p := s.dialect.Placeholder
+	id, err := s.dialect.InsertID(ctx, s.db, fmt.Sprintf(`INSERT INTO audit_log
+		(occurred_at, actor, action, entity_type, entity_id, changes, request_id, datasource, event_key)
+		VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)`, p(1), p(2), p(3), p(4), p(5), p(6), p(7), p(8), p(9)),
+		e.OccurredAt, e.Actor, e.Action, e.EntityType, e.EntityID, string(e.Changes),
+		sql.NullString{String: e.RequestID, Valid: e.RequestID != ""}, e.Datasource,
+		sql.NullString{String: e.EventKey, Valid: e.EventKey != ""})
+	if err != nil {
+		return err
+	}
+	e.ID = id
+	return nil
+}
+
+// Recorded reports whether the entry of the outbox event with the key was stored.
+func (s *Store) Recorded(ctx context.Context, eventKey string) (bool, error) {
+	var n int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM audit_log WHERE event_key = "+s.dialect.Placeholder(1), eventKey).Scan(&n)
+	return n > 0, err
+}
+
+// Filter selects audit entries. Zero values are not filtered on.
+type Filter struct {
+	EntityType string
+	EntityID   int64
+	Actor      string
+	From       time.Time // inclusive
+	To         time.Time // exclusive
+	Limit      int
+}
+
+// List returns the entries matching the filter, newest first.
+func (s *Store) List(ctx context.Context, f Filter) ([]Entry, error) {
+	var (
+		where []string
+		args  []any
+	)
+	add := func(cond string, v any) {
+		args = append(args, v)
+		where = append(where, fmt.Sprintf(cond, s.dialect.Placeholder(len(args))))
+	}
+	if f.EntityType != "" {
+		add("entity_type = %s", f.EntityType)
+	}
+	if f.EntityID != 0 {
+		add("entity_id = %s", f.EntityID)
+	}
+	if f.Actor != "" {
+		add("actor = %s", f.Actor)
+	}
+	if !f.From.IsZero() {
+		add("occurred_at >= %s", f.From.UTC())
+	}
+	if !f.To.IsZero() {
+		add("occurred_at < %s", f.To.UTC())
+	}
+
+	q := "SELECT " + entryColumns + " FROM audit_log"
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+	q += " ORDER BY occurred_at DESC, id DESC " + s.dialect.Limit(f.Limit)
+
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Entry
+	for rows.Next() {
+		var (
+			e       Entry
+			changes string
+			reqID   sql.NullString
+		)
+		if err := rows.Scan(&e.ID, &e.OccurredAt, &e.Actor, &e.Action, &e.EntityType, &e.EntityID,
+			&changes, &reqID, &e.Datasource); err != nil {
+			return nil, err
+		}
+		e.Changes = []byte(changes)
+		e.RequestID = reqID.String
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"multi-datasource-go/internal/db"
+	"multi-datasource-go/internal/domain"
+)
+
+// APIKeyHeader is the request header carrying an API key.
+const APIKeyHeader = "X-API-Key"
+
+// apiKeyPrefix makes keys recognizable in logs and secret scanners.
+const apiKeyPrefix = "mds_"
+
+// APIKey is a stored API key. Only the SHA-256 hash of the key is persisted.
+type APIKey struct {
+	ID        int64
+	Name      string   // caller identity reported as Actor.ID
+	Scopes    []string // granted scopes
+	KeyHash   string   // hex(SHA-256(key))
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// HashAPIKey returns the hex encoded SHA-256 hash under which a key is stored.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateAPIKey returns a new random API key (to hand to the client once) and its hash.
+func GenerateAPIKey() (key, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, HashAPIKey(key), nil
+}
+
+// ddl holds the CREATE TABLE statement of the api_keys table per dialect.
+var ddl = map[db.Dialect]string{
+	db.MySQL: `
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			key_hash CHAR(64) NOT NULL UNIQUE,
+			scopes VARCHAR(1000) NOT NULL,
+			created_at TIMESTAMP(6) NOT NULL,
+			revoked_at TIMESTAMP(6) NULL
+		)`,
+	db.Postgres: `
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id BIGSERIAL PRIMARY KEY,
+			name TEXT NOT NULL,
+			key_hash CHAR(64) NOT NULL UNIQUE,
+			scopes TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			revoked_at TIMESTAMPTZ NULL
+		)`,
+	db.Oracle: `
+		BEGIN
+			EXECUTE IMMEDIATE 'CREATE TABLE api_keys (
+				id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+				name VARCHAR2(100) NOT NULL,
+				key_hash CHAR(64) NOT NULL UNIQUE,
+				scopes VARCHAR2(1000) NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				revoked_at TIMESTAMP NULL
+			)';
+		EXCEPTION
+			WHEN OTHERS THEN
+				IF SQLCODE != -955 THEN RAISE; END IF; -- ORA-00955 = name is already used by an existing object
+		END;`,
+	db.SQLite: `
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			key_hash CHAR(64) NOT NULL UNIQUE,
+			scopes TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			revoked_at TIMESTAMP NULL
+		)`,
+}
+
+// APIKeyStore persists hashed API keys in one of the configured datasources.
+type APIKeyStore struct {
+	dialect db.Dialect
+	db      *sql.DB
+}
+
+// NewAPIKeyStore creates an APIKeyStore on top of the given connection pool.
+func NewAPIKeyStore(d db.Dialect, dbx *sql.DB) *APIKeyStore {
+	return &APIKeyStore{dialect: d, db: dbx}
+}
+
+// EnsureTable creates the api_keys table if it does not exist.
+func (s *APIKeyStore) EnsureTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, ddl[s.dialect])
+	return err
+}
+
+// Create stores a new key and sets its ID.
+func (s *APIKeyStore) Create(ctx context.Context, k *APIKey) error {
+	p := s.dialect.Placeholder
+	id, err := s.dialect.InsertID(ctx, s.db,
+		fmt.Sprintf("INSERT INTO api_keys (name, key_hash, scopes, created_at) VALUES (%s, %s, %s, %s)", p(1), p(2), p(3), p(4)),
+		k.Name, k.KeyHash, strings.Join(k.Scopes, " "), k.CreatedAt)
+	if err != nil {
+		return err
+	}
+	k.ID = id
+	return nil
+}
+
+// Revoke disables all keys with the given name.
+func (s *APIKeyStore) Revoke(ctx context.Context, name string) (int64, error) {
+	p := s.dialect.Placeholder
+	res, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE api_keys SET revoked_at = %s WHERE name = %s AND revoked_at IS NULL", p(1), p(2)),
+		time.Now().UTC(), name)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// FindByHash returns the key stored under the hash, or ErrInvalidCredentials.
+func (s *APIKeyStore) FindByHash(ctx context.Context, hash string) (*APIKey, error) {
+	var (
+		k       APIKey
+		scopes  string
+		revoked sql.NullTime
+	)
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT id, name, key_hash, scopes, created_at, revoked_at FROM api_keys WHERE key_hash = %s",
+			s.dialect.Placeholder(1)), hash).
+		Scan(&k.ID, &k.Name, &k.KeyHash, &scopes, &k.CreatedAt, &revoked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	k.Scopes = strings.Fields(scopes)
+	if revoked.Valid {
+		k.RevokedAt = &revoked.Time
+	}
+	return &k, nil
+}
+
+// APIKeyAuthenticator authenticates requests carrying an X-API-Key header.
+type APIKeyAuthenticator struct {
+	Store   *APIKeyStore
+	Timeout time.Duration // bound for the key lookup
+}
+
+// Authenticate looks up the hash of the presented key.
+func (a *APIKeyAuthenticator) Authenticate(r *http.Request) (domain.Actor, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		return domain.Actor{}, ErrNoCredentials
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), a.Timeout)
+	defer cancel()
+
+	k, err := a.Store.FindByHash(ctx, HashAPIKey(key))
+	if err != nil {
+		return domain.Actor{}, err
+	}
+	if k.RevokedAt != nil {
+		return domain.Actor{}, ErrInvalidCredentials
+	}
+	return domain.Actor{ID: k.Name, Method: "api_key", Scopes: k.Scopes}, nil
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"database/sql"
+	"errors"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"multi-datasource-go/internal/db"
+)
+
+// newKeyStore returns a store on a SQLite file, with its connection pool.
+func newKeyStore(t *testing.T) (*APIKeyStore, *sql.DB) {
+	t.Helper()
+	dbx, err := db.OpenSQLite(filepath.Join(t.TempDir(), "keys.db"), db.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbx.Close() })
+	s := NewAPIKeyStore(db.SQLite, dbx)
+	for range 2 {
+		if err := s.EnsureTable(t.Context()); err != nil {
+			t.Fatalf("EnsureTable: %v", err)
+		}
+	}
+	return s, dbx
+}
+
+// createKey stores a new key and returns the plaintext handed to the client.
+func createKey(t *testing.T, s *APIKeyStore, name string, scopes ...string) string {
+	t.Helper()
+	key, hash, err := GenerateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Create(t.Context(), &APIKey{Name: name, Scopes: scopes, KeyHash: hash, CreatedAt: time.Now().UTC()}); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestAPIKeyStoreKeepsHashes(t *testing.T) {
+	s, dbx := newKeyStore(t)
+	key := createKey(t, s, "ci", "users:read")
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		t.Errorf("key %q lacks the %s prefix", key, apiKeyPrefix)
+	}
+
+	var stored string
+	if err := dbx.QueryRowContext(t.Context(), "SELECT key_hash FROM api_keys WHERE name = 'ci'").Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored != HashAPIKey(key) || len(stored) != 64 {
+		t.Errorf("stored %q, want the SHA-256 hex of the key", stored)
+	}
+	var n int
+	if err := dbx.QueryRowContext(t.Context(),
+		"SELECT COUNT(*) FROM api_keys WHERE name = ? OR key_hash = ? OR scopes = ?", key, key, key).Scan(&n); err != nil || n != 0 {
+		t.Errorf("%d rows hold the plaintext key (%v), want 0", n, err)
+	}
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	s, _ := newKeyStore(t)
+	good := createKey(t, s, "ci", "users:read", "users:write")
+	revoked := createKey(t, s, "old")
+	if n, err := s.Revoke(t.Context(), "old"); err != nil || n != 1 {
+		t.Fatalf("Revoke = %d, %v; want 1", n, err)
+	}
+	a := &APIKeyAuthenticator{Store: s, Timeout: time.Second}
+
+	tests := []struct {
+		name string
+		key  string
+		want error
+	}{
+		{"valid", good, nil},
+		{"revoked", revoked, ErrInvalidCredentials},
+		{"unknown", "mds_unknown", ErrInvalidCredentials},
+		{"stored hash as key", HashAPIKey(good), ErrInvalidCredentials},
+		{"no key", "", ErrNoCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if tt.key != "" {
+				r.Header.Set(APIKeyHeader, tt.key)
+			}
+			actor, err := a.Authenticate(r)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && (actor.ID != "ci" || actor.Method != "api_key" || !slices.Equal(actor.Scopes, []string{"users:read", "users:write"})) {
+				t.Errorf("actor %+v, want ci via api_key with users:read users:write", actor)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Package auth authenticates API callers and enforces scope-based authorization.
+//
+// Authenticators turn request credentials into a domain.Actor:
+//   - APIKeyAuthenticator: "X-API-Key: <key>", keys stored hashed in a datasource
+//   - JWTAuthenticator:    "Authorization: Bearer <jwt>", HS256 secret or JWKS file
+//
+// Middleware runs the authenticators and stores the Actor in the request context;
+// RequireScope rejects actors lacking a scope. Authenticate is the transport
+// neutral core, also used by the gRPC server.
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"multi-datasource-go/internal/domain"
+)
+
+var (
+	// ErrNoCredentials is returned when a request carries no credentials for an authenticator.
+	ErrNoCredentials = errors.New("no credentials")
+
+	// ErrInvalidCredentials is returned when credentials are present but not valid.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator resolves the caller of a request.
+// It returns ErrNoCredentials when the request carries no credentials it understands,
+// so the next authenticator can be tried.
+type Authenticator interface {
+	Authenticate(r *http.Request) (domain.Actor, error)
+}
+
+// Authenticate resolves the caller with the first authenticator that finds credentials.
+// It returns ErrNoCredentials when none does; errors other than ErrInvalidCredentials
+// (e.g. key store unavailable) are returned as is so callers can log them.
+func Authenticate(r *http.Request, authenticators ...Authenticator) (domain.Actor, error) {
+	for _, a := range authenticators {
+		actor, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return actor, err
+	}
+	return domain.Actor{}, ErrNoCredentials
+}
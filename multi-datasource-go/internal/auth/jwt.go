@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"multi-datasource-go/internal/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTConfig configures bearer token validation.
+// Exactly one of HS256Secret or JWKSFile must be set.
+type JWTConfig struct {
+	HS256Secret string        // shared secret for HS256 tokens
+	JWKSFile    string        // JSON Web Key Set with RSA/EC public keys (RS256/ES256 tokens)
+	Issuer      string        // expected "iss" claim; empty = not checked
+	Audience    string        // expected "aud" claim; empty = not checked
+	Leeway      time.Duration // tolerated clock skew for exp/nbf
+}
+
+// JWTAuthenticator authenticates requests carrying "Authorization: Bearer <jwt>".
+// The actor ID is the "sub" claim; scopes come from the space separated "scope"
+// claim (RFC 8693) or the "scp" array claim.
+type JWTAuthenticator struct {
+	parser  *jwt.Parser
+	keyFunc jwt.Keyfunc
+}
+
+// NewJWTAuthenticator validates the configuration and loads the JWKS file, if any.
+func NewJWTAuthenticator(cfg JWTConfig) (*JWTAuthenticator, error) {
+	opts := []jwt.ParserOption{jwt.WithExpirationRequired(), jwt.WithLeeway(cfg.Leeway)}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	a := &JWTAuthenticator{}
+	switch {
+	case cfg.HS256Secret != "" && cfg.JWKSFile != "":
+		return nil, errors.New("jwt: configure either hs256Secret or jwksFile, not both")
+	case cfg.HS256Secret != "":
+		secret := []byte(cfg.HS256Secret)
+		opts = append(opts, jwt.WithValidMethods([]string{"HS256"}))
+		a.keyFunc = func(*jwt.Token) (any, error) { return secret, nil }
+	case cfg.JWKSFile != "":
+		keys, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}))
+		a.keyFunc = func(t *jwt.Token) (any, error) {
+			kid, _ := t.Header["kid"].(string)
+			if k, ok := keys[kid]; ok {
+				return k, nil
+			}
+			if kid == "" && len(keys) == 1 { // single-key sets may omit the kid
+				for _, k := range keys {
+					return k, nil
+				}
+			}
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+	default:
+		return nil, errors.New("jwt: hs256Secret or jwksFile is required")
+	}
+	a.parser = jwt.NewParser(opts...)
+	return a, nil
+}
+
+// claims are the token claims used by the authenticator.
+type claims struct {
+	jwt.RegisteredClaims
+	Scope string   `json:"scope"`
+	Scp   []string `json:"scp"`
+}
+
+// Authenticate validates the bearer token and maps its claims onto an Actor.
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (domain.Actor, error) {
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, "Bearer ") {
+		return domain.Actor{}, ErrNoCredentials
+	}
+
+	var c claims
+	if _, err := a.parser.ParseWithClaims(strings.TrimPrefix(h, "Bearer "), &c, a.keyFunc); err != nil {
+		return domain.Actor{}, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+	if c.Subject == "" {
+		return domain.Actor{}, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	}
+	scopes := append(strings.Fields(c.Scope), c.Scp...)
+	return domain.Actor{ID: c.Subject, Method: "jwt", Scopes: scopes}, nil
+}
+
+// jwk is the subset of RFC 7517 fields needed for RSA and EC public keys.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// loadJWKS reads a JWKS file and returns its signing keys indexed by key ID.
+func loadJWKS(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: read jwks: %w", err)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("jwt: parse jwks: %w", err)
+	}
+
+	keys := map[string]any{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("jwt: key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = pub
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwt: jwks contains no signing keys")
+	}
+	return keys, nil
+}
+
+// publicKey decodes the JWK into an *rsa.PublicKey or *ecdsa.PublicKey.
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := b64Int(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := b64Int(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := b64Int(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := b64Int(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// b64Int decodes a base64url (unpadded) big-endian integer.
+func b64Int(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
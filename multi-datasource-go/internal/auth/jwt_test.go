@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// b64 encodes a big-endian integer as base64url without padding.
+func b64(i *big.Int) string { return base64.RawURLEncoding.EncodeToString(i.Bytes()) }
+
+func rsaJWK(kid string, k *rsa.PublicKey) jwk {
+	return jwk{Kty: "RSA", Kid: kid, Use: "sig", N: b64(k.N), E: b64(big.NewInt(int64(k.E)))}
+}
+
+func ecJWK(kid string, k *ecdsa.PublicKey) jwk {
+	return jwk{Kty: "EC", Kid: kid, Crv: "P-256", X: b64(k.X), Y: b64(k.Y)}
+}
+
+// writeJWKS writes a key set file and returns its path.
+func writeJWKS(t *testing.T, keys ...jwk) string {
+	t.Helper()
+	data, err := json.Marshal(map[string][]jwk{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return writeFile(t, data)
+}
+
+func writeFile(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// sign returns a token signed with key; kid is omitted when empty.
+func sign(t *testing.T, m jwt.SigningMethod, key any, kid string, c jwt.MapClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(m, c)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// authenticate runs a with the token as bearer credentials.
+func authenticate(a *JWTAuthenticator, token string) (string, []string, error) {
+	r := httptest.NewRequest("GET", "/", nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	actor, err := a.Authenticate(r)
+	return actor.ID, actor.Scopes, err
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	newAuth := func(cfg JWTConfig) *JWTAuthenticator {
+		t.Helper()
+		cfg.Issuer, cfg.Audience, cfg.Leeway = "https://issuer.test", "api", 30*time.Second
+		a, err := NewJWTAuthenticator(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	jwks := newAuth(JWTConfig{JWKSFile: writeJWKS(t, rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK("ec-1", &ecKey.PublicKey))})
+	single := newAuth(JWTConfig{JWKSFile: writeJWKS(t, rsaJWK("only", &rsaKey.PublicKey))})
+	hs256 := newAuth(JWTConfig{HS256Secret: "s3cret"})
+
+	now := time.Now()
+	valid := func(edit func(jwt.MapClaims)) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub": "ci", "iss": "https://issuer.test", "aud": "api",
+			"exp": now.Add(time.Hour).Unix(), "scope": "users:read users:write", "scp": []string{"audit:read"},
+		}
+		if edit != nil {
+			edit(c)
+		}
+		return c
+	}
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, valid(nil)).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		auth  *JWTAuthenticator
+		token string
+		want  error // nil = authenticated as ci
+	}{
+		{"RS256 by kid", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", valid(nil)), nil},
+		{"ES256 by kid", jwks, sign(t, jwt.SigningMethodES256, ecKey, "ec-1", valid(nil)), nil},
+		{"HS256 secret", hs256, sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", valid(nil)), nil},
+		{"single key without kid", single, sign(t, jwt.SigningMethodRS256, rsaKey, "", valid(nil)), nil},
+		{"no kid with several keys", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "", valid(nil)), ErrInvalidCredentials},
+		{"unknown kid", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-2", valid(nil)), ErrInvalidCredentials},
+		{"kid of another key", jwks, sign(t, jwt.SigningMethodRS256, otherKey, "rsa-1", valid(nil)), ErrInvalidCredentials},
+		{"alg none", jwks, none, ErrInvalidCredentials},
+		{"alg none with secret", hs256, none, ErrInvalidCredentials},
+		{"HS256 keyed with the JWKS public key", jwks, sign(t, jwt.SigningMethodHS256, pubPEM, "rsa-1", valid(nil)), ErrInvalidCredentials},
+		{"RS256 for an HS256 secret", hs256, sign(t, jwt.SigningMethodRS256, rsaKey, "", valid(nil)), ErrInvalidCredentials},
+		{"wrong secret", hs256, sign(t, jwt.SigningMethodHS256, []byte("guess"), "", valid(nil)), ErrInvalidCredentials},
+		{"no exp", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", valid(func(c jwt.MapClaims) { delete(c, "exp") })), ErrInvalidCredentials},
+		{"expired within leeway", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", valid(func(c jwt.MapClaims) { c["exp"] = now.Add(-10 * time.Second).Unix() })), nil},
+		{"expired", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", valid(func(c jwt.MapClaims) { c["exp"] = now.Add(-time.Minute).Unix() })), ErrInvalidCredentials},
+		{"not yet valid", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", valid(func(c jwt.MapClaims) { c["nbf"] = now.Add(time.Minute).Unix() })), ErrInvalidCredentials},
+		{"wrong issuer", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", valid(func(c jwt.MapClaims) { c["iss"] = "https://evil.test" })), ErrInvalidCredentials},
+		{"no issuer", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", valid(func(c jwt.MapClaims) { delete(c, "iss") })), ErrInvalidCredentials},
+		{"wrong audience", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", valid(func(c jwt.MapClaims) { c["aud"] = "other" })), ErrInvalidCredentials},
+		{"audience list", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", valid(func(c jwt.MapClaims) { c["aud"] = []string{"other", "api"} })), nil},
+		{"no subject", jwks, sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", valid(func(c jwt.MapClaims) { delete(c, "sub") })), ErrInvalidCredentials},
+		{"malformed", jwks, "not.a.jwt", ErrInvalidCredentials},
+		{"no bearer token", jwks, "", ErrNoCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, scopes, err := authenticate(tt.auth, tt.token)
+			if tt.want != nil {
+				if !errors.Is(err, tt.want) {
+					t.Fatalf("err = %v, want %v", err, tt.want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []string{"users:read", "users:write", "audit:read"}; sub != "ci" || !slices.Equal(scopes, want) {
+				t.Errorf("actor %q with %v, want ci with %v", sub, scopes, want)
+			}
+		})
+	}
+
+	// Other schemes are left to the next authenticator.
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Basic Y2k6cHc=")
+	if _, err := jwks.Authenticate(r); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("basic auth: err = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestNewJWTAuthenticatorRejects(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	good := ecJWK("ec-1", &ecKey.PublicKey)
+	withCurve := good
+	withCurve.Crv = "P-192"
+	badX := good
+	badX.X = "not base64!"
+	encOnly := good
+	encOnly.Use = "enc"
+
+	tests := []struct {
+		name string
+		cfg  JWTConfig
+	}{
+		{"no key", JWTConfig{}},
+		{"secret and JWKS", JWTConfig{HS256Secret: "s3cret", JWKSFile: writeJWKS(t, good)}},
+		{"missing file", JWTConfig{JWKSFile: filepath.Join(t.TempDir(), "missing.json")}},
+		{"not JSON", JWTConfig{JWKSFile: writeFile(t, []byte("-----BEGIN PUBLIC KEY-----"))}},
+		{"no keys", JWTConfig{JWKSFile: writeFile(t, []byte(`{"keys":[]}`))}},
+		{"only encryption keys", JWTConfig{JWKSFile: writeJWKS(t, encOnly)}},
+		{"symmetric key", JWTConfig{JWKSFile: writeJWKS(t, jwk{Kty: "oct", Kid: "hs"})}},
+		{"unsupported curve", JWTConfig{JWKSFile: writeJWKS(t, good, withCurve)}},
+		{"bad coordinate", JWTConfig{JWKSFile: writeJWKS(t, badX)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewJWTAuthenticator(tt.cfg); err == nil {
+				t.Error("configuration accepted")
+			}
+		})
+	}
+}
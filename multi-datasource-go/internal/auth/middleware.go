@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"multi-datasource-go/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// actorKey is the gin context key under which the Actor is stored.
+const actorKey = "auth.actor"
+
+// Middleware authenticates every request with the first authenticator that
+// finds credentials. Requests without valid credentials are rejected with 401;
+// when the credentials cannot be checked (e.g. the key store is down) the
+// answer is 503, so clients retry instead of taking their credentials for bad.
+// On success the Actor is stored in the request context (see domain.ActorFrom).
+func Middleware(authenticators ...Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, err := Authenticate(c.Request, authenticators...)
+		switch {
+		case errors.Is(err, ErrNoCredentials):
+			unauthorized(c, "missing credentials")
+			return
+		case errors.Is(err, ErrInvalidCredentials):
+			unauthorized(c, "invalid credentials")
+			return
+		case err != nil:
+			log.Printf("auth: %v", err) // e.g. key store unavailable; don't leak details
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "authentication unavailable, retry later"})
+			return
+		}
+		c.Set(actorKey, actor)
+		c.Request = c.Request.WithContext(domain.WithActor(c.Request.Context(), actor))
+		c.Next()
+	}
+}
+
+// RequireScope rejects authenticated callers lacking the scope with 403.
+// It must run after Middleware.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor, ok := ActorFrom(c)
+		if !ok {
+			unauthorized(c, "missing credentials")
+			return
+		}
+		if !actor.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "missing scope " + scope})
+			return
+		}
+		c.Next()
+	}
+}
+
+// ActorFrom returns the actor authenticated for the request.
+func ActorFrom(c *gin.Context) (domain.Actor, bool) {
+	v, ok := c.Get(actorKey)
+	if !ok {
+		return domain.Actor{}, false
+	}
+	actor, ok := v.(domain.Actor)
+	return actor, ok
+}
+
+// unauthorized answers 401 with a WWW-Authenticate challenge.
+func unauthorized(c *gin.Context, msg string) {
+	c.Header("WWW-Authenticate", `Bearer realm="api", ApiKey header="X-API-Key"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": msg})
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s, _ := newKeyStore(t)
+	reader := createKey(t, s, "reader", "users:read")
+	admin := createKey(t, s, "admin", "*")
+	writer := createKey(t, s, "writer", "users:write")
+
+	// The store of the "down" route has lost its database.
+	down, downDB := newKeyStore(t)
+	stale := createKey(t, down, "reader", "users:read")
+	downDB.Close()
+
+	r := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	r.GET("/users", Middleware(&APIKeyAuthenticator{Store: s, Timeout: time.Second}), RequireScope("users:read"), ok)
+	r.GET("/down", Middleware(&APIKeyAuthenticator{Store: down, Timeout: time.Second}), RequireScope("users:read"), ok)
+	r.GET("/unauthenticated", RequireScope("users:read"), ok)
+
+	tests := []struct {
+		name string
+		path string
+		key  string
+		want int
+	}{
+		{"scope granted", "/users", reader, http.StatusNoContent},
+		{"wildcard scope", "/users", admin, http.StatusNoContent},
+		{"scope missing", "/users", writer, http.StatusForbidden},
+		{"unknown key", "/users", "mds_unknown", http.StatusUnauthorized},
+		{"no key", "/users", "", http.StatusUnauthorized},
+		{"store down", "/down", stale, http.StatusServiceUnavailable},
+		{"no authentication middleware", "/unauthenticated", reader, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("status %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			switch w.Code {
+			case http.StatusUnauthorized:
+				if w.Header().Get("WWW-Authenticate") == "" {
+					t.Error("401 without WWW-Authenticate")
+				}
+			case http.StatusServiceUnavailable:
+				if w.Header().Get("Retry-After") == "" {
+					t.Error("503 without Retry-After")
+				}
+			}
+		})
+	}
+}
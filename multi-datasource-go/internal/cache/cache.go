@@ -0,0 +1,208 @@
+// Package cache provides read-through caching of entities in front of the
+// repositories.
+//
+// A Cache stores JSON encoded entities in a pluggable Backend (an in-memory
+// LRU today, a shared cache such as Redis later), collapses concurrent misses
+// for the same key into one repository call (singleflight) and counts hits
+// and misses per entity. The repository decorators in this package read
+// through the cache on Get and invalidate on Update and Delete.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrMiss is returned by backends when a key is absent or expired.
+var ErrMiss = errors.New("cache miss")
+
+// Backend stores encoded values by key. Implementations must be safe for concurrent use.
+type Backend interface {
+	// Get returns the value stored under key, or ErrMiss.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores value under key for at most ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes key; deleting an absent key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Stats are the counters of one entity cache.
+type Stats struct {
+	Name   string  `json:"name"`
+	Hits   uint64  `json:"hits"`
+	Misses uint64  `json:"misses"` // lookups not answered from the cache
+	Loads  uint64  `json:"loads"`  // repository calls; lower than misses when concurrent misses were collapsed
+	Errors uint64  `json:"errors"` // backend failures (the repository is used instead)
+	Ratio  float64 `json:"hitRatio"`
+}
+
+// StatsProvider is implemented by caches exposing their counters.
+type StatsProvider interface {
+	Stats() Stats
+}
+
+// Cache is a read-through cache of entities of type T keyed by ID.
+type Cache[T any] struct {
+	name        string
+	backend     Backend
+	ttl         time.Duration
+	loadTimeout time.Duration
+	group       singleflight.Group
+
+	mu   sync.Mutex
+	gens map[string]*generation // keys with loads in flight
+
+	hits, misses, loads, errors atomic.Uint64
+}
+
+// generation counts the invalidations of a key while loads of it are in flight.
+// A load only stores its result if the count did not change meanwhile, so a
+// value read before an update cannot outlive the update's invalidation.
+type generation struct {
+	n     uint64
+	loads int
+}
+
+// New creates a cache for the named entity (e.g. "companies") storing entries for ttl.
+// Loads run for at most loadTimeout, whether or not their callers wait that long.
+func New[T any](name string, backend Backend, ttl, loadTimeout time.Duration) *Cache[T] {
+	return &Cache[T]{name: name, backend: backend, ttl: ttl, loadTimeout: loadTimeout, gens: map[string]*generation{}}
+}
+
+// key returns the backend key of an entity ID.
+func (c *Cache[T]) key(id int64) string {
+	return c.name + ":" + strconv.FormatInt(id, 10)
+}
+
+// Get returns the cached entity or loads it with load and caches the result.
+// Concurrent misses for the same ID share a single load, which is detached
+// from the cancellation of the caller starting it and bounded by the load
+// timeout instead. Errors (including not found) are returned to the caller and
+// not cached.
+func (c *Cache[T]) Get(ctx context.Context, id int64, load func(ctx context.Context) (*T, error)) (*T, error) {
+	key := c.key(id)
+	if data, err := c.backend.Get(ctx, key); err == nil {
+		var v T
+		if err := json.Unmarshal(data, &v); err == nil {
+			c.hits.Add(1)
+			return &v, nil
+		}
+		c.errors.Add(1)
+	} else if !errors.Is(err, ErrMiss) {
+		c.errors.Add(1)
+		log.Printf("cache %s: get: %v", c.name, err)
+	}
+
+	c.misses.Add(1)
+	ch := c.group.DoChan(key, func() (any, error) {
+		c.loads.Add(1)
+		lctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.loadTimeout)
+		defer cancel()
+		gen := c.startLoad(key)
+		defer c.endLoad(key)
+		v, err := load(lctx)
+		if err != nil {
+			return nil, err
+		}
+		if data, err := json.Marshal(v); err == nil && c.generation(key) == gen {
+			if err := c.backend.Set(lctx, key, data, c.ttl); err != nil {
+				c.errors.Add(1)
+				log.Printf("cache %s: set: %v", c.name, err)
+			}
+			// An invalidation during Set may have deleted the key before
+			// Set stored it; delete the stale value once more.
+			if c.generation(key) != gen {
+				c.delete(lctx, key)
+			}
+		}
+		return v, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		v := *res.Val.(*T) // copy, so callers cannot modify each other's result
+		return &v, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate drops the cached entity. It also detaches any in-flight load of
+// the ID, so callers arriving after the change start a fresh load, and keeps
+// that load from storing the value it read.
+func (c *Cache[T]) Invalidate(ctx context.Context, id int64) {
+	key := c.key(id)
+	c.mu.Lock()
+	if g, ok := c.gens[key]; ok {
+		g.n++
+	}
+	c.mu.Unlock()
+	c.group.Forget(key)
+	c.delete(ctx, key)
+}
+
+// startLoad registers a load of key and returns the generation it started in.
+func (c *Cache[T]) startLoad(key string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	g, ok := c.gens[key]
+	if !ok {
+		g = &generation{}
+		c.gens[key] = g
+	}
+	g.loads++
+	return g.n
+}
+
+// endLoad unregisters a load of key. The generation of a key is dropped once
+// none of its loads is in flight.
+func (c *Cache[T]) endLoad(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if g := c.gens[key]; g.loads == 1 {
+		delete(c.gens, key)
+	} else {
+		g.loads--
+	}
+}
+
+// generation returns the current generation of key, which has a load in flight.
+func (c *Cache[T]) generation(key string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gens[key].n
+}
+
+func (c *Cache[T]) delete(ctx context.Context, key string) {
+	if err := c.backend.Delete(ctx, key); err != nil {
+		c.errors.Add(1)
+		log.Printf("cache %s: delete: %v", c.name, err)
+	}
+}
+
+// Stats returns a snapshot of the counters.
+func (c *Cache[T]) Stats() Stats {
+	s := Stats{
+		Name:   c.name,
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Loads:  c.loads.Load(),
+		Errors: c.errors.Load(),
+	}
+	if total := s.Hits + s.Misses; total > 0 {
+		s.Ratio = float64(s.Hits) / float64(total)
+	}
+	return s
+}
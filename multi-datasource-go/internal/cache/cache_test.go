@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type entity struct{ Name string }
+
+// TestInvalidateDuringLoad checks that a value loaded before an update is not
+// cached after the update invalidated it.
+func TestInvalidateDuringLoad(t *testing.T) {
+	ctx := t.Context()
+	backend := NewLRU(10)
+	c := New[entity]("entities", backend, time.Minute, time.Second)
+
+	loading, release := make(chan struct{}), make(chan struct{})
+	done := make(chan error)
+	go func() {
+		_, err := c.Get(ctx, 1, func(context.Context) (*entity, error) {
+			close(loading)
+			<-release
+			return &entity{"before"}, nil
+		})
+		done <- err
+	}()
+	<-loading
+	c.Invalidate(ctx, 1) // the update commits while the old value is read
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := backend.Get(ctx, c.key(1)); !errors.Is(err, ErrMiss) {
+		t.Fatalf("backend get = %v, want the stale value not cached", err)
+	}
+	v, err := c.Get(ctx, 1, func(context.Context) (*entity, error) { return &entity{"after"}, nil })
+	if err != nil || v.Name != "after" {
+		t.Fatalf("Get = %v, %v; want after", v, err)
+	}
+	if len(c.gens) != 0 {
+		t.Errorf("%d generations kept after the loads ended", len(c.gens))
+	}
+}
+
+// TestLoadOutlivesFirstCaller checks that a caller giving up does not fail the
+// load shared with other callers.
+func TestLoadOutlivesFirstCaller(t *testing.T) {
+	c := New[entity]("entities", NewLRU(10), time.Minute, time.Second)
+	first, cancel := context.WithCancel(t.Context())
+
+	loading, release := make(chan struct{}), make(chan struct{})
+	load := func(ctx context.Context) (*entity, error) {
+		close(loading)
+		<-release
+		return &entity{"loaded"}, ctx.Err()
+	}
+	firstErr := make(chan error)
+	go func() {
+		_, err := c.Get(first, 1, load)
+		firstErr <- err
+	}()
+	<-loading
+	second := make(chan error)
+	go func() {
+		_, err := c.Get(t.Context(), 1, nil) // joins the load in flight
+		second <- err
+	}()
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("first caller: %v, want context.Canceled", err)
+	}
+	close(release)
+	if err := <-second; err != nil {
+		t.Fatalf("second caller: %v", err)
+	}
+	if s := c.Stats(); s.Loads != 1 {
+		t.Errorf("%d loads, want 1", s.Loads)
+	}
+}
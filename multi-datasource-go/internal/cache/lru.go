@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRU is an in-memory Backend bounded by entry count and entry age.
+// When full, the least recently used entry is evicted.
+type LRU struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List               // front = most recently used
+	items      map[string]*list.Element // key -> element holding *lruEntry
+}
+
+// lruEntry is a stored value with its expiry.
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRU creates an LRU holding at most maxEntries values.
+func NewLRU(maxEntries int) *LRU {
+	return &LRU{maxEntries: maxEntries, ll: list.New(), items: map[string]*list.Element{}}
+}
+
+// Get implements Backend. Expired entries are removed on access.
+func (l *LRU) Get(_ context.Context, key string) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.items[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	e := el.Value.(*lruEntry)
+	if time.Now().After(e.expiresAt) {
+		l.remove(el)
+		return nil, ErrMiss
+	}
+	l.ll.MoveToFront(el)
+	return e.value, nil
+}
+
+// Set implements Backend.
+func (l *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if el, ok := l.items[key]; ok {
+		e := el.Value.(*lruEntry)
+		e.value, e.expiresAt = value, expiresAt
+		l.ll.MoveToFront(el)
+		return nil
+	}
+	l.items[key] = l.ll.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for l.maxEntries > 0 && l.ll.Len() > l.maxEntries {
+		l.remove(l.ll.Back())
+	}
+	return nil
+}
+
+// Delete implements Backend.
+func (l *LRU) Delete(_ context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.items[key]; ok {
+		l.remove(el)
+	}
+	return nil
+}
+
+// Len returns the number of stored entries, including expired ones not yet evicted.
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.ll.Len()
+}
+
+// remove unlinks an element; the caller holds mu.
+func (l *LRU) remove(el *list.Element) {
+	l.ll.Remove(el)
+	delete(l.items, el.Value.(*lruEntry).key)
+}
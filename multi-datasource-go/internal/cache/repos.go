@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+
+	"multi-datasource-go/internal/domain"
+)
+
+// UserRepo caches user lookups in front of another UserRepo.
+type UserRepo struct {
+	domain.UserRepo
+	cache *Cache[domain.User]
+}
+
+// NewUserRepo decorates next with the cache.
+func NewUserRepo(next domain.UserRepo, c *Cache[domain.User]) *UserRepo {
+	return &UserRepo{UserRepo: next, cache: c}
+}
+
+// Get reads through the cache.
+func (r *UserRepo) Get(ctx context.Context, id int64) (*domain.User, error) {
+	return r.cache.Get(ctx, id, func(ctx context.Context) (*domain.User, error) { return r.UserRepo.Get(ctx, id) })
+}
+
+// Update writes to the repository and invalidates the cached user.
+func (r *UserRepo) Update(ctx context.Context, u *domain.User) error {
+	defer r.cache.Invalidate(ctx, u.ID)
+	return r.UserRepo.Update(ctx, u)
+}
+
+// Delete removes the user from the repository and the cache.
+func (r *UserRepo) Delete(ctx context.Context, id int64) error {
+	defer r.cache.Invalidate(ctx, id)
+	return r.UserRepo.Delete(ctx, id)
+}
+
+// Stats returns the cache counters.
+func (r *UserRepo) Stats() Stats { return r.cache.Stats() }
+
+// CompanyRepo caches company lookups in front of another CompanyRepo.
+type CompanyRepo struct {
+	domain.CompanyRepo
+	cache *Cache[domain.Company]
+}
+
+// NewCompanyRepo decorates next with the cache.
+func NewCompanyRepo(next domain.CompanyRepo, c *Cache[domain.Company]) *CompanyRepo {
+	return &CompanyRepo{CompanyRepo: next, cache: c}
+}
+
+// Get reads through the cache.
+func (r *CompanyRepo) Get(ctx context.Context, id int64) (*domain.Company, error) {
+	return r.cache.Get(ctx, id, func(ctx context.Context) (*domain.Company, error) { return r.CompanyRepo.Get(ctx, id) })
+}
+
+// Update writes to the repository and invalidates the cached company.
+func (r *CompanyRepo) Update(ctx context.Context, c *domain.Company) error {
+	defer r.cache.Invalidate(ctx, c.ID)
+	return r.CompanyRepo.Update(ctx, c)
+}
+
+// Delete removes the company from the repository and the cache.
+func (r *CompanyRepo) Delete(ctx context.Context, id int64) error {
+	defer r.cache.Invalidate(ctx, id)
+	return r.CompanyRepo.Delete(ctx, id)
+}
+
+// Stats returns the cache counters.
+func (r *CompanyRepo) Stats() Stats { return r.cache.Stats() }
+
+// BrandRepo caches brand lookups in front of another BrandRepo.
+type BrandRepo struct {
+	domain.BrandRepo
+	cache *Cache[domain.Brand]
+}
+
+// NewBrandRepo decorates next with the cache.
+func NewBrandRepo(next domain.BrandRepo, c *Cache[domain.Brand]) *BrandRepo {
+	return &BrandRepo{BrandRepo: next, cache: c}
+}
+
+// Get reads through the cache.
+func (r *BrandRepo) Get(ctx context.Context, id int64) (*domain.Brand, error) {
+	return r.cache.Get(ctx, id, func(ctx context.Context) (*domain.Brand, error) { return r.BrandRepo.Get(ctx, id) })
+}
+
+// Update writes to the repository and invalidates the cached brand.
+func (r *BrandRepo) Update(ctx context.Context, b *domain.Brand) error {
+	defer r.cache.Invalidate(ctx, b.ID)
+	return r.BrandRepo.Update(ctx, b)
+}
+
+// Delete removes the brand from the repository and the cache.
+func (r *BrandRepo) Delete(ctx context.Context, id int64) error {
+	defer r.cache.Invalidate(ctx, id)
+	return r.BrandRepo.Delete(ctx, id)
+}
+
+// Stats returns the cache counters.
+func (r *BrandRepo) Stats() Stats { return r.cache.Stats() }
@@ -0,0 +1,162 @@
+// Package certs loads TLS certificates from PEM files and reloads them when the
+// files change, so certificates can be rotated without restarting the application.
+// It is used for the connections to the datasources and by the HTTPS server.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Files caches a CA bundle and a key pair read from PEM files, and reloads
+// them when the modification time of one of the files changes. The zero value
+// of a path means the file is not used. A Files must not be copied after first use.
+type Files struct {
+	// CAFile is a PEM bundle of certificate authorities: the roots that sign
+	// the certificates of the peers (servers for a client, clients for a server).
+	CAFile string
+
+	// CertFile and KeyFile are the PEM certificate and private key presented to peers.
+	CertFile string
+	KeyFile  string
+
+	mu    sync.Mutex
+	stamp [3]time.Time // modification times of CAFile, CertFile and KeyFile at the last load
+	roots *x509.CertPool
+	pair  *tls.Certificate
+}
+
+// Load returns the current CA pool (nil without CAFile) and key pair (nil
+// without CertFile), re-reading the files if one of them changed since the
+// last call. It is cheap enough to call for every TLS handshake.
+//
+// If re-reading fails (e.g. the key pair is replaced file by file and does not
+// match yet), the previous certificates stay in use and the error is logged;
+// the error is only returned when nothing has been loaded before.
+func (f *Files) Load() (*x509.CertPool, *tls.Certificate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if (f.CertFile == "") != (f.KeyFile == "") {
+		return nil, nil, fmt.Errorf("tls: certFile and keyFile must be set together")
+	}
+
+	var stamp [3]time.Time
+	for i, name := range []string{f.CAFile, f.CertFile, f.KeyFile} {
+		if name == "" {
+			continue
+		}
+		st, err := os.Stat(name)
+		if err != nil {
+			return f.keep(err)
+		}
+		stamp[i] = st.ModTime()
+	}
+	loaded := f.stamp != [3]time.Time{}
+	if loaded && stamp == f.stamp {
+		return f.roots, f.pair, nil
+	}
+
+	var roots *x509.CertPool
+	if f.CAFile != "" {
+		pem, err := os.ReadFile(f.CAFile)
+		if err != nil {
+			return f.keep(err)
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return f.keep(fmt.Errorf("tls: no certificates found in %s", f.CAFile))
+		}
+	}
+	var pair *tls.Certificate
+	if f.CertFile != "" {
+		p, err := tls.LoadX509KeyPair(f.CertFile, f.KeyFile)
+		if err != nil {
+			return f.keep(err)
+		}
+		pair = &p
+	}
+
+	if loaded {
+		log.Printf("tls: reloaded %s", f)
+	}
+	f.stamp, f.roots, f.pair = stamp, roots, pair
+	return roots, pair, nil
+}
+
+// keep returns the previously loaded certificates after a failed reload, or the error if there are none.
+func (f *Files) keep(err error) (*x509.CertPool, *tls.Certificate, error) {
+	if f.stamp == [3]time.Time{} {
+		return nil, nil, err
+	}
+	log.Printf("tls: keeping previous certificates of %s: %v", f, err)
+	return f.roots, f.pair, nil
+}
+
+// String lists the files for log messages.
+func (f *Files) String() string {
+	return fmt.Sprintf("ca=%q cert=%q", f.CAFile, f.CertFile)
+}
+
+// clientAuthTypes maps the configuration names of client certificate policies
+// onto the tls package constants.
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	"":                   tls.NoClientCert,
+	"none":               tls.NoClientCert,
+	"request":            tls.RequestClientCert,
+	"require":            tls.RequireAnyClientCert,
+	"verify-if-given":    tls.VerifyClientCertIfGiven,
+	"require-and-verify": tls.RequireAndVerifyClientCert,
+}
+
+// ParseClientAuth converts a client certificate policy name (none, request,
+// require, verify-if-given or require-and-verify) into a tls.ClientAuthType.
+func ParseClientAuth(s string) (tls.ClientAuthType, error) {
+	t, ok := clientAuthTypes[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return 0, fmt.Errorf("unknown client auth %q (expected none, request, require, verify-if-given or require-and-verify)", s)
+	}
+	return t, nil
+}
+
+// ServerConfig returns the TLS configuration of a server presenting the key
+// pair of f and verifying client certificates against the CA bundle of f
+// according to auth. Both are looked up on every handshake, so rotated files
+// apply to the next connection. protos lists the ALPN protocols, e.g. "h2" and
+// "http/1.1"; net/http cannot add them to a per-handshake configuration itself.
+//
+// The files are loaded once before returning, so a missing or invalid file is
+// reported at startup.
+func ServerConfig(f *Files, auth tls.ClientAuthType, protos ...string) (*tls.Config, error) {
+	if f.CertFile == "" {
+		return nil, fmt.Errorf("tls: certFile and keyFile are required")
+	}
+	if auth >= tls.VerifyClientCertIfGiven && f.CAFile == "" {
+		return nil, fmt.Errorf("tls: verifying client certificates requires a CA file")
+	}
+	if _, _, err := f.Load(); err != nil {
+		return nil, err
+	}
+
+	base := &tls.Config{MinVersion: tls.VersionTLS12, ClientAuth: auth, NextProtos: protos}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: protos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			roots, pair, err := f.Load()
+			if err != nil {
+				return nil, err
+			}
+			c := base.Clone()
+			c.Certificates = []tls.Certificate{*pair}
+			c.ClientCAs = roots
+			return c, nil
+		},
+	}, nil
+}
@@ -0,0 +1,166 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// selfSigned writes a self-signed certificate for "localhost" and its key,
+// moving the modification time forward so rewrites are always detected.
+func selfSigned(t *testing.T, certFile, keyFile string, mtime time.Time) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, block := range map[string]*pem.Block{certFile: {Type: "CERTIFICATE", Bytes: der}, keyFile: {Type: "EC PRIVATE KEY", Bytes: keyDER}} {
+		if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// serverCert connects to the listener and returns the certificate it presents.
+func serverCert(t *testing.T, ln net.Listener, client *tls.Config) (*x509.Certificate, error) {
+	t.Helper()
+	go func() {
+		c, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		_ = c.(*tls.Conn).Handshake()
+	}()
+	conn, err := tls.Dial("tcp", ln.Addr().String(), client)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0], nil
+}
+
+func TestServerConfigReloadsCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.pem"), filepath.Join(dir, "server-key.pem")
+	now := time.Now()
+	first := selfSigned(t, certFile, keyFile, now)
+
+	cfg, err := ServerConfig(&Files{CertFile: certFile, KeyFile: keyFile}, tls.NoClientCert, "h2", "http/1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	client := &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"h2"}}
+
+	got, err := serverCert(t, ln, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(first) {
+		t.Fatal("server did not present the configured certificate")
+	}
+
+	second := selfSigned(t, certFile, keyFile, now.Add(time.Minute))
+	if got, err = serverCert(t, ln, client); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(second) {
+		t.Fatal("server did not pick up the rotated certificate")
+	}
+}
+
+func TestServerConfigClientAuth(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.pem"), filepath.Join(dir, "server-key.pem")
+	clientCert, clientKey := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	selfSigned(t, certFile, keyFile, time.Now())
+	selfSigned(t, clientCert, clientKey, time.Now())
+
+	if _, err := ServerConfig(&Files{CertFile: certFile, KeyFile: keyFile}, tls.RequireAndVerifyClientCert); err == nil {
+		t.Fatal("client verification without a CA file was accepted")
+	}
+	cfg, err := ServerConfig(&Files{CAFile: clientCert, CertFile: certFile, KeyFile: keyFile}, tls.RequireAndVerifyClientCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	pair, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := serverCert(t, ln, &tls.Config{InsecureSkipVerify: true, Certificates: []tls.Certificate{pair}}); err != nil {
+		t.Fatalf("trusted client certificate: %v", err)
+	}
+
+	// TLS 1.3 reports a rejected client certificate on the first read.
+	go func() {
+		if c, err := ln.Accept(); err == nil {
+			_ = c.(*tls.Conn).Handshake()
+			c.Close()
+		}
+	}()
+	conn, err := tls.Dial("tcp", ln.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+	if err == nil {
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	if err == nil {
+		t.Fatal("a client without certificate was accepted")
+	}
+}
+
+func TestParseClientAuth(t *testing.T) {
+	if got, err := ParseClientAuth("Require-And-Verify"); err != nil || got != tls.RequireAndVerifyClientCert {
+		t.Errorf("ParseClientAuth = %v, %v", got, err)
+	}
+	if _, err := ParseClientAuth("always"); err == nil {
+		t.Error("unknown policy accepted")
+	}
+}
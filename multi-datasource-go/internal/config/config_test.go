@@ -0,0 +1,41 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// load runs Load on an application.yaml with the given content.
+func load(t *testing.T, yaml string) (*Config, error) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "application.yaml"), []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	return Load()
+}
+
+func TestLoadDurations(t *testing.T) {
+	cfg, err := load(t, "mysql:\n  connMaxLifetime: 30m\n  connMaxIdleTime: 90s\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MySQL.ConnMaxLifetime != 30*time.Minute || cfg.MySQL.ConnMaxIdleTime != 90*time.Second {
+		t.Errorf("lifetime %v, idle time %v; want 30m, 1m30s", cfg.MySQL.ConnMaxLifetime, cfg.MySQL.ConnMaxIdleTime)
+	}
+
+	for yaml, want := range map[string]string{
+		"mysql:\n  connMaxLifetime: 30\n":       `"30s" or "30m"`,
+		"app:\n  readTimeout: 30\n":             `"30s" or "30m"`,
+		"postgres:\n  connMaxLifetimeMin: 30\n": `postgres.connMaxLifetime to a duration such as "30m"`,
+		"oracle:\n  connMaxIdleMin: 5\n":        `oracle.connMaxIdleTime to a duration such as "5m"`,
+	} {
+		if _, err := load(t, yaml); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: err = %v, want it to mention %s", yaml, err, want)
+		}
+	}
+}
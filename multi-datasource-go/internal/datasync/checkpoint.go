@@ -0,0 +1,93 @@
+package datasync
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Checkpoint records how far a sync job has progressed.
+// For the "id" watermark only LastID is used; for "updated_at" the pair
+// (LastUpdatedAt, LastID) is used so rows sharing a timestamp are not skipped.
+type Checkpoint struct {
+	LastID        int64     `json:"lastId"`
+	LastUpdatedAt time.Time `json:"lastUpdatedAt,omitempty"`
+	SyncedAt      time.Time `json:"syncedAt"`
+}
+
+// CheckpointStore persists checkpoints between runs, keyed by job name.
+type CheckpointStore interface {
+	Load(job string) (Checkpoint, error)
+	Save(job string, cp Checkpoint) error
+}
+
+// FileCheckpoints stores all checkpoints as a JSON object in a single file.
+// Writes go through a temporary file and rename, so a crash never leaves a truncated file behind.
+type FileCheckpoints struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCheckpoints returns a store backed by the given file. The file is created on first save.
+func NewFileCheckpoints(path string) *FileCheckpoints {
+	return &FileCheckpoints{path: path}
+}
+
+// Load returns the checkpoint of a job, or a zero Checkpoint if the job never ran.
+func (s *FileCheckpoints) Load(job string) (Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.read()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	return all[job], nil
+}
+
+// Save stores the checkpoint of a job, keeping the checkpoints of other jobs intact.
+func (s *FileCheckpoints) Save(job string, cp Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	all[job] = cp
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// read loads all checkpoints; a missing file is treated as empty.
+func (s *FileCheckpoints) read() (map[string]Checkpoint, error) {
+	all := map[string]Checkpoint{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
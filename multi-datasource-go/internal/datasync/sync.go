@@ -0,0 +1,284 @@
+package datasync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"multi-datasource-go/internal/db"
+)
+
+// Endpoint is one side of a sync job: a named datasource and its dialect.
+type Endpoint struct {
+	Name    string     // datasource name as used in application.yaml (e.g., "mysql")
+	Dialect db.Dialect // SQL dialect of the datasource
+	DB      *sql.DB    // connection pool (Postgres pools are adapted via db.SQLFromPool)
+}
+
+// Options controls a sync run.
+type Options struct {
+	Table     Table  // entity table to copy
+	Watermark string // "id" or "updated_at"
+	BatchSize int    // rows fetched per round trip (max 1000, Oracle's IN-list limit)
+	DryRun    bool   // report differences without writing to the target or the checkpoint
+}
+
+// Action classifies what a sync does with a source row.
+type Action string
+
+const (
+	Insert Action = "insert" // row is missing in the target
+	Update Action = "update" // row exists in the target with different values
+)
+
+// Diff describes a row that differs between source and target.
+type Diff struct {
+	ID      int64
+	Action  Action
+	Changes map[string][2]any // column -> [target value, source value]; only set for updates
+}
+
+// Report summarizes a sync run.
+type Report struct {
+	Scanned   int    // rows read from the source
+	Inserted  int    // rows inserted (or that would be inserted in dry-run)
+	Updated   int    // rows updated (or that would be updated in dry-run)
+	Unchanged int    // rows already identical in the target
+	Diffs     []Diff // per-row differences, in watermark order
+}
+
+// Syncer copies rows of one table from a source to a target datasource.
+type Syncer struct {
+	From        Endpoint
+	To          Endpoint
+	Checkpoints CheckpointStore
+}
+
+// Job returns the checkpoint key of a sync job, e.g. "users:mysql->postgres:id".
+func (s *Syncer) Job(opts Options) string {
+	return fmt.Sprintf("%s:%s->%s:%s", opts.Table.Name, s.From.Name, s.To.Name, opts.Watermark)
+}
+
+// Run performs an incremental sync starting after the stored checkpoint.
+// Every batch is written in a single target transaction and the checkpoint
+// is saved after the transaction commits, so an interrupted run resumes
+// from the last completed batch.
+func (s *Syncer) Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.BatchSize <= 0 || opts.BatchSize > 1000 {
+		opts.BatchSize = 1000
+	}
+	if opts.Watermark == "" {
+		opts.Watermark = "id"
+	}
+	wm, ok := opts.Table.column(opts.Watermark)
+	if !ok || (wm.Name != "id" && wm.Type != Time) {
+		return nil, fmt.Errorf("table %s has no usable watermark column %q", opts.Table.Name, opts.Watermark)
+	}
+
+	job := s.Job(opts)
+	cp, err := s.Checkpoints.Load(job)
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint: %w", err)
+	}
+
+	if !opts.DryRun {
+		if err := EnsureTable(ctx, s.To, opts.Table); err != nil {
+			return nil, fmt.Errorf("ensure target table: %w", err)
+		}
+	}
+
+	rep := &Report{}
+	for {
+		batch, err := s.fetch(ctx, opts, cp)
+		if err != nil {
+			return rep, fmt.Errorf("read %s from %s: %w", opts.Table.Name, s.From.Name, err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		diffs, rows, err := s.diff(ctx, opts.Table, batch)
+		if err != nil {
+			return rep, fmt.Errorf("compare with %s: %w", s.To.Name, err)
+		}
+		if !opts.DryRun {
+			if err := s.apply(ctx, opts.Table, diffs, rows); err != nil {
+				return rep, fmt.Errorf("write %s to %s: %w", opts.Table.Name, s.To.Name, err)
+			}
+		}
+
+		rep.Scanned += len(batch)
+		rep.Unchanged += len(batch) - len(diffs)
+		for _, d := range diffs {
+			if d.Action == Insert {
+				rep.Inserted++
+			} else {
+				rep.Updated++
+			}
+		}
+		rep.Diffs = append(rep.Diffs, diffs...)
+
+		// Advance the watermark to the last row of the batch.
+		last := batch[len(batch)-1]
+		cp.LastID = last.id()
+		if opts.Watermark != "id" {
+			cp.LastUpdatedAt = last[columnIndex(opts.Table, wm.Name)].(time.Time)
+		}
+		cp.SyncedAt = time.Now().UTC()
+		if !opts.DryRun {
+			if err := s.Checkpoints.Save(job, cp); err != nil {
+				return rep, fmt.Errorf("save checkpoint: %w", err)
+			}
+		}
+
+		if len(batch) < opts.BatchSize {
+			break
+		}
+	}
+
+	if !opts.DryRun && rep.Inserted > 0 {
+		if err := resetIdentity(ctx, s.To, opts.Table); err != nil {
+			return rep, fmt.Errorf("reset identity of %s: %w", opts.Table.Name, err)
+		}
+	}
+	return rep, nil
+}
+
+// fetch reads the next batch of source rows after the checkpoint, ordered by watermark.
+func (s *Syncer) fetch(ctx context.Context, opts Options, cp Checkpoint) ([]row, error) {
+	d := s.From.Dialect
+	var (
+		where string
+		order string
+		args  []any
+	)
+	if opts.Watermark == "id" {
+		where = "id > " + d.Placeholder(1)
+		order = "id"
+		args = []any{cp.LastID}
+	} else {
+		// (updated_at, id) > (last updated_at, last id), spelled out for Oracle.
+		w := opts.Watermark
+		where = fmt.Sprintf("(%s > %s OR (%s = %s AND id > %s))",
+			w, d.Placeholder(1), w, d.Placeholder(2), d.Placeholder(3))
+		order = w + ", id"
+		args = bindArgs(d, cp.LastUpdatedAt, cp.LastUpdatedAt, cp.LastID)
+	}
+
+	q := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s %s",
+		opts.Table.columnList(), opts.Table.Name, where, order, d.Limit(opts.BatchSize))
+	return query(ctx, s.From, opts.Table, q, args...)
+}
+
+// diff loads the target rows matching the batch and compares them column by column.
+// It returns the differences and the source rows indexed by ID.
+func (s *Syncer) diff(ctx context.Context, t Table, batch []row) ([]Diff, map[int64]row, error) {
+	d := s.To.Dialect
+	marks := make([]string, len(batch))
+	args := make([]any, len(batch))
+	for i, r := range batch {
+		marks[i] = d.Placeholder(i + 1)
+		args[i] = r.id()
+	}
+	q := fmt.Sprintf("SELECT %s FROM %s WHERE id IN (%s)", t.columnList(), t.Name, strings.Join(marks, ", "))
+	existing, err := query(ctx, s.To, t, q, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	target := make(map[int64]row, len(existing))
+	for _, r := range existing {
+		target[r.id()] = r
+	}
+
+	var diffs []Diff
+	rows := make(map[int64]row, len(batch))
+	for _, src := range batch {
+		rows[src.id()] = src
+		dst, found := target[src.id()]
+		if !found {
+			diffs = append(diffs, Diff{ID: src.id(), Action: Insert})
+			continue
+		}
+		changes := map[string][2]any{}
+		for i, c := range t.Columns {
+			if !equal(dst[i], src[i]) {
+				changes[c.Name] = [2]any{dst[i], src[i]}
+			}
+		}
+		if len(changes) > 0 {
+			diffs = append(diffs, Diff{ID: src.id(), Action: Update, Changes: changes})
+		}
+	}
+	return diffs, rows, nil
+}
+
+// apply writes the differences of one batch in a single target transaction.
+func (s *Syncer) apply(ctx context.Context, t Table, diffs []Diff, rows map[int64]row) error {
+	if len(diffs) == 0 {
+		return nil
+	}
+	d := s.To.Dialect
+
+	// INSERT INTO t (c1, c2, ...) VALUES (p1, p2, ...)
+	marks := make([]string, len(t.Columns))
+	for i := range t.Columns {
+		marks[i] = d.Placeholder(i + 1)
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", t.Name, t.columnList(), strings.Join(marks, ", "))
+
+	// UPDATE t SET c2 = p1, c3 = p2, ... WHERE id = pN
+	sets := make([]string, 0, len(t.Columns)-1)
+	for i, c := range t.Columns[1:] {
+		sets = append(sets, c.Name+" = "+d.Placeholder(i+1))
+	}
+	update := fmt.Sprintf("UPDATE %s SET %s WHERE id = %s", t.Name, strings.Join(sets, ", "), d.Placeholder(len(t.Columns)))
+
+	tx, err := s.To.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, df := range diffs {
+		r := rows[df.ID]
+		if df.Action == Insert {
+			_, err = tx.ExecContext(ctx, insert, bindArgs(d, r...)...)
+		} else {
+			_, err = tx.ExecContext(ctx, update, bindArgs(d, append(append([]any{}, r[1:]...), r[0])...)...)
+		}
+		if err != nil {
+			return errors.Join(fmt.Errorf("%s id=%d: %w", df.Action, df.ID, err), tx.Rollback())
+		}
+	}
+	return tx.Commit()
+}
+
+// query runs a SELECT returning full table rows.
+func query(ctx context.Context, ep Endpoint, t Table, q string, args ...any) ([]row, error) {
+	rs, err := ep.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Close()
+
+	var out []row
+	for rs.Next() {
+		dest := scanDest(t)
+		if err := rs.Scan(dest...); err != nil {
+			return nil, err
+		}
+		out = append(out, toRow(dest))
+	}
+	return out, rs.Err()
+}
+
+// columnIndex returns the position of a column in the table definition.
+func columnIndex(t Table, name string) int {
+	for i, c := range t.Columns {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,186 @@
+package datasync
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"multi-datasource-go/internal/db"
+	"multi-datasource-go/internal/db/dbtest"
+	"multi-datasource-go/internal/domain"
+	"multi-datasource-go/internal/repo"
+)
+
+// newSyncer returns a syncer between two SQLite stand-ins with a checkpoint file.
+func newSyncer(t *testing.T) *Syncer {
+	return &Syncer{
+		From:        Endpoint{Name: "source", Dialect: db.SQLite, DB: dbtest.Open(t)},
+		To:          Endpoint{Name: "target", Dialect: db.SQLite, DB: dbtest.Open(t)},
+		Checkpoints: NewFileCheckpoints(filepath.Join(t.TempDir(), "checkpoints.json")),
+	}
+}
+
+// count returns the number of rows of a table.
+func count(t *testing.T, ep Endpoint, table string) int {
+	t.Helper()
+	var n int
+	if err := ep.DB.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM "+table).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// insertUser writes a user row with the given ID and change time.
+func insertUser(t *testing.T, ep Endpoint, id int64, name string, updatedAt time.Time) {
+	t.Helper()
+	if _, err := ep.DB.ExecContext(t.Context(),
+		"INSERT INTO users (id, name, last_name, updated_at) VALUES (?, ?, 'Doe', ?)", id, name, db.SQLiteTime(updatedAt)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncByID(t *testing.T) {
+	ctx := t.Context()
+	s := newSyncer(t)
+	opts := Options{Table: Tables["users"], Watermark: "id", BatchSize: 2}
+	at := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	for id := int64(1); id <= 5; id++ {
+		insertUser(t, s.From, id, "User", at)
+	}
+	insertUser(t, s.To, 2, "Stale", at) // differs
+	insertUser(t, s.To, 3, "User", at)  // identical
+
+	// A dry run reports the differences without writing rows or the checkpoint.
+	opts.DryRun = true
+	rep, err := s.Run(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Scanned != 5 || rep.Inserted != 3 || rep.Updated != 1 || rep.Unchanged != 1 {
+		t.Fatalf("dry run: %+v; want 5 scanned, 3 inserted, 1 updated, 1 unchanged", rep)
+	}
+	want := []Diff{
+		{ID: 1, Action: Insert},
+		{ID: 2, Action: Update, Changes: map[string][2]any{"name": {"Stale", "User"}}},
+		{ID: 4, Action: Insert},
+		{ID: 5, Action: Insert},
+	}
+	if !reflect.DeepEqual(rep.Diffs, want) {
+		t.Errorf("dry run diffs %+v, want %+v", rep.Diffs, want)
+	}
+	if n := count(t, s.To, "users"); n != 2 {
+		t.Errorf("dry run left %d target rows, want 2", n)
+	}
+	if cp, err := s.Checkpoints.Load(s.Job(opts)); err != nil || cp.LastID != 0 {
+		t.Errorf("dry run saved checkpoint %+v, %v", cp, err)
+	}
+
+	opts.DryRun = false
+	if rep, err = s.Run(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	if rep.Inserted != 3 || rep.Updated != 1 || count(t, s.To, "users") != 5 {
+		t.Fatalf("run: %+v, %d target rows; want 3 inserted, 1 updated, 5 rows", rep, count(t, s.To, "users"))
+	}
+	if cp, err := s.Checkpoints.Load(s.Job(opts)); err != nil || cp.LastID != 5 {
+		t.Errorf("checkpoint %+v, %v; want last ID 5", cp, err)
+	}
+
+	// The application keeps inserting after the highest synced ID.
+	id, err := db.SQLite.InsertID(ctx, s.To.DB, "INSERT INTO users (name, last_name) VALUES ('New', 'User')")
+	if err != nil || id != 6 {
+		t.Errorf("insert after sync: id %d, %v; want 6", id, err)
+	}
+}
+
+func TestSyncResumesFromCheckpoint(t *testing.T) {
+	ctx := t.Context()
+	s := newSyncer(t)
+	at := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	for id := int64(1); id <= 5; id++ {
+		insertUser(t, s.From, id, "User", at.Add(time.Duration(6-id)*time.Minute)) // newest first
+	}
+
+	for _, tt := range []struct {
+		watermark string
+		cp        Checkpoint
+		want      []int64
+	}{
+		// A previous run got as far as user 3.
+		{"id", Checkpoint{LastID: 3}, []int64{4, 5}},
+		// ... or as far as the change of user 3, the third newest change.
+		{"updated_at", Checkpoint{LastID: 3, LastUpdatedAt: at.Add(3 * time.Minute)}, []int64{2, 1}},
+	} {
+		t.Run(tt.watermark, func(t *testing.T) {
+			opts := Options{Table: Tables["users"], Watermark: tt.watermark, BatchSize: 10, DryRun: true}
+			if err := s.Checkpoints.Save(s.Job(opts), tt.cp); err != nil {
+				t.Fatal(err)
+			}
+			rep, err := s.Run(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []int64
+			for _, d := range rep.Diffs {
+				ids = append(ids, d.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("synced %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+// SQLite compares timestamps as text, so rows written in the second of the
+// checkpoint must still sort after it when the checkpoint is bound.
+func TestSyncUpdatedAtSameSecondAcrossBatches(t *testing.T) {
+	// A checkpoint that sorts before its own row makes Run fetch the same batch forever.
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	s := newSyncer(t)
+	opts := Options{Table: Tables["users"], Watermark: "updated_at", BatchSize: 2}
+
+	// Three rows in one second; the last two share the timestamp that ends the first batch.
+	at := time.Date(2026, 10, 18, 9, 30, 15, 0, time.UTC)
+	for i, ts := range []time.Time{at.Add(100 * time.Millisecond), at.Add(200 * time.Millisecond), at.Add(200 * time.Millisecond)} {
+		if _, err := s.From.DB.ExecContext(ctx,
+			"INSERT INTO users (name, last_name, updated_at) VALUES (?, ?, ?)",
+			"User", string(rune('A'+i)), db.SQLiteTime(ts)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rep, err := s.Run(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Scanned != 3 || rep.Inserted != 3 || count(t, s.To, "users") != 3 {
+		t.Fatalf("first run: scanned %d, inserted %d, target has %d rows; want 3 each",
+			rep.Scanned, rep.Inserted, count(t, s.To, "users"))
+	}
+
+	// Nothing changed: the checkpoint sorts after every row.
+	if rep, err = s.Run(ctx, opts); err != nil || rep.Scanned != 0 {
+		t.Fatalf("second run: scanned %d, %v; want 0", rep.Scanned, err)
+	}
+
+	// An update through the repository bumps updated_at past the checkpoint.
+	users := repo.NewSQLiteUserRepo(s.From.DB)
+	if err := users.Update(ctx, &domain.User{ID: 1, Name: "Renamed", LastName: "A"}); err != nil {
+		t.Fatal(err)
+	}
+	rep, err = s.Run(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Scanned != 1 || rep.Updated != 1 || rep.Diffs[0].ID != 1 {
+		t.Fatalf("after update: %+v; want user 1 updated", rep)
+	}
+	var name string
+	if err := s.To.DB.QueryRowContext(ctx, "SELECT name FROM users WHERE id = 1").Scan(&name); err != nil || name != "Renamed" {
+		t.Fatalf("target user 1 = %q, %v; want Renamed", name, err)
+	}
+}
@@ -0,0 +1,254 @@
+// Package datasync copies entity tables between configured datasources.
+// It is used by the `sync` subcommand of cmd/api to migrate entities
+// from one database to another (e.g., users from MySQL to Postgres).
+package datasync
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"multi-datasource-go/internal/db"
+)
+
+// ColumnType is a dialect-neutral column type.
+// Each type knows how to render itself as DDL for every supported dialect.
+type ColumnType int
+
+const (
+	Int64  ColumnType = iota // 64-bit integer
+	String                   // variable length string, bounded by Column.Size
+	Time                     // timestamp with sub-second precision
+)
+
+// Column describes one column of an entity table.
+type Column struct {
+	Name string
+	Type ColumnType
+	Size int // max length for String columns
+}
+
+// Table describes an entity table that can be synced.
+// The first column is always the numeric primary key "id".
+type Table struct {
+	Name    string
+	Columns []Column
+}
+
+// Tables lists the entity tables known to the sync command, keyed by entity name.
+var Tables = map[string]Table{
+	"users": {Name: "users", Columns: []Column{
+		{Name: "id", Type: Int64},
+		{Name: "name", Type: String, Size: 100},
+		{Name: "last_name", Type: String, Size: 100},
+		{Name: "company_id", Type: Int64},
+		{Name: "updated_at", Type: Time},
+	}},
+	"companies": {Name: "companies", Columns: []Column{
+		{Name: "id", Type: Int64},
+		{Name: "name", Type: String, Size: 4000},
+		{Name: "updated_at", Type: Time},
+	}},
+	"brands": {Name: "brands", Columns: []Column{
+		{Name: "id", Type: Int64},
+		{Name: "name", Type: String, Size: 100},
+		{Name: "company_id", Type: Int64},
+		{Name: "updated_at", Type: Time},
+	}},
+}
+
+// LookupTable returns the table definition for an entity name.
+func LookupTable(entity string) (Table, error) {
+	t, ok := Tables[entity]
+	if !ok {
+		names := make([]string, 0, len(Tables))
+		for n := range Tables {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Table{}, fmt.Errorf("unknown entity %q (expected one of %s)", entity, strings.Join(names, ", "))
+	}
+	return t, nil
+}
+
+// column returns the column with the given name.
+func (t Table) column(name string) (Column, bool) {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+// columnList renders the comma separated column names.
+func (t Table) columnList() string {
+	names := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// ddlType maps a column onto the native type of the given dialect.
+// The "id" column is mapped onto an identity column so that the
+// application can keep inserting rows after the sync.
+func ddlType(d db.Dialect, c Column) string {
+	if c.Name == "id" {
+		switch d {
+		case db.MySQL:
+			return "BIGINT AUTO_INCREMENT PRIMARY KEY"
+		case db.Postgres:
+			return "BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY"
+		case db.SQLite:
+			return "INTEGER PRIMARY KEY AUTOINCREMENT"
+		default:
+			return "NUMBER(19) GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY"
+		}
+	}
+	switch c.Type {
+	case Int64:
+		switch d {
+		case db.Oracle:
+			return "NUMBER(19)"
+		case db.SQLite:
+			return "INTEGER"
+		}
+		return "BIGINT"
+	case Time:
+		switch d {
+		case db.MySQL:
+			return "TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)"
+		case db.Postgres:
+			return "TIMESTAMPTZ NOT NULL DEFAULT now()"
+		case db.SQLite:
+			return "TIMESTAMP NOT NULL DEFAULT (" + db.SQLiteNow + ")"
+		default:
+			return "TIMESTAMP DEFAULT SYSTIMESTAMP NOT NULL"
+		}
+	default:
+		switch {
+		case d == db.Postgres, d == db.SQLite:
+			return "TEXT NOT NULL"
+		case d == db.Oracle:
+			return fmt.Sprintf("VARCHAR2(%d) NOT NULL", c.Size)
+		case c.Size > 255:
+			return "TEXT NOT NULL"
+		default:
+			return fmt.Sprintf("VARCHAR(%d) NOT NULL", c.Size)
+		}
+	}
+}
+
+// createTableSQL renders the CREATE TABLE statement for the given dialect.
+// MySQL, Postgres and SQLite use IF NOT EXISTS; Oracle ignores ORA-00955 instead.
+func createTableSQL(d db.Dialect, t Table) string {
+	cols := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		cols[i] = c.Name + " " + ddlType(d, c)
+	}
+	body := strings.Join(cols, ", ")
+	if d == db.Oracle {
+		return fmt.Sprintf(`
+			BEGIN
+				EXECUTE IMMEDIATE 'CREATE TABLE %s (%s)';
+			EXCEPTION
+				WHEN OTHERS THEN
+					IF SQLCODE != -955 THEN RAISE; END IF;
+			END;`, t.Name, body)
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", t.Name, body)
+}
+
+// EnsureTable creates the table in the target datasource if it does not exist yet.
+func EnsureTable(ctx context.Context, ep Endpoint, t Table) error {
+	_, err := ep.DB.ExecContext(ctx, createTableSQL(ep.Dialect, t))
+	return err
+}
+
+// resetIdentity moves the identity generator of the target table past the
+// highest synced ID, so rows created by the application don't collide with
+// rows copied from the source. MySQL adjusts AUTO_INCREMENT on its own,
+// and SQLite continues after the highest ID ever stored.
+func resetIdentity(ctx context.Context, ep Endpoint, t Table) error {
+	var stmt string
+	switch ep.Dialect {
+	case db.Postgres:
+		stmt = fmt.Sprintf(
+			"SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE((SELECT MAX(id) FROM %[1]s), 0) + 1, false)", t.Name)
+	case db.Oracle:
+		stmt = fmt.Sprintf("ALTER TABLE %s MODIFY (id GENERATED BY DEFAULT AS IDENTITY (START WITH LIMIT VALUE))", t.Name)
+	default:
+		return nil
+	}
+	_, err := ep.DB.ExecContext(ctx, stmt)
+	return err
+}
+
+// row holds the values of one table row, in Table.Columns order.
+type row []any
+
+// id returns the primary key of the row.
+func (r row) id() int64 { return r[0].(int64) }
+
+// scanDest allocates typed scan destinations for every column, so values are
+// normalized (int64, string, time.Time) regardless of the source driver.
+func scanDest(t Table) []any {
+	dest := make([]any, len(t.Columns))
+	for i, c := range t.Columns {
+		switch c.Type {
+		case Int64:
+			dest[i] = new(sql.NullInt64)
+		case Time:
+			dest[i] = new(sql.NullTime)
+		default:
+			dest[i] = new(sql.NullString)
+		}
+	}
+	return dest
+}
+
+// toRow converts scanned destinations into a row of plain Go values.
+// NULL integers (optional references such as company_id) become nil.
+// Timestamps are truncated to microseconds (the common precision of all dialects) and stored in UTC.
+func toRow(dest []any) row {
+	r := make(row, len(dest))
+	for i, d := range dest {
+		switch v := d.(type) {
+		case *sql.NullInt64:
+			if v.Valid {
+				r[i] = v.Int64
+			}
+		case *sql.NullTime:
+			r[i] = v.Time.UTC().Truncate(time.Microsecond)
+		case *sql.NullString:
+			r[i] = v.String
+		}
+	}
+	return r
+}
+
+// bindArgs converts row values into bind arguments of the dialect.
+// SQLite compares timestamps as text, so they are bound in the layout of db.SQLiteNow.
+func bindArgs(d db.Dialect, vals ...any) []any {
+	args := make([]any, len(vals))
+	for i, v := range vals {
+		if t, ok := v.(time.Time); ok && d == db.SQLite {
+			v = db.SQLiteTime(t)
+		}
+		args[i] = v
+	}
+	return args
+}
+
+// equal reports whether the column values of two rows match.
+func equal(a, b any) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	return a == b
+}
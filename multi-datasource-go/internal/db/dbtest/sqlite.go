@@ -0,0 +1,164 @@
+// Package dbtest provides an embedded SQL database for tests, so that the
+// database/sql repositories run without Docker or external services.
+//
+// Open returns an in-memory SQLite database standing in for MySQL and Oracle.
+// SQLite understands the SQL the repositories send to those databases (? and
+// :1 placeholders, IN lists, LastInsertId) except for a few constructs, which
+// the stand-in driver rewrites before SQLite sees them:
+//
+//	SYSTIMESTAMP                -> db.SQLiteNow
+//	... RETURNING id INTO :n    -> the insert, with LastInsertId written to the sql.Out argument
+//	SELECT ... FOR UPDATE       -> the SELECT; the single connection serializes access anyway
+//
+// PostgreSQL repositories use pgx directly and cannot run on the stand-in.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+
+	"multi-datasource-go/internal/db"
+)
+
+// schema creates the tables of all entities and the outbox in SQLite types.
+const schema = `
+	CREATE TABLE users (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		last_name TEXT NOT NULL,
+		company_id INTEGER NULL,
+		updated_at TIMESTAMP NOT NULL DEFAULT (` + db.SQLiteNow + `)
+	);
+	CREATE TABLE companies (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL DEFAULT (` + db.SQLiteNow + `)
+	);
+	CREATE TABLE brands (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		company_id INTEGER NULL,
+		updated_at TIMESTAMP NOT NULL DEFAULT (` + db.SQLiteNow + `)
+	);
+	CREATE TABLE outbox_events (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		aggregate_type TEXT NOT NULL,
+		aggregate_id INTEGER NOT NULL,
+		event_type TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		published_at TIMESTAMP NULL,
+		dead_at TIMESTAMP NULL,
+		audit TEXT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT NULL
+	);`
+
+// driverName is the name the stand-in driver is registered under.
+const driverName = "dbtest-sqlite"
+
+var (
+	register sync.Once
+	seq      atomic.Int64 // distinguishes the databases of one test binary
+)
+
+// Open returns an empty in-memory database with the users, companies, brands
+// and outbox_events tables. It is closed when the test ends.
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+	register.Do(func() { sql.Register(driverName, standIn{}) })
+
+	// A named shared-cache database lives as long as one of its connections,
+	// and a single connection serializes access like a small pool would.
+	dsn := fmt.Sprintf("file:dbtest%d?mode=memory&cache=shared&_foreign_keys=1", seq.Add(1))
+	dbx, err := sql.Open(driverName, dsn)
+	if err != nil {
+		t.Fatalf("dbtest: open: %v", err)
+	}
+	dbx.SetMaxOpenConns(1)
+	t.Cleanup(func() { dbx.Close() })
+
+	if _, err := dbx.Exec(schema); err != nil {
+		t.Fatalf("dbtest: create schema: %v", err)
+	}
+	return dbx
+}
+
+// ===== Stand-in driver =====
+
+var (
+	systimestamp  = regexp.MustCompile(`\bSYSTIMESTAMP\b`)
+	returningInto = regexp.MustCompile(`(?i)\s+RETURNING\s+id\s+INTO\s+:\d+\s*$`)
+	forUpdate     = regexp.MustCompile(`(?i)\s+FOR\s+UPDATE\s*$`)
+)
+
+// rewrite applies the rewrites every statement gets.
+func rewrite(query string) string {
+	return forUpdate.ReplaceAllString(systimestamp.ReplaceAllString(query, db.SQLiteNow), "")
+}
+
+// standIn is the SQLite driver with the Oracle rewrites applied.
+type standIn struct{}
+
+func (standIn) Open(dsn string) (driver.Conn, error) {
+	c, err := (&sqlite3.SQLiteDriver{}).Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{c.(*sqlite3.SQLiteConn)}, nil
+}
+
+// conn rewrites the statements of one SQLite connection.
+type conn struct{ *sqlite3.SQLiteConn }
+
+// CheckNamedValue lets sql.Out arguments through to ExecContext;
+// everything else gets the default conversion.
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if _, ok := nv.Value.(sql.Out); ok {
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	query = rewrite(query)
+	loc := returningInto.FindStringIndex(query)
+	if loc == nil {
+		return c.SQLiteConn.ExecContext(ctx, query, args)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("dbtest: %q has no sql.Out argument", query)
+	}
+	out, ok := args[len(args)-1].Value.(sql.Out)
+	if !ok {
+		return nil, fmt.Errorf("dbtest: last argument of %q is not a sql.Out", query)
+	}
+	dest, ok := out.Dest.(*int64)
+	if !ok {
+		return nil, fmt.Errorf("dbtest: RETURNING INTO supports *int64 destinations, got %T", out.Dest)
+	}
+	res, err := c.SQLiteConn.ExecContext(ctx, query[:loc[0]], args[:len(args)-1])
+	if err != nil {
+		return nil, err
+	}
+	if *dest, err = res.LastInsertId(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.SQLiteConn.QueryContext(ctx, rewrite(query), args)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.SQLiteConn.PrepareContext(ctx, rewrite(query))
+}
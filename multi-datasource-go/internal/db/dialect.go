@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib" // database/sql adapter on top of a pgx pool
+)
+
+// Dialect identifies the SQL flavour spoken by a configured datasource.
+// It is used by components that build SQL dynamically (e.g., the sync command)
+// and therefore need to know about placeholder and paging syntax.
+type Dialect string
+
+const (
+	MySQL    Dialect = "mysql"    // go-sql-driver/mysql
+	Postgres Dialect = "postgres" // pgx
+	Oracle   Dialect = "oracle"   // go-ora
+	SQLite   Dialect = "sqlite"   // mattn/go-sqlite3, for local development
+)
+
+// ParseDialect converts a datasource name (as used in application.yaml) into a Dialect.
+func ParseDialect(name string) (Dialect, error) {
+	switch d := Dialect(strings.ToLower(strings.TrimSpace(name))); d {
+	case MySQL, Postgres, Oracle, SQLite:
+		return d, nil
+	default:
+		return "", fmt.Errorf("unknown datasource %q (expected mysql, postgres, oracle or sqlite)", name)
+	}
+}
+
+// Placeholder returns the bind parameter marker for the n-th (1-based) argument.
+//
+//	MySQL:    ?
+//	SQLite:   ?
+//	Postgres: $1
+//	Oracle:   :1
+func (d Dialect) Placeholder(n int) string {
+	switch d {
+	case Postgres:
+		return fmt.Sprintf("$%d", n)
+	case Oracle:
+		return fmt.Sprintf(":%d", n)
+	default:
+		return "?"
+	}
+}
+
+// Limit returns the clause that restricts a query to the first n rows.
+// It must be appended after ORDER BY.
+func (d Dialect) Limit(n int) string {
+	if d == Oracle {
+		return fmt.Sprintf("FETCH FIRST %d ROWS ONLY", n)
+	}
+	return fmt.Sprintf("LIMIT %d", n)
+}
+
+// Execer is implemented by *sql.DB and *sql.Tx.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// InsertID executes an INSERT statement and returns the value generated for its "id" column.
+// The statement must not contain a RETURNING clause; it is added as needed:
+//
+//	MySQL:    LastInsertId()
+//	SQLite:   LastInsertId()
+//	Postgres: RETURNING id
+//	Oracle:   RETURNING id INTO :n
+func (d Dialect) InsertID(ctx context.Context, ex Execer, stmt string, args ...any) (int64, error) {
+	var id int64
+	switch d {
+	case Postgres:
+		err := ex.QueryRowContext(ctx, stmt+" RETURNING id", args...).Scan(&id)
+		return id, err
+	case Oracle:
+		stmt = fmt.Sprintf("%s RETURNING id INTO %s", stmt, d.Placeholder(len(args)+1))
+		_, err := ex.ExecContext(ctx, stmt, append(args, sql.Out{Dest: &id})...)
+		return id, err
+	default:
+		res, err := ex.ExecContext(ctx, stmt, args...)
+		if err != nil {
+			return 0, err
+		}
+		return res.LastInsertId()
+	}
+}
+
+// HasColumn reports whether the table of the current schema has the column.
+// Table and column are given in lower case.
+func (d Dialect) HasColumn(ctx context.Context, ex Execer, table, column string) (bool, error) {
+	var q string
+	switch d {
+	case MySQL:
+		q = `SELECT COUNT(*) FROM information_schema.columns
+			WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?`
+	case Postgres:
+		q = `SELECT COUNT(*) FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2`
+	case Oracle:
+		q = `SELECT COUNT(*) FROM user_tab_columns WHERE table_name = UPPER(:1) AND column_name = UPPER(:2)`
+	default:
+		q = `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`
+	}
+	var n int
+	if err := ex.QueryRowContext(ctx, q, table, column).Scan(&n); err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// SQLFromPool exposes a pgx pool through the database/sql interface,
+// so dialect-agnostic code can treat all datasources as *sql.DB.
+// Connections are borrowed from (and returned to) the given pool.
+func SQLFromPool(pool *pgxpool.Pool) *sql.DB {
+	return stdlib.OpenDBFromPool(pool)
+}
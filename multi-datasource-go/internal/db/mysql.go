@@ -2,37 +2,63 @@ package db
 
 import (
 	"database/sql"
-	"time"
+	"net"
 
-	_ "github.com/go-sql-driver/mysql" // MySQL driver registration for database/sql
+	"github.com/go-sql-driver/mysql" // MySQL driver for database/sql
 )
 
 // OpenMySQL initializes and returns a MySQL database connection pool.
 //
 // Parameters:
-//   - dsn:      Data Source Name containing credentials and connection info.
+//   - dsn:   Data Source Name containing credentials and connection info.
 //     Example: "user:pass@tcp(127.0.0.1:3306)/dbname?parseTime=true"
-//   - maxOpen:  Maximum number of open connections to the database.
-//   - maxIdle:  Maximum number of idle (unused) connections in the pool.
-//   - lifeMin:  Maximum lifetime (in minutes) for which a connection may be reused.
-//   - idleMin:  Maximum idle time (in minutes) before an idle connection is closed.
+//   - opts:  Pool sizing and lifetimes, connect timeout, TLS settings and session init statements
+//     (e.g. "SET SESSION sql_mode = 'STRICT_ALL_TABLES'"). Settings made in opts take
+//     precedence over the equivalent DSN parameters (timeout, tls). A CA bundle, client
+//     certificate or server name is registered with mysql.RegisterTLSConfig under the
+//     key "<addr>/<dbname>", which DSNs may also reference as tls=<key>.
 //
 // Returns:
 //   - *sql.DB:  A configured database connection pool.
-//   - error:    Non-nil if connection initialization or ping fails.
-func OpenMySQL(dsn string, maxOpen, maxIdle, lifeMin, idleMin int) (*sql.DB, error) {
-	// Initialize MySQL connection using provided DSN.
-	db, err := sql.Open("mysql", dsn)
+//   - error:    Non-nil if the options are invalid or connection initialization or ping fails.
+func OpenMySQL(dsn string, opts Options) (*sql.DB, error) {
+	// Parse the DSN so the options can be applied to the driver configuration.
+	cfg, err := mysql.ParseDSN(dsn)
 	if err != nil {
 		return nil, err
 	}
+	if opts.ConnectTimeout > 0 {
+		cfg.Timeout = opts.ConnectTimeout // Dial timeout.
+	}
+	if err := opts.TLS.validate(); err != nil {
+		return nil, err
+	}
+	switch {
+	case opts.TLS.custom():
+		host, _, err := net.SplitHostPort(cfg.Addr)
+		if err != nil {
+			host = cfg.Addr
+		}
+		tc, err := opts.TLS.tlsConfig(host)
+		if err != nil {
+			return nil, err
+		}
+		key := cfg.Addr + "/" + cfg.DBName
+		if err := mysql.RegisterTLSConfig(key, tc); err != nil {
+			return nil, err
+		}
+		cfg.TLS, cfg.TLSConfig = nil, key
+	case opts.TLS.Mode == TLSDisable:
+		cfg.TLS, cfg.TLSConfig = nil, "false"
+	case opts.TLS.Mode == TLSRequire:
+		cfg.TLS, cfg.TLSConfig = nil, "skip-verify"
+	case opts.TLS.Mode == TLSVerifyFull:
+		cfg.TLS, cfg.TLSConfig = nil, "true" // system roots, server name taken from the address
+	}
 
-	// Configure connection pool settings.
-	db.SetMaxOpenConns(maxOpen)                                 // Limit total number of open connections.
-	db.SetMaxIdleConns(maxIdle)                                 // Limit number of idle connections retained.
-	db.SetConnMaxLifetime(time.Duration(lifeMin) * time.Minute) // Set maximum lifetime for a single connection.
-	db.SetConnMaxIdleTime(time.Duration(idleMin) * time.Minute) // Set maximum idle time before closing a connection.
-
-	// Verify the database connection.
-	return db, db.Ping()
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return openDB(connector, opts)
 }
@@ -0,0 +1,195 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Options configures a connection pool and the sessions it opens.
+// Zero values keep the defaults of the driver (or of database/sql).
+type Options struct {
+	// MaxOpenConns is the maximum number of open connections to the database.
+	MaxOpenConns int
+
+	// MaxIdleConns is the maximum number of idle connections retained (database/sql pools only;
+	// pgx closes idle connections after ConnMaxIdleTime instead).
+	MaxIdleConns int
+
+	// MinConns is the number of connections a pgx pool keeps open even when idle.
+	MinConns int
+
+	// ConnMaxLifetime is how long a connection may be reused before it is closed.
+	ConnMaxLifetime time.Duration
+
+	// ConnMaxIdleTime is how long a connection may stay idle before it is closed.
+	ConnMaxIdleTime time.Duration
+
+	// HealthCheckPeriod is the pause between health checks of idle pgx connections.
+	HealthCheckPeriod time.Duration
+
+	// ConnectTimeout bounds dialing, authenticating and initializing one new connection.
+	ConnectTimeout time.Duration
+
+	// StatementCacheMode selects how pgx executes queries: cache_statement (default),
+	// cache_describe, describe_exec, exec or simple_protocol. Use exec or simple_protocol
+	// behind a transaction-pooling proxy such as PgBouncer.
+	StatementCacheMode string
+
+	// TLS configures encryption of the connections.
+	TLS TLSOptions
+
+	// InitStatements run on every new connection before it is used, e.g.
+	//
+	//	MySQL:    SET SESSION sql_mode = 'STRICT_ALL_TABLES'
+	//	Postgres: SET search_path TO app, public
+	//	Oracle:   ALTER SESSION SET NLS_DATE_FORMAT = 'YYYY-MM-DD HH24:MI:SS'
+	InitStatements []string
+}
+
+// TLS modes, named after the libpq sslmode values they correspond to.
+const (
+	TLSDisable    = "disable"     // plain connections
+	TLSRequire    = "require"     // encrypted, server certificate not verified
+	TLSVerifyFull = "verify-full" // encrypted, certificate chain and host name verified
+)
+
+// TLSOptions configures encryption of the connections to a datasource.
+// The files are re-read when they change, so certificates can be rotated
+// without restarting the application.
+type TLSOptions struct {
+	// Mode is one of TLSDisable, TLSRequire or TLSVerifyFull.
+	// Empty keeps whatever the DSN specifies, unless one of the settings
+	// below is made; then it defaults to TLSVerifyFull.
+	Mode string
+
+	// CAFile is a PEM bundle of the certificate authorities trusted to sign the
+	// server certificate (e.g. the internal CA). Empty uses the system roots.
+	CAFile string
+
+	// CertFile and KeyFile are the PEM client certificate and private key
+	// presented to servers that require mutual TLS.
+	CertFile string
+	KeyFile  string
+
+	// ServerName is the host name expected in the server certificate (and sent
+	// as SNI). Empty uses the host of the DSN.
+	ServerName string
+
+	// Wallet is the directory of an Oracle wallet (cwallet.sso, or ewallet.p12
+	// with WalletPassword) holding the CA and client certificates. Oracle only;
+	// it replaces CAFile, CertFile and KeyFile.
+	Wallet         string
+	WalletPassword string
+}
+
+// validate rejects unknown TLS modes and settings that contradict the mode.
+func (t TLSOptions) validate() error {
+	switch t.Mode {
+	case "", TLSRequire, TLSVerifyFull:
+		return nil
+	case TLSDisable:
+		if t.custom() || t.Wallet != "" {
+			return fmt.Errorf("tls mode %s does not take certificate settings", TLSDisable)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown tls mode %q (expected %s, %s or %s)", t.Mode, TLSDisable, TLSRequire, TLSVerifyFull)
+	}
+}
+
+// openDB creates a database/sql pool on top of the connector and applies the pool
+// options. New connections are bounded by ConnectTimeout and run InitStatements.
+// The pool is verified with a ping; it is closed again if the ping fails.
+func openDB(c driver.Connector, o Options) (*sql.DB, error) {
+	db := sql.OpenDB(&initConnector{Connector: c, timeout: o.ConnectTimeout, stmts: o.InitStatements})
+
+	// Configure connection pool settings (zero keeps the database/sql default:
+	// unlimited connections, 2 idle ones, no lifetime or idle time limit).
+	db.SetMaxOpenConns(o.MaxOpenConns) // Limit total number of open connections.
+	if o.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(o.MaxIdleConns) // Limit number of idle connections retained.
+	}
+	db.SetConnMaxLifetime(o.ConnMaxLifetime) // Set maximum lifetime for a single connection.
+	db.SetConnMaxIdleTime(o.ConnMaxIdleTime) // Set maximum idle time before closing a connection.
+
+	// Verify the database connection.
+	ctx := context.Background()
+	if o.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.ConnectTimeout)
+		defer cancel()
+	}
+	if err := db.PingContext(ctx); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+	return db, nil
+}
+
+// initConnector bounds the connections opened by a driver connector with a
+// timeout and runs the init statements on each of them.
+type initConnector struct {
+	driver.Connector
+	timeout time.Duration
+	stmts   []string
+}
+
+// Connect opens a connection and prepares its session.
+func (c *initConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, stmt := range c.stmts {
+		if err := execConn(ctx, conn, stmt); err != nil {
+			return nil, errors.Join(fmt.Errorf("init statement %q: %w", stmt, err), conn.Close())
+		}
+	}
+	return conn, nil
+}
+
+// execConn executes a statement without arguments on a driver connection.
+func execConn(ctx context.Context, conn driver.Conn, stmt string) error {
+	if ex, ok := conn.(driver.ExecerContext); ok {
+		_, err := ex.ExecContext(ctx, stmt, nil)
+		if !errors.Is(err, driver.ErrSkip) {
+			return err
+		}
+	}
+	var (
+		st  driver.Stmt
+		err error
+	)
+	if p, ok := conn.(driver.ConnPrepareContext); ok {
+		st, err = p.PrepareContext(ctx, stmt)
+	} else {
+		st, err = conn.Prepare(stmt)
+	}
+	if err != nil {
+		return err
+	}
+	defer st.Close()
+	if ex, ok := st.(driver.StmtExecContext); ok {
+		_, err = ex.ExecContext(ctx, nil)
+	} else {
+		_, err = st.Exec(nil)
+	}
+	return err
+}
+
+// dsnConnector adapts a driver that only opens connections by DSN.
+type dsnConnector struct {
+	dsn string
+	drv driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) { return c.drv.Open(c.dsn) }
+func (c dsnConnector) Driver() driver.Driver                        { return c.drv }
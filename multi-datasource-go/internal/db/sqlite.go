@@ -0,0 +1,72 @@
+package db
+
+import (
+	"database/sql"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3" // SQLite driver for database/sql (cgo)
+)
+
+// sqliteDefaults are the connection parameters OpenSQLite adds unless the DSN sets them:
+// wait for locks instead of failing with SQLITE_BUSY, let readers run next to the
+// writer (WAL), take the write lock when a transaction begins (so read-then-write
+// transactions cannot deadlock) and enforce foreign keys.
+var sqliteDefaults = map[string]string{
+	"_busy_timeout": "5000",
+	"_journal_mode": "WAL",
+	"_txlock":       "immediate",
+	"_foreign_keys": "1",
+}
+
+// SQLite has no timestamp type: timestamps are stored as text and compared as
+// text, so every writer must use one layout for ORDER BY and range conditions
+// to agree with time order. SQLiteNow is the current time in that layout
+// (UTC, microseconds, the precision the other datasources store), and
+// SQLiteTime formats a time.Time as a bind argument in the same layout.
+// CURRENT_TIMESTAMP (no fraction) and the driver's default binding of
+// time.Time (with a zone offset) both sort differently and must not be used.
+const (
+	SQLiteNow        = "strftime('%Y-%m-%d %H:%M:%f000', 'now')"
+	sqliteTimeLayout = "2006-01-02 15:04:05.000000"
+)
+
+// SQLiteTime returns t in the layout of SQLiteNow.
+func SQLiteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeLayout)
+}
+
+// OpenSQLite initializes and returns a SQLite database connection pool.
+// SQLite keeps the whole database in one local file, so it needs no server;
+// it is meant for local development and demos, not for production traffic.
+//
+// Parameters:
+//   - dsn:   Path of the database file, optionally with driver parameters.
+//     Example: "multi-datasource.db" or "file:dev.db?_busy_timeout=10000"
+//     An in-memory database (":memory:") exists per connection; use MaxOpenConns 1 with it.
+//   - opts:  Pool sizing and lifetimes and session init statements (e.g. "PRAGMA cache_size = -20000").
+//     TLS and the pgx settings do not apply to a local file.
+//
+// Returns:
+//   - *sql.DB:  A configured database connection pool.
+//   - error:    Non-nil if connection initialization or ping fails.
+func OpenSQLite(dsn string, opts Options) (*sql.DB, error) {
+	// Open the database file (created on first use) with the default parameters applied.
+	return openDB(dsnConnector{dsn: sqliteDSN(dsn), drv: &sqlite3.SQLiteDriver{}}, opts)
+}
+
+// sqliteDSN appends the sqliteDefaults missing from the query part of dsn.
+func sqliteDSN(dsn string) string {
+	path, query, _ := strings.Cut(dsn, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return dsn // let the driver report the malformed parameters
+	}
+	for k, v := range sqliteDefaults {
+		if !params.Has(k) {
+			params.Set(k, v)
+		}
+	}
+	return path + "?" + params.Encode()
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenSQLite(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "test.db")
+	dbx, err := OpenSQLite(dsn, Options{
+		MaxOpenConns:    2,
+		ConnMaxLifetime: time.Minute,
+		ConnectTimeout:  time.Second,
+		InitStatements:  []string{"CREATE TEMP TABLE session_marker (x INTEGER)"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbx.Close()
+
+	// The defaults of sqliteDSN apply unless the DSN sets them.
+	var mode string
+	if err := dbx.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatal(err)
+	}
+	if mode != "wal" {
+		t.Errorf("journal_mode = %q, want wal", mode)
+	}
+
+	// Temporary tables exist per connection, so every connection ran the init statement.
+	// Both connections are held at once, so they are distinct.
+	for i := range 2 {
+		c, err := dbx.Conn(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+		var n int
+		if err := c.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM temp.session_marker").Scan(&n); err != nil {
+			t.Fatalf("connection %d: %v", i, err)
+		}
+	}
+}
+
+func TestOpenSQLiteFailingInitStatement(t *testing.T) {
+	_, err := OpenSQLite(filepath.Join(t.TempDir(), "test.db"), Options{
+		InitStatements: []string{"SELEC 1"},
+	})
+	if err == nil || !strings.Contains(err.Error(), `init statement "SELEC 1"`) {
+		t.Fatalf("err = %v, want the failing init statement", err)
+	}
+}
+
+func TestSQLiteDSN(t *testing.T) {
+	got := sqliteDSN("file:dev.db?_busy_timeout=100")
+	for _, want := range []string{"file:dev.db?", "_busy_timeout=100", "_journal_mode=WAL", "_txlock=immediate", "_foreign_keys=1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("sqliteDSN = %q, missing %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+
+	"multi-datasource-go/internal/certs"
+)
+
+// custom reports whether the options need a tls.Config of our own
+// (a private CA, a client certificate or a fixed server name).
+func (t TLSOptions) custom() bool {
+	return t.CAFile != "" || t.CertFile != "" || t.ServerName != ""
+}
+
+// mode returns the effective TLS mode. Custom settings without a mode imply verify-full.
+func (t TLSOptions) mode() string {
+	if t.Mode == "" && t.custom() {
+		return TLSVerifyFull
+	}
+	return t.Mode
+}
+
+// tlsConfig builds the client TLS configuration for a datasource reached at host.
+// The CA bundle and the client key pair are read now, so configuration mistakes
+// stop the startup, and read again whenever one of the files changes: a rotated
+// certificate is used by the next connection the pool opens, without a restart.
+//
+// The certificate chain is verified in VerifyConnection against the current CA
+// bundle (the system roots if CAFile is empty) and ServerName (host if empty).
+// The standard verification is switched off because it can only use the roots
+// known when the config was built, and because go-ora overwrites ServerName.
+func (t TLSOptions) tlsConfig(host string) (*tls.Config, error) {
+	files := &certs.Files{CAFile: t.CAFile, CertFile: t.CertFile, KeyFile: t.KeyFile}
+	if _, _, err := files.Load(); err != nil {
+		return nil, err
+	}
+
+	name := t.ServerName
+	if name == "" {
+		name = host
+	}
+	mode := t.mode()
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         name,
+		InsecureSkipVerify: true, // verified by VerifyConnection with the current CA bundle
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if mode != TLSVerifyFull {
+				return nil
+			}
+			roots, _, err := files.Load()
+			if err != nil {
+				return err
+			}
+			return verifyChain(cs.PeerCertificates, roots, name)
+		},
+	}
+	if t.CertFile != "" {
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			_, pair, err := files.Load()
+			return pair, err
+		}
+	}
+	return cfg, nil
+}
+
+// verifyChain verifies the certificates presented by a server against roots
+// (the system roots if nil) and the expected server name.
+func verifyChain(certs []*x509.Certificate, roots *x509.CertPool, name string) error {
+	if len(certs) == 0 {
+		return errors.New("tls: server presented no certificate")
+	}
+	opts := x509.VerifyOptions{Roots: roots, DNSName: name, Intermediates: x509.NewCertPool()}
+	for _, c := range certs[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
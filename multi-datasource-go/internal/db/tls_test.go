@@ -0,0 +1,223 @@
+package db
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCert is a certificate with its key, signed by parent (self-signed if nil).
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid = true, true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+// write stores the certificate (and key, if keyPath is set) as PEM files.
+// The modification time moves forward on every call, so rewrites are detected
+// even on file systems with coarse timestamps.
+func (c *testCert) write(t *testing.T, certPath, keyPath string) {
+	t.Helper()
+	writePEM(t, certPath, "CERTIFICATE", c.der)
+	if keyPath != "" {
+		der, err := x509.MarshalECPrivateKey(c.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writePEM(t, keyPath, "EC PRIVATE KEY", der)
+	}
+}
+
+var mtime = time.Now()
+
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mtime = mtime.Add(time.Second)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// handshake connects the client config to a server presenting server and
+// requiring a client certificate signed by clientCA. It returns the client
+// certificate the server saw and the client error.
+func handshake(t *testing.T, client *tls.Config, server, clientCA *testCert) (*x509.Certificate, error) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	cc, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+	sc, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sc.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(clientCA.cert)
+	srv := tls.Server(sc, &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{server.der}, PrivateKey: server.key}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	})
+	peer := make(chan *x509.Certificate, 1)
+	go func() {
+		if srv.Handshake() != nil {
+			peer <- nil
+			return
+		}
+		peer <- srv.ConnectionState().PeerCertificates[0]
+	}()
+
+	err = tls.Client(cc, client.Clone()).Handshake()
+	if err != nil {
+		cc.Close() // unblocks the server
+	}
+	return <-peer, err
+}
+
+func TestTLSConfigVerifiesAndReloads(t *testing.T) {
+	dir := t.TempDir()
+	caFile, certFile, keyFile := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+
+	oldCA, newCA := newTestCert(t, "old-ca", nil), newTestCert(t, "new-ca", nil)
+	oldServer, newServer := newTestCert(t, "db.internal", oldCA), newTestCert(t, "db.internal", newCA)
+	oldClient, newClient := newTestCert(t, "app", oldCA), newTestCert(t, "app", oldCA)
+	oldCA.write(t, caFile, "")
+	oldClient.write(t, certFile, keyFile)
+
+	cfg, err := TLSOptions{CAFile: caFile, CertFile: certFile, KeyFile: keyFile, ServerName: "db.internal"}.tlsConfig("10.0.0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen, err := handshake(t, cfg, oldServer, oldCA)
+	if err != nil {
+		t.Fatalf("handshake with the trusted server: %v", err)
+	}
+	if !seen.Equal(oldClient.cert) {
+		t.Error("server did not receive the client certificate")
+	}
+	if _, err := handshake(t, cfg, newServer, oldCA); err == nil {
+		t.Fatal("a server signed by an untrusted CA was accepted")
+	}
+
+	// Rotate the CA bundle and the client key pair in place.
+	newCA.write(t, caFile, "")
+	newClient.write(t, certFile, keyFile)
+	seen, err = handshake(t, cfg, newServer, oldCA)
+	if err != nil {
+		t.Fatalf("handshake after rotating the CA: %v", err)
+	}
+	if !seen.Equal(newClient.cert) {
+		t.Error("server did not receive the rotated client certificate")
+	}
+	if _, err := handshake(t, cfg, oldServer, oldCA); err == nil {
+		t.Fatal("a server signed by the replaced CA was still accepted")
+	}
+
+	// A broken file keeps the previous certificates in use.
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handshake(t, cfg, newServer, oldCA); err != nil {
+		t.Fatalf("handshake after a broken CA file: %v", err)
+	}
+}
+
+func TestTLSConfigServerName(t *testing.T) {
+	dir := t.TempDir()
+	caFile, certFile, keyFile := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	ca := newTestCert(t, "ca", nil)
+	ca.write(t, caFile, "")
+	newTestCert(t, "app", ca).write(t, certFile, keyFile)
+	server := newTestCert(t, "db.internal", ca)
+
+	// Without ServerName the host of the DSN must match the certificate.
+	opts := TLSOptions{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}
+	cfg, err := opts.tlsConfig("db.internal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handshake(t, cfg, server, ca); err != nil {
+		t.Fatalf("matching host: %v", err)
+	}
+	if cfg, err = opts.tlsConfig("10.0.0.5"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handshake(t, cfg, server, ca); err == nil {
+		t.Fatal("a certificate for another host was accepted")
+	}
+
+	// Mode require encrypts without verifying the server.
+	opts.Mode = TLSRequire
+	if cfg, err = opts.tlsConfig("10.0.0.5"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handshake(t, cfg, server, ca); err != nil {
+		t.Fatalf("mode require: %v", err)
+	}
+}
+
+func TestTLSOptionsValidate(t *testing.T) {
+	if err := (TLSOptions{Mode: TLSDisable, CAFile: "ca.pem"}).validate(); err == nil {
+		t.Error("disable with a CA file was accepted")
+	}
+	if _, err := (TLSOptions{CertFile: "client.pem"}).tlsConfig("db"); err == nil {
+		t.Error("a client certificate without key was accepted")
+	}
+	if _, err := (TLSOptions{CAFile: filepath.Join(t.TempDir(), "missing.pem")}).tlsConfig("db"); err == nil {
+		t.Error("a missing CA file was accepted")
+	}
+}
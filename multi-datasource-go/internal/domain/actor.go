@@ -0,0 +1,37 @@
+package domain
+
+import "context"
+
+// Actor identifies the authenticated caller on whose behalf an operation runs.
+// It is attached to the request context by the auth middleware so that
+// repositories can record who performed a change.
+type Actor struct {
+	ID     string   // stable caller identifier (API key name or JWT subject)
+	Method string   // how the caller authenticated: "api_key" or "jwt"
+	Scopes []string // granted scopes, e.g. "users:write"
+}
+
+// HasScope reports whether the actor was granted the scope.
+// The "*" scope grants everything.
+func (a Actor) HasScope(scope string) bool {
+	for _, s := range a.Scopes {
+		if s == scope || s == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// actorKey is the unexported context key for the Actor.
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the actor.
+func WithActor(ctx context.Context, a Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFrom returns the actor stored in ctx, if any.
+func ActorFrom(ctx context.Context) (Actor, bool) {
+	a, ok := ctx.Value(actorKey{}).(Actor)
+	return a, ok
+}
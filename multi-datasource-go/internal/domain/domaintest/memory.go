@@ -0,0 +1,146 @@
+// Package domaintest provides in-memory implementations of the domain
+// repositories for tests that need the real services without a database.
+//
+// The fakes pass the same contract suite (internal/repo/repotest) as the SQL
+// repositories, so tests built on them exercise realistic behavior: IDs are
+// assigned on create, missing rows report domain.ErrNotFound and canceled
+// contexts fail.
+package domaintest
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"multi-datasource-go/internal/domain"
+)
+
+// table is an in-memory table of one entity type.
+type table[T any] struct {
+	mu    sync.Mutex
+	next  int64
+	rows  map[int64]T
+	setID func(*T, int64)
+	getID func(*T) int64
+}
+
+func newTable[T any](setID func(*T, int64), getID func(*T) int64) *table[T] {
+	return &table[T]{rows: map[int64]T{}, setID: setID, getID: getID}
+}
+
+func (r *table[T]) Create(ctx context.Context, v *T) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.next++
+	r.setID(v, r.next)
+	r.rows[r.next] = *v
+	return r.next, nil
+}
+
+func (r *table[T]) Get(ctx context.Context, id int64) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.rows[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &v, nil
+}
+
+func (r *table[T]) GetMany(ctx context.Context, ids []int64) ([]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []T
+	for _, id := range ids {
+		if v, ok := r.rows[id]; ok {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+func (r *table[T]) Update(ctx context.Context, v *T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rows[r.getID(v)]; !ok {
+		return domain.ErrNotFound
+	}
+	r.rows[r.getID(v)] = *v
+	return nil
+}
+
+func (r *table[T]) Delete(ctx context.Context, id int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rows[id]; !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.rows, id)
+	return nil
+}
+
+// UserRepo is an in-memory domain.UserRepo.
+type UserRepo struct{ *table[domain.User] }
+
+// NewUserRepo returns an empty in-memory user repository.
+func NewUserRepo() *UserRepo {
+	return &UserRepo{newTable(func(u *domain.User, id int64) { u.ID = id }, func(u *domain.User) int64 { return u.ID })}
+}
+
+// CompanyRepo is an in-memory domain.CompanyRepo.
+type CompanyRepo struct{ *table[domain.Company] }
+
+// NewCompanyRepo returns an empty in-memory company repository.
+func NewCompanyRepo() *CompanyRepo {
+	return &CompanyRepo{newTable(func(c *domain.Company, id int64) { c.ID = id }, func(c *domain.Company) int64 { return c.ID })}
+}
+
+// BrandRepo is an in-memory domain.BrandRepo.
+type BrandRepo struct{ *table[domain.Brand] }
+
+// NewBrandRepo returns an empty in-memory brand repository.
+func NewBrandRepo() *BrandRepo {
+	return &BrandRepo{newTable(func(b *domain.Brand, id int64) { b.ID = id }, func(b *domain.Brand) int64 { return b.ID })}
+}
+
+// ListByCompanies returns the brands of the given companies ordered by ID.
+func (r *BrandRepo) ListByCompanies(ctx context.Context, companyIDs []int64) ([]domain.Brand, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []domain.Brand
+	for _, b := range r.rows {
+		if b.CompanyID != nil && slices.Contains(companyIDs, *b.CompanyID) {
+			out = append(out, b)
+		}
+	}
+	slices.SortFunc(out, func(a, b domain.Brand) int { return cmp.Compare(a.ID, b.ID) })
+	return out, nil
+}
+
+// NewServices returns the real domain services over fresh in-memory
+// repositories, without auditing and with a one second timeout.
+func NewServices() (domain.UserService, domain.CompanyService, domain.BrandService) {
+	return domain.NewUserService(NewUserRepo(), time.Second),
+		domain.NewCompanyService(NewCompanyRepo(), time.Second),
+		domain.NewBrandService(NewBrandRepo(), time.Second)
+}
@@ -0,0 +1,23 @@
+package domaintest_test
+
+import (
+	"testing"
+
+	"multi-datasource-go/internal/domain"
+	"multi-datasource-go/internal/domain/domaintest"
+	"multi-datasource-go/internal/repo/repotest"
+)
+
+// The fakes must behave like the SQL repositories they replace in tests.
+
+func TestUserRepo(t *testing.T) {
+	repotest.UserRepoContract(t, func(*testing.T) domain.UserRepo { return domaintest.NewUserRepo() })
+}
+
+func TestCompanyRepo(t *testing.T) {
+	repotest.CompanyRepoContract(t, func(*testing.T) domain.CompanyRepo { return domaintest.NewCompanyRepo() })
+}
+
+func TestBrandRepo(t *testing.T) {
+	repotest.BrandRepoContract(t, func(*testing.T) domain.BrandRepo { return domaintest.NewBrandRepo() })
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when the requested entity does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrInvalidInput is wrapped by validation errors of the service layer.
+	ErrInvalidInput = errors.New("invalid input")
+)
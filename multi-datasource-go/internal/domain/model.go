@@ -1,11 +1,16 @@
 package domain
 
+// References between entities cross databases (users in MySQL and brands in
+// Oracle point at companies in PostgreSQL), so they are plain IDs that no
+// foreign key enforces: a reference to a deleted company resolves to nothing.
+
 // User represents an application user entity.
 // It maps to the "users" table in the MySQL database.
 type User struct {
-	ID       int64  `json:"id"`       // Unique identifier for the user (auto-incremented primary key)
-	Name     string `json:"name"`     // First name of the user
-	LastName string `json:"lastName"` // Last name of the user
+	ID        int64  `json:"id"`                  // Unique identifier for the user (auto-incremented primary key)
+	Name      string `json:"name"`                // First name of the user
+	LastName  string `json:"lastName"`            // Last name of the user
+	CompanyID *int64 `json:"companyId,omitempty"` // Company the user works for (PostgreSQL); nil if none
 }
 
 // Company represents a company entity.
@@ -18,6 +23,7 @@ type Company struct {
 // Brand represents a brand entity.
 // It maps to the "brands" table in the Oracle database.
 type Brand struct {
-	ID   int64  `json:"id"`   // Unique identifier for the brand (auto-incremented primary key)
-	Name string `json:"name"` // Name of the brand
+	ID        int64  `json:"id"`                  // Unique identifier for the brand (auto-incremented primary key)
+	Name      string `json:"name"`                // Name of the brand
+	CompanyID *int64 `json:"companyId,omitempty"` // Company owning the brand (PostgreSQL); nil if none
 }
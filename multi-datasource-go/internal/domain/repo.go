@@ -8,6 +8,18 @@ type UserRepo interface {
 	// Create inserts a new user record into the database.
 	// Returns the generated user ID or an error if the operation fails.
 	Create(ctx context.Context, u *User) (int64, error)
+
+	// Get returns the user with the given ID, or ErrNotFound.
+	Get(ctx context.Context, id int64) (*User, error)
+
+	// GetMany returns the users with the given IDs in one query; missing IDs are skipped.
+	GetMany(ctx context.Context, ids []int64) ([]User, error)
+
+	// Update overwrites the fields of an existing user, or returns ErrNotFound.
+	Update(ctx context.Context, u *User) error
+
+	// Delete removes the user with the given ID, or returns ErrNotFound.
+	Delete(ctx context.Context, id int64) error
 }
 
 // CompanyRepo defines the contract for company-related data operations.
@@ -16,6 +28,18 @@ type CompanyRepo interface {
 	// Create inserts a new company record into the database.
 	// Returns the generated company ID or an error if the operation fails.
 	Create(ctx context.Context, c *Company) (int64, error)
+
+	// Get returns the company with the given ID, or ErrNotFound.
+	Get(ctx context.Context, id int64) (*Company, error)
+
+	// GetMany returns the companies with the given IDs in one query; missing IDs are skipped.
+	GetMany(ctx context.Context, ids []int64) ([]Company, error)
+
+	// Update overwrites the fields of an existing company, or returns ErrNotFound.
+	Update(ctx context.Context, c *Company) error
+
+	// Delete removes the company with the given ID, or returns ErrNotFound.
+	Delete(ctx context.Context, id int64) error
 }
 
 // BrandRepo defines the contract for brand-related data operations.
@@ -24,4 +48,16 @@ type BrandRepo interface {
 	// Create inserts a new brand record into the database.
 	// Returns the generated brand ID or an error if the operation fails.
 	Create(ctx context.Context, b *Brand) (int64, error)
+
+	// Get returns the brand with the given ID, or ErrNotFound.
+	Get(ctx context.Context, id int64) (*Brand, error)
+
+	// ListByCompanies returns the brands of the given companies in one query.
+	ListByCompanies(ctx context.Context, companyIDs []int64) ([]Brand, error)
+
+	// Update overwrites the fields of an existing brand, or returns ErrNotFound.
+	Update(ctx context.Context, b *Brand) error
+
+	// Delete removes the brand with the given ID, or returns ErrNotFound.
+	Delete(ctx context.Context, id int64) error
 }
@@ -2,7 +2,7 @@ package domain
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"strings"
 	"time"
 )
@@ -14,21 +14,57 @@ import (
 // UserService defines business operations related to users.
 // Handlers depend on this interface rather than concrete repositories.
 type UserService interface {
-	// CreateUser validates and creates a new user record.
+	// CreateUser validates and creates a new user record, optionally working for a company.
 	// Returns the created user ID or an error.
-	CreateUser(ctx context.Context, name, lastName string) (int64, error)
+	CreateUser(ctx context.Context, name, lastName string, companyID *int64) (int64, error)
+
+	// GetUser returns the user with the given ID, or ErrNotFound.
+	GetUser(ctx context.Context, id int64) (*User, error)
+
+	// GetUsers returns the users with the given IDs; missing IDs are skipped.
+	GetUsers(ctx context.Context, ids []int64) ([]User, error)
+
+	// UpdateUser validates and overwrites the user's names and company.
+	UpdateUser(ctx context.Context, id int64, name, lastName string, companyID *int64) (*User, error)
+
+	// DeleteUser removes the user with the given ID.
+	DeleteUser(ctx context.Context, id int64) error
 }
 
 // CompanyService defines business operations related to companies.
 type CompanyService interface {
 	// CreateCompany validates and creates a new company record.
 	CreateCompany(ctx context.Context, name string) (int64, error)
+
+	// GetCompany returns the company with the given ID, or ErrNotFound.
+	GetCompany(ctx context.Context, id int64) (*Company, error)
+
+	// GetCompanies returns the companies with the given IDs; missing IDs are skipped.
+	GetCompanies(ctx context.Context, ids []int64) ([]Company, error)
+
+	// UpdateCompany validates and renames the company.
+	UpdateCompany(ctx context.Context, id int64, name string) (*Company, error)
+
+	// DeleteCompany removes the company with the given ID.
+	DeleteCompany(ctx context.Context, id int64) error
 }
 
 // BrandService defines business operations related to brands.
 type BrandService interface {
-	// CreateBrand validates and creates a new brand record.
-	CreateBrand(ctx context.Context, name string) (int64, error)
+	// CreateBrand validates and creates a new brand record, optionally owned by a company.
+	CreateBrand(ctx context.Context, name string, companyID *int64) (int64, error)
+
+	// GetBrand returns the brand with the given ID, or ErrNotFound.
+	GetBrand(ctx context.Context, id int64) (*Brand, error)
+
+	// ListBrandsByCompanies returns the brands owned by the given companies.
+	ListBrandsByCompanies(ctx context.Context, companyIDs []int64) ([]Brand, error)
+
+	// UpdateBrand validates and overwrites the brand's name and company.
+	UpdateBrand(ctx context.Context, id int64, name string, companyID *int64) (*Brand, error)
+
+	// DeleteBrand removes the brand with the given ID.
+	DeleteBrand(ctx context.Context, id int64) error
 }
 
 // =====================================================
@@ -50,6 +86,8 @@ func withTimeout(parent context.Context, d time.Duration) (context.Context, cont
 // =====================================================
 
 // userService provides user-related business logic and enforces validation and timeouts.
+// Changes are audited by the repository, which records the caller and the
+// previous state with the change event in the same transaction.
 type userService struct {
 	repo    UserRepo      // Underlying data repository for users
 	timeout time.Duration // Operation timeout duration
@@ -60,23 +98,90 @@ func NewUserService(repo UserRepo, timeout time.Duration) UserService {
 	return &userService{repo: repo, timeout: timeout}
 }
 
-// CreateUser validates input and delegates user creation to the repository layer.
-func (s *userService) CreateUser(ctx context.Context, name, lastName string) (int64, error) {
-	// Clean and validate input
+// validateCompanyRef rejects non-positive company references.
+// Whether the company exists is not checked: it lives in another database.
+func validateCompanyRef(companyID *int64) error {
+	if companyID != nil && *companyID <= 0 {
+		return fmt.Errorf("%w: companyId must be a positive integer", ErrInvalidInput)
+	}
+	return nil
+}
+
+// validateUser cleans and validates user names.
+func validateUser(name, lastName string) (string, string, error) {
 	name = strings.TrimSpace(name)
 	lastName = strings.TrimSpace(lastName)
 	if name == "" || lastName == "" {
-		return 0, errors.New("name and lastName are required")
+		return "", "", fmt.Errorf("%w: name and lastName are required", ErrInvalidInput)
+	}
+	return name, lastName, nil
+}
+
+// CreateUser validates input and delegates user creation to the repository layer.
+func (s *userService) CreateUser(ctx context.Context, name, lastName string, companyID *int64) (int64, error) {
+	// Clean and validate input
+	name, lastName, err := validateUser(name, lastName)
+	if err != nil {
+		return 0, err
+	}
+	if err := validateCompanyRef(companyID); err != nil {
+		return 0, err
 	}
 
 	// Build user entity
-	u := &User{Name: name, LastName: lastName}
+	u := &User{Name: name, LastName: lastName, CompanyID: companyID}
 
 	// Apply timeout for database operation
 	cctx, cancel := withTimeout(ctx, s.timeout)
 	defer cancel()
 
-	return s.repo.Create(cctx, u)
+	id, err := s.repo.Create(cctx, u)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetUser loads a user through the repository.
+func (s *userService) GetUser(ctx context.Context, id int64) (*User, error) {
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+	return s.repo.Get(cctx, id)
+}
+
+// GetUsers loads several users with one repository call.
+func (s *userService) GetUsers(ctx context.Context, ids []int64) ([]User, error) {
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+	return s.repo.GetMany(cctx, ids)
+}
+
+// UpdateUser validates input and updates the user.
+func (s *userService) UpdateUser(ctx context.Context, id int64, name, lastName string, companyID *int64) (*User, error) {
+	name, lastName, err := validateUser(name, lastName)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCompanyRef(companyID); err != nil {
+		return nil, err
+	}
+
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+
+	u := &User{ID: id, Name: name, LastName: lastName, CompanyID: companyID}
+	if err := s.repo.Update(cctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// DeleteUser removes a user.
+func (s *userService) DeleteUser(ctx context.Context, id int64) error {
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.repo.Delete(cctx, id)
 }
 
 // =====================================================
@@ -94,11 +199,20 @@ func NewCompanyService(repo CompanyRepo, timeout time.Duration) CompanyService {
 	return &companyService{repo: repo, timeout: timeout}
 }
 
-// CreateCompany validates the company name and creates a record via the repository.
-func (s *companyService) CreateCompany(ctx context.Context, name string) (int64, error) {
+// validateName cleans and validates the name of a company or brand.
+func validateName(kind, name string) (string, error) {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return 0, errors.New("company name is required")
+		return "", fmt.Errorf("%w: %s name is required", ErrInvalidInput, kind)
+	}
+	return name, nil
+}
+
+// CreateCompany validates the company name and creates a record via the repository.
+func (s *companyService) CreateCompany(ctx context.Context, name string) (int64, error) {
+	name, err := validateName("company", name)
+	if err != nil {
+		return 0, err
 	}
 
 	c := &Company{Name: name}
@@ -106,7 +220,50 @@ func (s *companyService) CreateCompany(ctx context.Context, name string) (int64,
 	cctx, cancel := withTimeout(ctx, s.timeout)
 	defer cancel()
 
-	return s.repo.Create(cctx, c)
+	id, err := s.repo.Create(cctx, c)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetCompany loads a company through the repository.
+func (s *companyService) GetCompany(ctx context.Context, id int64) (*Company, error) {
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+	return s.repo.Get(cctx, id)
+}
+
+// GetCompanies loads several companies with one repository call.
+func (s *companyService) GetCompanies(ctx context.Context, ids []int64) ([]Company, error) {
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+	return s.repo.GetMany(cctx, ids)
+}
+
+// UpdateCompany validates the name and renames the company.
+func (s *companyService) UpdateCompany(ctx context.Context, id int64, name string) (*Company, error) {
+	name, err := validateName("company", name)
+	if err != nil {
+		return nil, err
+	}
+
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+
+	c := &Company{ID: id, Name: name}
+	if err := s.repo.Update(cctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DeleteCompany removes a company.
+func (s *companyService) DeleteCompany(ctx context.Context, id int64) error {
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+
+	return s.repo.Delete(cctx, id)
 }
 
 // =====================================================
@@ -125,16 +282,65 @@ func NewBrandService(repo BrandRepo, timeout time.Duration) BrandService {
 }
 
 // CreateBrand validates the brand name and delegates creation to the repository.
-func (s *brandService) CreateBrand(ctx context.Context, name string) (int64, error) {
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return 0, errors.New("brand name is required")
+func (s *brandService) CreateBrand(ctx context.Context, name string, companyID *int64) (int64, error) {
+	name, err := validateName("brand", name)
+	if err != nil {
+		return 0, err
+	}
+	if err := validateCompanyRef(companyID); err != nil {
+		return 0, err
 	}
 
-	b := &Brand{Name: name}
+	b := &Brand{Name: name, CompanyID: companyID}
+
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+
+	id, err := s.repo.Create(cctx, b)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// GetBrand loads a brand through the repository.
+func (s *brandService) GetBrand(ctx context.Context, id int64) (*Brand, error) {
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+	return s.repo.Get(cctx, id)
+}
+
+// ListBrandsByCompanies loads the brands of several companies with one repository call.
+func (s *brandService) ListBrandsByCompanies(ctx context.Context, companyIDs []int64) ([]Brand, error) {
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+	return s.repo.ListByCompanies(cctx, companyIDs)
+}
+
+// UpdateBrand validates input and updates the brand.
+func (s *brandService) UpdateBrand(ctx context.Context, id int64, name string, companyID *int64) (*Brand, error) {
+	name, err := validateName("brand", name)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCompanyRef(companyID); err != nil {
+		return nil, err
+	}
+
+	cctx, cancel := withTimeout(ctx, s.timeout)
+	defer cancel()
+
+	b := &Brand{ID: id, Name: name, CompanyID: companyID}
+	if err := s.repo.Update(cctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
 
+// DeleteBrand removes a brand.
+func (s *brandService) DeleteBrand(ctx context.Context, id int64) error {
 	cctx, cancel := withTimeout(ctx, s.timeout)
 	defer cancel()
 
-	return s.repo.Create(cctx, b)
+	return s.repo.Delete(cctx, id)
 }
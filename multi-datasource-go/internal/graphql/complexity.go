@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// complexity estimates the cost of running an operation: every field costs 1
+// and the cost of a list field's selection is multiplied by the number of
+// elements it is expected to return. Lists selected by IDs count their IDs;
+// other lists (a company's brands) count listCost elements.
+// Introspection fields are free; they never reach a datasource.
+type complexity struct {
+	schema   *ast.Schema
+	listCost int
+}
+
+// measure returns the complexity of the named operation of query. Queries the
+// schema rejects measure 0, leaving it to the executor to report the errors.
+func (c complexity) measure(query, operationName string, variables map[string]any) int {
+	doc, errs := gqlparser.LoadQueryWithRules(c.schema, query, nil)
+	if len(errs) > 0 {
+		return 0
+	}
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		return 0
+	}
+	return c.selectionSet(op.SelectionSet, variables)
+}
+
+// selectionSet sums the cost of the fields of set. Validation linked every
+// fragment spread to its definition and rejected fragment cycles.
+func (c complexity) selectionSet(set ast.SelectionSet, vars map[string]any) int {
+	total := 0
+	for _, sel := range set {
+		switch s := sel.(type) {
+		case *ast.Field:
+			total += c.field(s, vars)
+		case *ast.InlineFragment:
+			total += c.selectionSet(s.SelectionSet, vars)
+		case *ast.FragmentSpread:
+			total += c.selectionSet(s.Definition.SelectionSet, vars)
+		}
+	}
+	return total
+}
+
+func (c complexity) field(f *ast.Field, vars map[string]any) int {
+	if strings.HasPrefix(f.Name, "__") {
+		return 0
+	}
+	children := c.selectionSet(f.SelectionSet, vars)
+	if f.Definition != nil && f.Definition.Type.Elem != nil {
+		children *= c.elements(f, vars)
+	}
+	return 1 + children
+}
+
+// elements is the expected number of elements of the list field f.
+func (c complexity) elements(f *ast.Field, vars map[string]any) int {
+	if ids, ok := f.ArgumentMap(vars)["ids"].([]any); ok {
+		return len(ids)
+	}
+	return c.listCost
+}
@@ -0,0 +1,112 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// fetchFunc loads the values of many keys with one datasource query.
+// Keys without a value are simply absent from the returned map.
+type fetchFunc[V any] func(ctx context.Context, keys []int64) (map[int64]V, error)
+
+// result is the outcome of one key, shared by every caller loading it.
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+}
+
+// loader batches the lookups made while resolving one request: keys requested
+// within a short window are fetched together, so resolving N users' companies
+// costs one query instead of N. Results are memoized for the request.
+//
+// Parent resolvers Prime the keys their children will load, which makes the
+// batching independent of how many resolvers the executor runs in parallel.
+type loader[V any] struct {
+	ctx      context.Context // request context the batches run in
+	fetch    fetchFunc[V]
+	wait     time.Duration // how long a batch collects keys before it is fetched
+	maxBatch int           // a batch reaching this size is fetched immediately
+
+	mu      sync.Mutex
+	results map[int64]*result[V]
+	pending []int64 // keys of the batch being collected
+}
+
+func newLoader[V any](ctx context.Context, fetch fetchFunc[V], wait time.Duration, maxBatch int) *loader[V] {
+	return &loader[V]{ctx: ctx, fetch: fetch, wait: wait, maxBatch: maxBatch, results: map[int64]*result[V]{}}
+}
+
+// Prime schedules the keys for the next batch without waiting for them.
+func (l *loader[V]) Prime(keys ...int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, k := range keys {
+		l.enqueue(k)
+	}
+}
+
+// Load returns the value of key, reporting found = false if it does not exist.
+func (l *loader[V]) Load(ctx context.Context, key int64) (V, bool, error) {
+	l.mu.Lock()
+	r := l.enqueue(key)
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.found, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, false, ctx.Err()
+	}
+}
+
+// enqueue returns the result of key, adding the key to the pending batch
+// if it was not requested before. l.mu must be held.
+func (l *loader[V]) enqueue(key int64) *result[V] {
+	if r, ok := l.results[key]; ok {
+		return r
+	}
+	r := &result[V]{done: make(chan struct{})}
+	l.results[key] = r
+	l.pending = append(l.pending, key)
+
+	switch {
+	case len(l.pending) >= l.maxBatch:
+		go l.dispatch(l.take())
+	case len(l.pending) == 1:
+		time.AfterFunc(l.wait, func() {
+			l.mu.Lock()
+			keys := l.take()
+			l.mu.Unlock()
+			l.dispatch(keys)
+		})
+	}
+	return r
+}
+
+// take detaches the pending batch. l.mu must be held.
+func (l *loader[V]) take() []int64 {
+	keys := l.pending
+	l.pending = nil
+	return keys
+}
+
+// dispatch fetches a batch and completes the results of its keys.
+func (l *loader[V]) dispatch(keys []int64) {
+	if len(keys) == 0 {
+		return // already fetched because the batch filled up
+	}
+	values, err := l.fetch(l.ctx, keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, k := range keys {
+		r := l.results[k]
+		r.value, r.found = values[k]
+		r.err = err
+		close(r.done)
+	}
+}
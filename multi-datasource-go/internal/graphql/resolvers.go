@@ -0,0 +1,259 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"multi-datasource-go/internal/domain"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// request is the state shared by the resolvers of one request.
+type request struct {
+	authorize bool
+	users     *loader[domain.User]
+	companies *loader[domain.Company]
+	brands    *loader[[]domain.Brand] // keyed by company ID
+}
+
+// requestKey is the unexported context key for the request.
+type requestKey struct{}
+
+func withRequest(ctx context.Context, r *request) context.Context {
+	return context.WithValue(ctx, requestKey{}, r)
+}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// require fails unless authorization is disabled or the actor has the scope,
+// mirroring the <entity>:read scopes of the REST routes.
+func (r *request) require(ctx context.Context, scope string) error {
+	if !r.authorize {
+		return nil
+	}
+	if a, ok := domain.ActorFrom(ctx); !ok || !a.HasScope(scope) {
+		return fmt.Errorf("missing scope %s", scope)
+	}
+	return nil
+}
+
+// primeCompanies schedules the companies (and their brands) that the selection
+// under ctx will resolve, so that all of them are fetched in one batch no matter
+// how many resolvers the executor runs concurrently. path is the selected field
+// holding the company, relative to ctx.
+func (r *request) primeCompanies(ctx context.Context, path string, companyIDs []int64) {
+	if len(companyIDs) == 0 {
+		return
+	}
+	if graphql.HasSelectedField(ctx, path) {
+		r.companies.Prime(companyIDs...)
+	}
+	if graphql.HasSelectedField(ctx, path+".brands") {
+		r.brands.Prime(companyIDs...)
+	}
+}
+
+// parseID converts a GraphQL ID into an entity ID.
+func parseID(id graphql.ID) (int64, error) {
+	n, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%w: id must be a positive integer", domain.ErrInvalidInput)
+	}
+	return n, nil
+}
+
+// load resolves the entities with the given IDs through l, skipping missing ones.
+func load[V any](ctx context.Context, l *loader[V], args []graphql.ID) ([]V, error) {
+	ids := make([]int64, len(args))
+	for i, a := range args {
+		id, err := parseID(a)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	l.Prime(ids...)
+	values := make([]V, 0, len(ids))
+	for _, id := range ids {
+		v, found, err := l.Load(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// companyIDs returns the non-nil company references.
+func companyIDs(refs ...*int64) []int64 {
+	ids := make([]int64, 0, len(refs))
+	for _, ref := range refs {
+		if ref != nil {
+			ids = append(ids, *ref)
+		}
+	}
+	return ids
+}
+
+// ===== Query =====
+
+// queryResolver resolves the fields of the Query type. Brands are the only
+// entities looked up by ID without a loader: nothing references them by ID.
+type queryResolver struct {
+	brands domain.BrandService
+}
+
+func (q *queryResolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	users, err := q.Users(ctx, struct{ IDs []graphql.ID }{[]graphql.ID{args.ID}})
+	if err != nil || len(users) == 0 {
+		return nil, err
+	}
+	return users[0], nil
+}
+
+func (*queryResolver) Users(ctx context.Context, args struct{ IDs []graphql.ID }) ([]*userResolver, error) {
+	r := requestFrom(ctx)
+	if err := r.require(ctx, "users:read"); err != nil {
+		return nil, err
+	}
+	users, err := load(ctx, r.users, args.IDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*userResolver, len(users))
+	refs := make([]*int64, len(users))
+	for i, u := range users {
+		out[i] = &userResolver{r: r, u: u}
+		refs[i] = u.CompanyID
+	}
+	r.primeCompanies(ctx, "company", companyIDs(refs...))
+	return out, nil
+}
+
+func (q *queryResolver) Company(ctx context.Context, args struct{ ID graphql.ID }) (*companyResolver, error) {
+	companies, err := q.Companies(ctx, struct{ IDs []graphql.ID }{[]graphql.ID{args.ID}})
+	if err != nil || len(companies) == 0 {
+		return nil, err
+	}
+	return companies[0], nil
+}
+
+func (*queryResolver) Companies(ctx context.Context, args struct{ IDs []graphql.ID }) ([]*companyResolver, error) {
+	r := requestFrom(ctx)
+	if err := r.require(ctx, "companies:read"); err != nil {
+		return nil, err
+	}
+	companies, err := load(ctx, r.companies, args.IDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*companyResolver, len(companies))
+	ids := make([]int64, len(companies))
+	for i, c := range companies {
+		out[i] = &companyResolver{r: r, c: c}
+		ids[i] = c.ID
+	}
+	if graphql.HasSelectedField(ctx, "brands") {
+		r.brands.Prime(ids...)
+	}
+	return out, nil
+}
+
+func (q *queryResolver) Brand(ctx context.Context, args struct{ ID graphql.ID }) (*brandResolver, error) {
+	r := requestFrom(ctx)
+	if err := r.require(ctx, "brands:read"); err != nil {
+		return nil, err
+	}
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := q.brands.GetBrand(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.primeCompanies(ctx, "company", companyIDs(b.CompanyID))
+	return &brandResolver{r: r, b: *b}, nil
+}
+
+// ===== User =====
+
+// userResolver resolves the fields of the User type.
+type userResolver struct {
+	r *request
+	u domain.User
+}
+
+func (u *userResolver) ID() graphql.ID   { return graphql.ID(strconv.FormatInt(u.u.ID, 10)) }
+func (u *userResolver) Name() string     { return u.u.Name }
+func (u *userResolver) LastName() string { return u.u.LastName }
+
+func (u *userResolver) Company(ctx context.Context) (*companyResolver, error) {
+	return u.r.company(ctx, u.u.CompanyID)
+}
+
+// ===== Company =====
+
+// companyResolver resolves the fields of the Company type.
+type companyResolver struct {
+	r *request
+	c domain.Company
+}
+
+func (c *companyResolver) ID() graphql.ID { return graphql.ID(strconv.FormatInt(c.c.ID, 10)) }
+func (c *companyResolver) Name() string   { return c.c.Name }
+
+func (c *companyResolver) Brands(ctx context.Context) ([]*brandResolver, error) {
+	if err := c.r.require(ctx, "brands:read"); err != nil {
+		return nil, err
+	}
+	brands, _, err := c.r.brands.Load(ctx, c.c.ID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*brandResolver, len(brands))
+	for i, b := range brands {
+		out[i] = &brandResolver{r: c.r, b: b}
+	}
+	return out, nil
+}
+
+// company resolves a company reference; dangling references resolve to null.
+func (r *request) company(ctx context.Context, ref *int64) (*companyResolver, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	if err := r.require(ctx, "companies:read"); err != nil {
+		return nil, err
+	}
+	c, found, err := r.companies.Load(ctx, *ref)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &companyResolver{r: r, c: c}, nil
+}
+
+// ===== Brand =====
+
+// brandResolver resolves the fields of the Brand type.
+type brandResolver struct {
+	r *request
+	b domain.Brand
+}
+
+func (b *brandResolver) ID() graphql.ID { return graphql.ID(strconv.FormatInt(b.b.ID, 10)) }
+func (b *brandResolver) Name() string   { return b.b.Name }
+
+func (b *brandResolver) Company(ctx context.Context) (*companyResolver, error) {
+	return b.r.company(ctx, b.b.CompanyID)
+}
@@ -0,0 +1,128 @@
+// Package graphql serves users, companies and brands through one GraphQL
+// schema (schema.graphql) backed by the domain services.
+//
+// Lookups of referenced entities (a user's company, a company's brands) go
+// through per-request batching loaders, so resolving them for a list of N
+// entities costs one query per datasource instead of N. Queries are bounded
+// by nesting depth, length and an estimated complexity before they execute.
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"multi-datasource-go/internal/domain"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	maxQueryLength = 16 << 10 // bytes of a query document
+	maxBatch       = 500      // keys of one loader batch; repositories split larger IN lists further
+)
+
+// Options configures the limits and batching of a Server.
+type Options struct {
+	MaxDepth      int           // deepest allowed field nesting; 0 disables the limit
+	MaxComplexity int           // highest allowed query complexity; 0 disables the limit
+	ListCost      int           // assumed length of lists not selected by IDs when measuring complexity
+	BatchWait     time.Duration // how long lookups are collected into one batch
+	Authorize     bool          // require the <entity>:read scope of the actor in the context
+}
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Server executes GraphQL requests against the domain services.
+type Server struct {
+	users      domain.UserService
+	companies  domain.CompanyService
+	brands     domain.BrandService
+	opts       Options
+	schema     *graphql.Schema
+	complexity complexity
+}
+
+// New parses the schema and binds it to the services.
+func New(users domain.UserService, companies domain.CompanyService, brands domain.BrandService, opts Options) (*Server, error) {
+	s := &Server{users: users, companies: companies, brands: brands, opts: opts}
+
+	schemaOpts := []graphql.SchemaOpt{graphql.MaxQueryLength(maxQueryLength)}
+	if opts.MaxDepth > 0 {
+		schemaOpts = append(schemaOpts, graphql.MaxDepth(opts.MaxDepth))
+	}
+	schema, err := graphql.ParseSchema(schemaSDL, &queryResolver{brands: brands}, schemaOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse schema: %w", err)
+	}
+	s.schema = schema
+
+	// The executor's schema is opaque, so complexity is measured on a second
+	// parse of the same SDL.
+	measured, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSDL})
+	if err != nil {
+		return nil, fmt.Errorf("load schema: %w", err)
+	}
+	s.complexity = complexity{schema: measured, listCost: opts.ListCost}
+	return s, nil
+}
+
+// Execute runs the request. Failures are reported in the response's errors,
+// as GraphQL prescribes, never as a Go error.
+func (s *Server) Execute(ctx context.Context, req Request) *graphql.Response {
+	if len(req.Query) <= maxQueryLength && s.opts.MaxComplexity > 0 {
+		if c := s.complexity.measure(req.Query, req.OperationName, req.Variables); c > s.opts.MaxComplexity {
+			return &graphql.Response{Errors: []*errors.QueryError{
+				errors.Errorf("query complexity %d exceeds the limit of %d", c, s.opts.MaxComplexity),
+			}}
+		}
+	}
+	ctx = withRequest(ctx, s.newRequest(ctx))
+	return s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+}
+
+// newRequest creates the loaders shared by the resolvers of one request.
+func (s *Server) newRequest(ctx context.Context) *request {
+	return &request{
+		authorize: s.opts.Authorize,
+		users: newLoader(ctx, func(ctx context.Context, ids []int64) (map[int64]domain.User, error) {
+			users, err := s.users.GetUsers(ctx, ids)
+			return byID(users, func(u domain.User) int64 { return u.ID }), err
+		}, s.opts.BatchWait, maxBatch),
+		companies: newLoader(ctx, func(ctx context.Context, ids []int64) (map[int64]domain.Company, error) {
+			companies, err := s.companies.GetCompanies(ctx, ids)
+			return byID(companies, func(c domain.Company) int64 { return c.ID }), err
+		}, s.opts.BatchWait, maxBatch),
+		brands: newLoader(ctx, func(ctx context.Context, companyIDs []int64) (map[int64][]domain.Brand, error) {
+			brands, err := s.brands.ListBrandsByCompanies(ctx, companyIDs)
+			byCompany := make(map[int64][]domain.Brand)
+			for _, b := range brands {
+				if b.CompanyID != nil {
+					byCompany[*b.CompanyID] = append(byCompany[*b.CompanyID], b)
+				}
+			}
+			return byCompany, err
+		}, s.opts.BatchWait, maxBatch),
+	}
+}
+
+// byID indexes entities by their ID.
+func byID[V any](values []V, id func(V) int64) map[int64]V {
+	m := make(map[int64]V, len(values))
+	for _, v := range values {
+		m[id(v)] = v
+	}
+	return m
+}
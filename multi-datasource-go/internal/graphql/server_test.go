@@ -0,0 +1,245 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"multi-datasource-go/internal/domain"
+	"multi-datasource-go/internal/domain/domaintest"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// The counting services record how often each datasource is queried.
+type countingUsers struct {
+	domain.UserService
+	calls atomic.Int32
+}
+
+func (s *countingUsers) GetUsers(ctx context.Context, ids []int64) ([]domain.User, error) {
+	s.calls.Add(1)
+	return s.UserService.GetUsers(ctx, ids)
+}
+
+type countingCompanies struct {
+	domain.CompanyService
+	calls atomic.Int32
+}
+
+func (s *countingCompanies) GetCompanies(ctx context.Context, ids []int64) ([]domain.Company, error) {
+	s.calls.Add(1)
+	return s.CompanyService.GetCompanies(ctx, ids)
+}
+
+type countingBrands struct {
+	domain.BrandService
+	calls atomic.Int32
+}
+
+func (s *countingBrands) ListBrandsByCompanies(ctx context.Context, companyIDs []int64) ([]domain.Brand, error) {
+	s.calls.Add(1)
+	return s.BrandService.ListBrandsByCompanies(ctx, companyIDs)
+}
+
+// fixture holds a server over three companies with two brands each and
+// users 1 to 6 working for them (user 6 for none).
+type fixture struct {
+	srv       *Server
+	users     *countingUsers
+	companies *countingCompanies
+	brands    *countingBrands
+}
+
+func newFixture(t *testing.T, opts Options) *fixture {
+	t.Helper()
+	ctx := t.Context()
+	us, cs, bs := domaintest.NewServices()
+	f := &fixture{users: &countingUsers{UserService: us}, companies: &countingCompanies{CompanyService: cs}, brands: &countingBrands{BrandService: bs}}
+	for c := range 3 {
+		id, err := cs.CreateCompany(ctx, "Company "+string(rune('A'+c)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for b := range 2 {
+			if _, err := bs.CreateBrand(ctx, "Brand "+string(rune('A'+c))+string(rune('1'+b)), &id); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for range 2 - c/2 { // companies 1 and 2 get two users, company 3 one
+			if _, err := us.CreateUser(ctx, "Ada", "Lovelace", &id); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if _, err := us.CreateUser(ctx, "Grace", "Hopper", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if opts.BatchWait == 0 {
+		opts.BatchWait = 5 * time.Millisecond
+	}
+	srv, err := New(f.users, f.companies, f.brands, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.srv = srv
+	return f
+}
+
+// execute runs req and returns the data and the joined error messages.
+func (f *fixture) execute(ctx context.Context, req Request) (json.RawMessage, string) {
+	res := f.srv.Execute(ctx, req)
+	msgs := make([]string, len(res.Errors))
+	for i, e := range res.Errors {
+		msgs[i] = e.Message
+	}
+	return res.Data, strings.Join(msgs, "; ")
+}
+
+func TestBatchingCostsOneQueryPerDatasource(t *testing.T) {
+	f := newFixture(t, Options{})
+	data, errs := f.execute(t.Context(), Request{Query: `{
+		users(ids: ["1", "2", "3", "4", "5", "6", "99"]) {
+			name
+			company { name brands { name company { name } } }
+		}
+	}`})
+	if errs != "" {
+		t.Fatal(errs)
+	}
+
+	var out struct {
+		Users []struct {
+			Company *struct {
+				Brands []struct{ Name string }
+			}
+		}
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	brands := 0
+	for _, u := range out.Users {
+		if u.Company != nil {
+			brands += len(u.Company.Brands)
+		}
+	}
+	if len(out.Users) != 6 || out.Users[5].Company != nil || brands != 10 {
+		t.Errorf("%d users, %d brands of their companies; want 6 users (the last without company), 10 brands: %s", len(out.Users), brands, data)
+	}
+	// Brands' companies were loaded with the users' companies already.
+	if u, c, b := f.users.calls.Load(), f.companies.calls.Load(), f.brands.calls.Load(); u != 1 || c != 1 || b != 1 {
+		t.Errorf("queries: %d users, %d companies, %d brands; want 1 each", u, c, b)
+	}
+}
+
+func TestMeasureComplexity(t *testing.T) {
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSDL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := complexity{schema: schema, listCost: 10}
+	tests := []struct {
+		name      string
+		query     string
+		operation string
+		vars      map[string]any
+		want      int
+	}{
+		{"single entity", `{ user(id: "1") { name } }`, "", nil, 2},
+		{"list by ids", `{ users(ids: ["1", "2", "3"]) { name } }`, "", nil, 4},
+		{"nested lists", `{ companies(ids: ["1", "2"]) { name brands { name } } }`, "", nil, 1 + 2*(1+1+10)},
+		{"ids from variables", `query Q($ids: [ID!]!) { users(ids: $ids) { name company { name } } }`, "Q",
+			map[string]any{"ids": []any{"1", "2", "3", "4"}}, 1 + 4*(1+2)},
+		{"fragment spread", `{ companies(ids: ["1"]) { ...c } } fragment c on Company { name brands { name } }`, "", nil, 1 + 1 + 1 + 10},
+		{"inline fragment", `{ brand(id: "1") { ... on Brand { name company { name } } } }`, "", nil, 1 + 1 + 2},
+		{"introspection", `{ __schema { types { name fields { name } } } }`, "", nil, 0},
+		{"typename", `{ __typename user(id: "1") { __typename name } }`, "", nil, 2},
+		{"named operation", `query A { user(id: "1") { name } } query B { users(ids: ["1", "2"]) { name } }`, "B", nil, 3},
+		{"unknown operation", `query A { user(id: "1") { name } }`, "B", nil, 0},
+		{"invalid query", `{ user(id: "1") { salary } }`, "", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.measure(tt.query, tt.operation, tt.vars); got != tt.want {
+				t.Errorf("measure = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimits(t *testing.T) {
+	const deep = `{ users(ids: ["1"]) { company { brands { company { name } } } } }`
+	tests := []struct {
+		name  string
+		opts  Options
+		query string
+		vars  map[string]any
+		want  string // error substring; empty = executed
+	}{
+		{"depth within limit", Options{MaxDepth: 5}, deep, nil, ""},
+		{"too deep", Options{MaxDepth: 4}, deep, nil, "depth"},
+		{"complexity within limit", Options{MaxComplexity: 7, ListCost: 10}, `{ users(ids: ["1", "2"]) { name company { name } } }`, nil, ""},
+		{"too complex", Options{MaxComplexity: 6, ListCost: 10}, `{ users(ids: ["1", "2"]) { name company { name } } }`, nil, "complexity 7 exceeds the limit of 6"},
+		{"too complex by variables", Options{MaxComplexity: 20, ListCost: 10}, `query($ids: [ID!]!) { users(ids: $ids) { name } }`,
+			map[string]any{"ids": []any{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12", "13", "14", "15", "16", "17", "18", "19", "20"}},
+			"complexity 21 exceeds"},
+		{"assumed list length", Options{MaxComplexity: 20, ListCost: 50}, `{ company(id: "1") { brands { name } } }`, nil, "complexity 52 exceeds"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, tt.opts)
+			_, errs := f.execute(t.Context(), Request{Query: tt.query, Variables: tt.vars})
+			if tt.want == "" {
+				if errs != "" {
+					t.Fatalf("rejected: %s", errs)
+				}
+				return
+			}
+			if !strings.Contains(errs, tt.want) {
+				t.Fatalf("errors %q, want %q", errs, tt.want)
+			}
+			if n := f.users.calls.Load() + f.companies.calls.Load() + f.brands.calls.Load(); n != 0 {
+				t.Errorf("rejected query ran %d datasource queries", n)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	const query = `{ users(ids: ["1"]) { name company { name brands { name } } } }`
+	tests := []struct {
+		name   string
+		scopes []string // nil = no actor
+		want   []string // missing scopes reported
+	}{
+		{"no actor", nil, []string{"users:read"}},
+		{"users only", []string{"users:read"}, []string{"companies:read"}},
+		{"no brands", []string{"users:read", "companies:read"}, []string{"brands:read"}},
+		{"all entities", []string{"users:read", "companies:read", "brands:read"}, nil},
+		{"wildcard", []string{"*"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, Options{Authorize: true})
+			ctx := t.Context()
+			if tt.scopes != nil {
+				ctx = domain.WithActor(ctx, domain.Actor{ID: "ci", Scopes: tt.scopes})
+			}
+			_, errs := f.execute(ctx, Request{Query: query})
+			if tt.want == nil && errs != "" {
+				t.Fatalf("errors %q, want none", errs)
+			}
+			for _, scope := range tt.want {
+				if !strings.Contains(errs, "missing scope "+scope) {
+					t.Errorf("errors %q, want missing scope %s", errs, scope)
+				}
+			}
+		})
+	}
+}
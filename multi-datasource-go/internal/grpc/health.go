@@ -0,0 +1,44 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthCheck reports whether the datasource behind a service is reachable, e.g. db.PingContext.
+type HealthCheck func(ctx context.Context) error
+
+// WatchHealth runs the checks every interval until ctx is done and publishes the
+// result per service name (e.g. "entities.v1.UserService") on hs. The overall
+// status (service "") is SERVING only while every check passes.
+func WatchHealth(ctx context.Context, hs *health.Server, interval time.Duration, checks map[string]HealthCheck) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		overall := healthpb.HealthCheckResponse_SERVING
+		for service, check := range checks {
+			cctx, cancel := context.WithTimeout(ctx, interval)
+			err := check(cctx)
+			cancel()
+
+			st := healthpb.HealthCheckResponse_SERVING
+			if err != nil {
+				log.Printf("grpc health: %s: %v", service, err)
+				st, overall = healthpb.HealthCheckResponse_NOT_SERVING, healthpb.HealthCheckResponse_NOT_SERVING
+			}
+			hs.SetServingStatus(service, st)
+		}
+		hs.SetServingStatus("", overall)
+
+		select {
+		case <-ctx.Done():
+			hs.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
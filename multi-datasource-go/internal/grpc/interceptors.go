@@ -0,0 +1,169 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"multi-datasource-go/internal/auth"
+	"multi-datasource-go/internal/domain"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDKey is the metadata key carrying the request ID (the X-Request-ID header of the REST API).
+const requestIDKey = "x-request-id"
+
+// requestID propagates the caller's x-request-id (or generates one), returns it
+// in the response header and stores it in the context (see domain.RequestIDFrom).
+func requestID(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(requestIDKey); len(v) > 0 {
+			id = v[0]
+		}
+	}
+	if id == "" || len(id) > 100 {
+		b := make([]byte, 16)
+		_, _ = rand.Read(b)
+		id = hex.EncodeToString(b)
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+	return handler(domain.WithRequestID(ctx, id), req)
+}
+
+// authenticate resolves the caller of entity methods with the configured
+// authenticators and checks the scope of the method. The call metadata is
+// presented to the authenticators as HTTP headers, so "authorization: Bearer <jwt>"
+// and "x-api-key: <key>" work as on the REST API.
+func (s *Server) authenticate(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	scope, ok := scopes[info.FullMethod]
+	if s.Authenticators == nil || !ok {
+		return handler(ctx, req)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, info.FullMethod, nil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for k, v := range md {
+		r.Header[http.CanonicalHeaderKey(k)] = v
+	}
+
+	actor, err := auth.Authenticate(r, s.Authenticators...)
+	switch {
+	case errors.Is(err, auth.ErrNoCredentials):
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	case err != nil:
+		log.Printf("grpc auth: %v", err) // e.g. key store unavailable; don't leak details
+		return nil, status.Error(codes.Unavailable, "authentication unavailable, retry later")
+	}
+	if !actor.HasScope(scope) {
+		return nil, status.Error(codes.PermissionDenied, "missing scope "+scope)
+	}
+	return handler(domain.WithActor(ctx, actor), req)
+}
+
+// timeout bounds the call by the configured timeout. The client deadline
+// (grpc-timeout) is already part of ctx, so the shorter of both applies.
+func (s *Server) timeout(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if s.Timeout <= 0 {
+		return handler(ctx, req)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+	return handler(ctx, req)
+}
+
+// limitPeer rate limits entity methods per peer IP, before authentication, so
+// that floods of calls with bad credentials are limited too. The bucket is the
+// one the REST API uses for the client IP.
+func (s *Server) limitPeer(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	group, l, ok := s.limits(info.FullMethod)
+	if !ok || l.Limiter == nil {
+		return handler(ctx, req)
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return handler(ctx, req)
+	}
+	ip := p.Addr.String()
+	if ap, err := netip.ParseAddrPort(ip); err == nil {
+		ip = ap.Addr().Unmap().String()
+	}
+	if err := allow(ctx, l, group, group+":ip:"+ip); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// limitCaller rate limits entity methods per authenticated caller, with the
+// REST API's bucket of the identity, and then holds a slot of the bulkhead of
+// the datasource for the duration of the call.
+func (s *Server) limitCaller(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	group, l, ok := s.limits(info.FullMethod)
+	if !ok {
+		return handler(ctx, req)
+	}
+	if actor, ok := domain.ActorFrom(ctx); ok && l.Limiter != nil {
+		if err := allow(ctx, l, group, group+":actor:"+actor.ID); err != nil {
+			return nil, err
+		}
+	}
+	if l.Bulkhead != nil {
+		release, ok := l.Bulkhead.Acquire(ctx)
+		if !ok {
+			setRetryAfter(ctx, l.Bulkhead.RetryAfter)
+			return nil, status.Error(codes.Unavailable, l.Bulkhead.Name+" is overloaded, retry later")
+		}
+		defer release()
+	}
+	return handler(ctx, req)
+}
+
+// limits returns the route group of an entity method and its limits.
+func (s *Server) limits(method string) (string, Limits, bool) {
+	scope, ok := scopes[method]
+	if !ok {
+		return "", Limits{}, false
+	}
+	group, _, _ := strings.Cut(scope, ":")
+	l, ok := s.Limits[group]
+	return group, l, ok
+}
+
+// allow takes a token from the bucket of key. A call over the limit fails with
+// RESOURCE_EXHAUSTED and a retry-after header (seconds). If the limiter
+// backend fails the call is let through (fail open) and the error is logged.
+func allow(ctx context.Context, l Limits, group, key string) error {
+	res, err := l.Limiter.Allow(ctx, key, l.Rate)
+	if err != nil {
+		log.Printf("grpc ratelimit %s: %v", group, err)
+		return nil
+	}
+	if !res.Allowed {
+		setRetryAfter(ctx, res.RetryAfter)
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return nil
+}
+
+// setRetryAfter sends the retry-after response header, in seconds rounded up to at least 1.
+func setRetryAfter(ctx context.Context, d time.Duration) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Max(1, math.Ceil(d.Seconds()))))))
+}
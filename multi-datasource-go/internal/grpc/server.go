@@ -0,0 +1,127 @@
+// Package grpc exposes the user, company and brand services over gRPC
+// (api/proto/entities/v1). It is the gRPC counterpart of internal/http:
+// both transports call the same domain services, map domain errors the same
+// way (invalid input, not found) and enforce the same scopes.
+//
+// Every call runs through interceptors that propagate the request ID, rate
+// limit the peer IP, authenticate the caller, rate limit the caller, hold a
+// slot of the datasource bulkhead and bound the call by the configured
+// timeout; a client deadline shorter than the timeout wins and reaches the
+// repositories through the context.
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	entitiesv1 "multi-datasource-go/api/proto/entities/v1"
+	"multi-datasource-go/internal/auth"
+	"multi-datasource-go/internal/domain"
+	"multi-datasource-go/internal/ratelimit"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// Server groups the dependencies of the gRPC services, like http.Handlers does for the REST API.
+type Server struct {
+	Users     domain.UserService    // Service for MySQL-backed user operations
+	Companies domain.CompanyService // Service for PostgreSQL-backed company operations
+	Brands    domain.BrandService   // Service for Oracle-backed brand operations
+	Timeout   time.Duration         // Upper bound of every call; shorter client deadlines win
+
+	// Authenticators resolve the caller from the call metadata ("authorization",
+	// "x-api-key"); nil disables authentication and scope checks.
+	Authenticators []auth.Authenticator
+
+	// Limits throttles the entity methods per route group ("users", "companies",
+	// "brands") like the REST API; groups without an entry are not limited.
+	Limits map[string]Limits
+
+	// Health is registered as grpc.health.v1.Health when set (see WatchHealth).
+	Health *health.Server
+
+	// Reflection registers the server reflection service (grpcurl, grpcui).
+	Reflection bool
+}
+
+// Limits are the rate limit and bulkhead of one route group.
+type Limits struct {
+	Limiter  ratelimit.Limiter   // nil disables rate limiting
+	Rate     ratelimit.Rate      // applies per peer IP and per caller
+	Bulkhead *ratelimit.Bulkhead // of the datasource serving the group; nil for none
+}
+
+// scopes maps each entity method to the scope it requires.
+// Methods without an entry (health, reflection) are served without authentication.
+var scopes = map[string]string{
+	entitiesv1.UserService_CreateUser_FullMethodName:       "users:write",
+	entitiesv1.UserService_GetUser_FullMethodName:          "users:read",
+	entitiesv1.UserService_UpdateUser_FullMethodName:       "users:write",
+	entitiesv1.UserService_DeleteUser_FullMethodName:       "users:write",
+	entitiesv1.CompanyService_CreateCompany_FullMethodName: "companies:write",
+	entitiesv1.CompanyService_GetCompany_FullMethodName:    "companies:read",
+	entitiesv1.CompanyService_UpdateCompany_FullMethodName: "companies:write",
+	entitiesv1.CompanyService_DeleteCompany_FullMethodName: "companies:write",
+	entitiesv1.BrandService_CreateBrand_FullMethodName:     "brands:write",
+	entitiesv1.BrandService_GetBrand_FullMethodName:        "brands:read",
+	entitiesv1.BrandService_UpdateBrand_FullMethodName:     "brands:write",
+	entitiesv1.BrandService_DeleteBrand_FullMethodName:     "brands:write",
+}
+
+// NewServer builds a *grpc.Server with the interceptor chain (request ID, peer
+// rate limit, authentication, caller rate limit and bulkhead, then the call
+// timeout) and registers the services of s.
+func (s *Server) NewServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(requestID, s.limitPeer, s.authenticate, s.limitCaller, s.timeout),
+	}, opts...)
+	g := grpc.NewServer(opts...)
+	s.Register(g)
+	return g
+}
+
+// Register registers the entity services, and health and reflection when configured.
+func (s *Server) Register(g *grpc.Server) {
+	entitiesv1.RegisterUserServiceServer(g, &userServer{svc: s.Users})
+	entitiesv1.RegisterCompanyServiceServer(g, &companyServer{svc: s.Companies})
+	entitiesv1.RegisterBrandServiceServer(g, &brandServer{svc: s.Brands})
+	if s.Health != nil {
+		healthpb.RegisterHealthServer(g, s.Health)
+	}
+	if s.Reflection {
+		reflection.Register(g)
+	}
+}
+
+// statusError maps service errors to gRPC status codes, mirroring the HTTP
+// status mapping: validation errors to INVALID_ARGUMENT, missing entities to
+// NOT_FOUND, expired or cancelled contexts to DEADLINE_EXCEEDED / CANCELED and
+// everything else to INTERNAL.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// checkID rejects non-positive IDs like the REST API's path parameter check.
+func checkID(id int64) error {
+	if id <= 0 {
+		return status.Error(codes.InvalidArgument, "id must be a positive integer")
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package grpc
+
+import (
+	"context"
+
+	entitiesv1 "multi-datasource-go/api/proto/entities/v1"
+	"multi-datasource-go/internal/domain"
+)
+
+// toPBUser, toPBCompany and toPBBrand map domain entities onto the protobuf messages.
+func toPBUser(u *domain.User) *entitiesv1.User {
+	return &entitiesv1.User{Id: u.ID, Name: u.Name, LastName: u.LastName, CompanyId: u.CompanyID}
+}
+func toPBCompany(c *domain.Company) *entitiesv1.Company {
+	return &entitiesv1.Company{Id: c.ID, Name: c.Name}
+}
+func toPBBrand(b *domain.Brand) *entitiesv1.Brand {
+	return &entitiesv1.Brand{Id: b.ID, Name: b.Name, CompanyId: b.CompanyID}
+}
+
+// ===== Users (MySQL) =====
+
+// userServer implements entitiesv1.UserServiceServer on top of domain.UserService.
+type userServer struct {
+	entitiesv1.UnimplementedUserServiceServer
+	svc domain.UserService
+}
+
+// CreateUser validates and creates a user.
+func (s *userServer) CreateUser(ctx context.Context, req *entitiesv1.CreateUserRequest) (*entitiesv1.CreateUserResponse, error) {
+	id, err := s.svc.CreateUser(ctx, req.GetName(), req.GetLastName(), req.CompanyId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.CreateUserResponse{Id: id}, nil
+}
+
+// GetUser returns a user.
+func (s *userServer) GetUser(ctx context.Context, req *entitiesv1.GetUserRequest) (*entitiesv1.GetUserResponse, error) {
+	if err := checkID(req.GetId()); err != nil {
+		return nil, err
+	}
+	u, err := s.svc.GetUser(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.GetUserResponse{User: toPBUser(u)}, nil
+}
+
+// UpdateUser overwrites the names of a user.
+func (s *userServer) UpdateUser(ctx context.Context, req *entitiesv1.UpdateUserRequest) (*entitiesv1.UpdateUserResponse, error) {
+	if err := checkID(req.GetId()); err != nil {
+		return nil, err
+	}
+	u, err := s.svc.UpdateUser(ctx, req.GetId(), req.GetName(), req.GetLastName(), req.CompanyId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.UpdateUserResponse{User: toPBUser(u)}, nil
+}
+
+// DeleteUser removes a user.
+func (s *userServer) DeleteUser(ctx context.Context, req *entitiesv1.DeleteUserRequest) (*entitiesv1.DeleteUserResponse, error) {
+	if err := checkID(req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := s.svc.DeleteUser(ctx, req.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.DeleteUserResponse{}, nil
+}
+
+// ===== Companies (PostgreSQL) =====
+
+// companyServer implements entitiesv1.CompanyServiceServer on top of domain.CompanyService.
+type companyServer struct {
+	entitiesv1.UnimplementedCompanyServiceServer
+	svc domain.CompanyService
+}
+
+// CreateCompany validates and creates a company.
+func (s *companyServer) CreateCompany(ctx context.Context, req *entitiesv1.CreateCompanyRequest) (*entitiesv1.CreateCompanyResponse, error) {
+	id, err := s.svc.CreateCompany(ctx, req.GetName())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.CreateCompanyResponse{Id: id}, nil
+}
+
+// GetCompany returns a company.
+func (s *companyServer) GetCompany(ctx context.Context, req *entitiesv1.GetCompanyRequest) (*entitiesv1.GetCompanyResponse, error) {
+	if err := checkID(req.GetId()); err != nil {
+		return nil, err
+	}
+	c, err := s.svc.GetCompany(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.GetCompanyResponse{Company: toPBCompany(c)}, nil
+}
+
+// UpdateCompany renames a company.
+func (s *companyServer) UpdateCompany(ctx context.Context, req *entitiesv1.UpdateCompanyRequest) (*entitiesv1.UpdateCompanyResponse, error) {
+	if err := checkID(req.GetId()); err != nil {
+		return nil, err
+	}
+	c, err := s.svc.UpdateCompany(ctx, req.GetId(), req.GetName())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.UpdateCompanyResponse{Company: toPBCompany(c)}, nil
+}
+
+// DeleteCompany removes a company.
+func (s *companyServer) DeleteCompany(ctx context.Context, req *entitiesv1.DeleteCompanyRequest) (*entitiesv1.DeleteCompanyResponse, error) {
+	if err := checkID(req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := s.svc.DeleteCompany(ctx, req.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.DeleteCompanyResponse{}, nil
+}
+
+// ===== Brands (Oracle) =====
+
+// brandServer implements entitiesv1.BrandServiceServer on top of domain.BrandService.
+type brandServer struct {
+	entitiesv1.UnimplementedBrandServiceServer
+	svc domain.BrandService
+}
+
+// CreateBrand validates and creates a brand.
+func (s *brandServer) CreateBrand(ctx context.Context, req *entitiesv1.CreateBrandRequest) (*entitiesv1.CreateBrandResponse, error) {
+	id, err := s.svc.CreateBrand(ctx, req.GetName(), req.CompanyId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.CreateBrandResponse{Id: id}, nil
+}
+
+// GetBrand returns a brand.
+func (s *brandServer) GetBrand(ctx context.Context, req *entitiesv1.GetBrandRequest) (*entitiesv1.GetBrandResponse, error) {
+	if err := checkID(req.GetId()); err != nil {
+		return nil, err
+	}
+	b, err := s.svc.GetBrand(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.GetBrandResponse{Brand: toPBBrand(b)}, nil
+}
+
+// UpdateBrand renames a brand.
+func (s *brandServer) UpdateBrand(ctx context.Context, req *entitiesv1.UpdateBrandRequest) (*entitiesv1.UpdateBrandResponse, error) {
+	if err := checkID(req.GetId()); err != nil {
+		return nil, err
+	}
+	b, err := s.svc.UpdateBrand(ctx, req.GetId(), req.GetName(), req.CompanyId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.UpdateBrandResponse{Brand: toPBBrand(b)}, nil
+}
+
+// DeleteBrand removes a brand.
+func (s *brandServer) DeleteBrand(ctx context.Context, req *entitiesv1.DeleteBrandRequest) (*entitiesv1.DeleteBrandResponse, error) {
+	if err := checkID(req.GetId()); err != nil {
+		return nil, err
+	}
+	if err := s.svc.DeleteBrand(ctx, req.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+	return &entitiesv1.DeleteBrandResponse{}, nil
+}
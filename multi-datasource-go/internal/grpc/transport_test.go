@@ -0,0 +1,522 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	entitiesv1 "multi-datasource-go/api/proto/entities/v1"
+	"multi-datasource-go/internal/auth"
+	"multi-datasource-go/internal/domain"
+	"multi-datasource-go/internal/domain/domaintest"
+	apihttp "multi-datasource-go/internal/http"
+	"multi-datasource-go/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// The shared suite below runs the same scenarios against the REST handlers and
+// the gRPC services, both backed by the real domain services over in-memory
+// repositories, and requires both transports to report the same outcome.
+
+// Outcomes of a call, independent of the transport.
+const (
+	success         = "success"         // 2xx / OK
+	invalid         = "invalid"         // 400 / INVALID_ARGUMENT
+	notFound        = "not_found"       // 404 / NOT_FOUND
+	unauthenticated = "unauthenticated" // 401 / UNAUTHENTICATED
+	forbidden       = "forbidden"       // 403 / PERMISSION_DENIED
+	unavailable     = "unavailable"     // 503 / UNAVAILABLE
+)
+
+// keyAuthenticator accepts the API keys of the test callers. The key "outage"
+// fails as if the key store were down.
+type keyAuthenticator map[string][]string
+
+func (k keyAuthenticator) Authenticate(r *http.Request) (domain.Actor, error) {
+	key := r.Header.Get(auth.APIKeyHeader)
+	if key == "" {
+		return domain.Actor{}, auth.ErrNoCredentials
+	}
+	if key == "outage" {
+		return domain.Actor{}, errors.New("key store unavailable")
+	}
+	scopes, ok := k[key]
+	if !ok {
+		return domain.Actor{}, auth.ErrInvalidCredentials
+	}
+	return domain.Actor{ID: key, Method: "api_key", Scopes: scopes}, nil
+}
+
+// testKeys are the API keys known to keyAuthenticator.
+var testKeys = keyAuthenticator{
+	"admin":  {"*"},
+	"reader": {"users:read", "companies:read", "brands:read"},
+}
+
+// fields is an entity as the suite sees it: "name" and, for users, "lastName".
+type fields map[string]string
+
+// transport performs entity operations over one API.
+// entity is "users", "companies" or "brands"; key is the API key ("" = none).
+type transport interface {
+	create(t *testing.T, key, entity string, in fields) (int64, string)
+	get(t *testing.T, key, entity string, id int64) (fields, string)
+	update(t *testing.T, key, entity string, id int64, in fields) (fields, string)
+	remove(t *testing.T, key, entity string, id int64) string
+}
+
+// ===== REST =====
+
+// restTransport calls the gin handlers.
+type restTransport struct{ r *gin.Engine }
+
+func newREST(withAuth bool) *restTransport {
+	gin.SetMode(gin.TestMode)
+	users, companies, brands := domaintest.NewServices()
+	h := &apihttp.Handlers{Users: users, Companies: companies, Brands: brands, Timeout: time.Second}
+	if withAuth {
+		h.Auth = auth.Middleware(testKeys)
+	}
+	r := gin.New()
+	h.Register(r)
+	return &restTransport{r: r}
+}
+
+// restPaths maps entities to their collection path.
+var restPaths = map[string]string{"users": "/api/v1/users", "companies": "/api/v2/companies", "brands": "/api/v3/brands"}
+
+func (rt *restTransport) do(t *testing.T, key, method, path string, body any, out any) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(auth.APIKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	rt.r.ServeHTTP(rec, req)
+
+	switch rec.Code {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		if out != nil {
+			if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+				t.Fatalf("%s %s: %v", method, path, err)
+			}
+		}
+		return success
+	case http.StatusBadRequest:
+		return invalid
+	case http.StatusNotFound:
+		return notFound
+	case http.StatusUnauthorized:
+		return unauthenticated
+	case http.StatusForbidden:
+		return forbidden
+	case http.StatusServiceUnavailable:
+		return unavailable
+	}
+	t.Fatalf("%s %s: unexpected status %d: %s", method, path, rec.Code, rec.Body)
+	return ""
+}
+
+func (rt *restTransport) create(t *testing.T, key, entity string, in fields) (int64, string) {
+	var out struct{ Id int64 }
+	res := rt.do(t, key, http.MethodPost, restPaths[entity], in, &out)
+	return out.Id, res
+}
+
+func (rt *restTransport) get(t *testing.T, key, entity string, id int64) (fields, string) {
+	out := fields{}
+	res := rt.do(t, key, http.MethodGet, fmt.Sprintf("%s/%d", restPaths[entity], id), nil, &jsonFields{out})
+	return out, res
+}
+
+func (rt *restTransport) update(t *testing.T, key, entity string, id int64, in fields) (fields, string) {
+	out := fields{}
+	res := rt.do(t, key, http.MethodPut, fmt.Sprintf("%s/%d", restPaths[entity], id), in, &jsonFields{out})
+	return out, res
+}
+
+func (rt *restTransport) remove(t *testing.T, key, entity string, id int64) string {
+	return rt.do(t, key, http.MethodDelete, fmt.Sprintf("%s/%d", restPaths[entity], id), nil, nil)
+}
+
+// jsonFields decodes the string properties of an entity, skipping its numeric id.
+type jsonFields struct{ f fields }
+
+func (j *jsonFields) UnmarshalJSON(b []byte) error {
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			j.f[k] = s
+		}
+	}
+	return nil
+}
+
+// ===== gRPC =====
+
+// grpcTransport calls the gRPC services through an in-memory connection.
+type grpcTransport struct {
+	users     entitiesv1.UserServiceClient
+	companies entitiesv1.CompanyServiceClient
+	brands    entitiesv1.BrandServiceClient
+}
+
+// dial serves srv on an in-memory listener and returns a client connection to it.
+func dial(t *testing.T, srv *Server) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	g := srv.NewServer()
+	go func() { _ = g.Serve(lis) }()
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func newGRPC(t *testing.T, withAuth bool) *grpcTransport {
+	users, companies, brands := domaintest.NewServices()
+	srv := &Server{Users: users, Companies: companies, Brands: brands, Timeout: time.Second}
+	if withAuth {
+		srv.Authenticators = []auth.Authenticator{testKeys}
+	}
+	conn := dial(t, srv)
+	return &grpcTransport{
+		users:     entitiesv1.NewUserServiceClient(conn),
+		companies: entitiesv1.NewCompanyServiceClient(conn),
+		brands:    entitiesv1.NewBrandServiceClient(conn),
+	}
+}
+
+// outcome maps the status of a call onto the transport-neutral outcome.
+func outcome(t *testing.T, err error) string {
+	t.Helper()
+	switch status.Code(err) {
+	case codes.OK:
+		return success
+	case codes.InvalidArgument:
+		return invalid
+	case codes.NotFound:
+		return notFound
+	case codes.Unauthenticated:
+		return unauthenticated
+	case codes.PermissionDenied:
+		return forbidden
+	case codes.Unavailable:
+		return unavailable
+	}
+	t.Fatalf("unexpected status: %v", err)
+	return ""
+}
+
+func withKey(key string) context.Context {
+	if key == "" {
+		return context.Background()
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+}
+
+func (g *grpcTransport) create(t *testing.T, key, entity string, in fields) (int64, string) {
+	ctx := withKey(key)
+	var (
+		id  int64
+		err error
+	)
+	switch entity {
+	case "users":
+		var res *entitiesv1.CreateUserResponse
+		res, err = g.users.CreateUser(ctx, &entitiesv1.CreateUserRequest{Name: in["name"], LastName: in["lastName"]})
+		id = res.GetId()
+	case "companies":
+		var res *entitiesv1.CreateCompanyResponse
+		res, err = g.companies.CreateCompany(ctx, &entitiesv1.CreateCompanyRequest{Name: in["name"]})
+		id = res.GetId()
+	case "brands":
+		var res *entitiesv1.CreateBrandResponse
+		res, err = g.brands.CreateBrand(ctx, &entitiesv1.CreateBrandRequest{Name: in["name"]})
+		id = res.GetId()
+	}
+	return id, outcome(t, err)
+}
+
+func (g *grpcTransport) get(t *testing.T, key, entity string, id int64) (fields, string) {
+	ctx := withKey(key)
+	switch entity {
+	case "users":
+		res, err := g.users.GetUser(ctx, &entitiesv1.GetUserRequest{Id: id})
+		return userFields(res.GetUser()), outcome(t, err)
+	case "companies":
+		res, err := g.companies.GetCompany(ctx, &entitiesv1.GetCompanyRequest{Id: id})
+		return nameFields(res.GetCompany()), outcome(t, err)
+	default:
+		res, err := g.brands.GetBrand(ctx, &entitiesv1.GetBrandRequest{Id: id})
+		return nameFields(res.GetBrand()), outcome(t, err)
+	}
+}
+
+func (g *grpcTransport) update(t *testing.T, key, entity string, id int64, in fields) (fields, string) {
+	ctx := withKey(key)
+	switch entity {
+	case "users":
+		res, err := g.users.UpdateUser(ctx, &entitiesv1.UpdateUserRequest{Id: id, Name: in["name"], LastName: in["lastName"]})
+		return userFields(res.GetUser()), outcome(t, err)
+	case "companies":
+		res, err := g.companies.UpdateCompany(ctx, &entitiesv1.UpdateCompanyRequest{Id: id, Name: in["name"]})
+		return nameFields(res.GetCompany()), outcome(t, err)
+	default:
+		res, err := g.brands.UpdateBrand(ctx, &entitiesv1.UpdateBrandRequest{Id: id, Name: in["name"]})
+		return nameFields(res.GetBrand()), outcome(t, err)
+	}
+}
+
+func (g *grpcTransport) remove(t *testing.T, key, entity string, id int64) string {
+	ctx := withKey(key)
+	var err error
+	switch entity {
+	case "users":
+		_, err = g.users.DeleteUser(ctx, &entitiesv1.DeleteUserRequest{Id: id})
+	case "companies":
+		_, err = g.companies.DeleteCompany(ctx, &entitiesv1.DeleteCompanyRequest{Id: id})
+	case "brands":
+		_, err = g.brands.DeleteBrand(ctx, &entitiesv1.DeleteBrandRequest{Id: id})
+	}
+	return outcome(t, err)
+}
+
+func userFields(u *entitiesv1.User) fields {
+	if u == nil {
+		return fields{}
+	}
+	return fields{"name": u.GetName(), "lastName": u.GetLastName()}
+}
+
+// nameFields maps companies and brands, which only have a name.
+func nameFields(e interface{ GetName() string }) fields {
+	if e == nil || e.GetName() == "" {
+		return fields{}
+	}
+	return fields{"name": e.GetName()}
+}
+
+// ===== Shared suite =====
+
+// valid and blank return valid and blank input of an entity.
+func valid(entity, name string) fields {
+	if entity == "users" {
+		return fields{"name": name, "lastName": "Lovelace"}
+	}
+	return fields{"name": name}
+}
+
+func blank(entity string) fields { return valid(entity, " ") }
+
+// TestTransportsBehaveTheSame runs the CRUD lifecycle of every entity over both transports.
+func TestTransportsBehaveTheSame(t *testing.T) {
+	transports := map[string]func(t *testing.T) transport{
+		"rest": func(*testing.T) transport { return newREST(false) },
+		"grpc": func(t *testing.T) transport { return newGRPC(t, false) },
+	}
+	for name, newTransport := range transports {
+		for _, entity := range []string{"users", "companies", "brands"} {
+			t.Run(name+"/"+entity, func(t *testing.T) {
+				tr := newTransport(t)
+				expect := func(step, got, want string) {
+					t.Helper()
+					if got != want {
+						t.Errorf("%s: got %s, want %s", step, got, want)
+					}
+				}
+
+				_, res := tr.create(t, "", entity, blank(entity))
+				expect("create blank", res, invalid)
+
+				id, res := tr.create(t, "", entity, valid(entity, "Ada"))
+				expect("create", res, success)
+				if id <= 0 {
+					t.Fatalf("create: id %d", id)
+				}
+
+				got, res := tr.get(t, "", entity, id)
+				expect("get", res, success)
+				if want := valid(entity, "Ada"); fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("get: got %v, want %v", got, want)
+				}
+
+				_, res = tr.get(t, "", entity, id+100)
+				expect("get missing", res, notFound)
+				_, res = tr.get(t, "", entity, 0)
+				expect("get id 0", res, invalid)
+
+				got, res = tr.update(t, "", entity, id, valid(entity, "Grace"))
+				expect("update", res, success)
+				if want := valid(entity, "Grace"); fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Errorf("update: got %v, want %v", got, want)
+				}
+				_, res = tr.update(t, "", entity, id, blank(entity))
+				expect("update blank", res, invalid)
+				_, res = tr.update(t, "", entity, id+100, valid(entity, "Grace"))
+				expect("update missing", res, notFound)
+
+				expect("delete", tr.remove(t, "", entity, id), success)
+				expect("delete again", tr.remove(t, "", entity, id), notFound)
+				_, res = tr.get(t, "", entity, id)
+				expect("get deleted", res, notFound)
+			})
+		}
+	}
+}
+
+// TestTransportsEnforceTheSameScopes checks authentication and scope checks over both transports.
+func TestTransportsEnforceTheSameScopes(t *testing.T) {
+	transports := map[string]func(t *testing.T) transport{
+		"rest": func(*testing.T) transport { return newREST(true) },
+		"grpc": func(t *testing.T) transport { return newGRPC(t, true) },
+	}
+	for name, newTransport := range transports {
+		for _, entity := range []string{"users", "companies", "brands"} {
+			t.Run(name+"/"+entity, func(t *testing.T) {
+				tr := newTransport(t)
+				expect := func(step, got, want string) {
+					t.Helper()
+					if got != want {
+						t.Errorf("%s: got %s, want %s", step, got, want)
+					}
+				}
+
+				_, res := tr.create(t, "", entity, valid(entity, "Ada"))
+				expect("create without key", res, unauthenticated)
+				_, res = tr.create(t, "unknown", entity, valid(entity, "Ada"))
+				expect("create with unknown key", res, unauthenticated)
+				_, res = tr.create(t, "outage", entity, valid(entity, "Ada"))
+				expect("create during a key store outage", res, unavailable)
+				_, res = tr.create(t, "reader", entity, valid(entity, "Ada"))
+				expect("create as reader", res, forbidden)
+				id, res := tr.create(t, "admin", entity, valid(entity, "Ada"))
+				expect("create as admin", res, success)
+				_, res = tr.get(t, "reader", entity, id)
+				expect("get as reader", res, success)
+				expect("delete as reader", tr.remove(t, "reader", entity, id), forbidden)
+			})
+		}
+	}
+}
+
+// deadlineUsers records the deadline of the context reaching the service.
+type deadlineUsers struct {
+	domain.UserService
+	deadline chan time.Time
+}
+
+func (d deadlineUsers) GetUser(ctx context.Context, _ int64) (*domain.User, error) {
+	dl, _ := ctx.Deadline()
+	d.deadline <- dl
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// TestClientDeadlinePropagates checks that a client deadline shorter than the
+// server timeout reaches the service and is reported as DEADLINE_EXCEEDED.
+func TestClientDeadlinePropagates(t *testing.T) {
+	users := deadlineUsers{deadline: make(chan time.Time, 1)}
+	conn := dial(t, &Server{Users: users, Timeout: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	_, err := entitiesv1.NewUserServiceClient(conn).GetUser(ctx, &entitiesv1.GetUserRequest{Id: 1})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("got %v, want DeadlineExceeded", err)
+	}
+	got := <-users.deadline
+	if got.IsZero() || got.After(want.Add(50*time.Millisecond)) {
+		t.Errorf("service deadline %v, want about %v", got, want)
+	}
+}
+
+// blockingUsers holds GetUser calls until release is closed.
+type blockingUsers struct {
+	domain.UserService
+	started, release chan struct{}
+}
+
+func (b blockingUsers) GetUser(context.Context, int64) (*domain.User, error) {
+	b.started <- struct{}{}
+	<-b.release
+	return &domain.User{ID: 1}, nil
+}
+
+// TestLimits checks that calls with bad credentials are rate limited per peer,
+// and that the bulkhead sheds calls beyond its capacity.
+func TestLimits(t *testing.T) {
+	t.Run("rate limit before authentication", func(t *testing.T) {
+		conn := dial(t, &Server{
+			Authenticators: []auth.Authenticator{testKeys},
+			Limits: map[string]Limits{"users": {
+				Limiter: ratelimit.NewMemoryLimiter(),
+				Rate:    ratelimit.Rate{PerSecond: 0.001, Burst: 2},
+			}},
+		})
+		client := entitiesv1.NewUserServiceClient(conn)
+		for i, want := range []codes.Code{codes.Unauthenticated, codes.Unauthenticated, codes.ResourceExhausted} {
+			var header metadata.MD
+			_, err := client.GetUser(withKey("nope"), &entitiesv1.GetUserRequest{Id: 1}, grpc.Header(&header))
+			if status.Code(err) != want {
+				t.Fatalf("call %d: %v, want %v", i+1, err, want)
+			}
+			if want == codes.ResourceExhausted && len(header.Get("retry-after")) == 0 {
+				t.Error("no retry-after header")
+			}
+		}
+	})
+
+	t.Run("bulkhead", func(t *testing.T) {
+		users := blockingUsers{started: make(chan struct{}), release: make(chan struct{})}
+		conn := dial(t, &Server{
+			Users:  users,
+			Limits: map[string]Limits{"users": {Bulkhead: ratelimit.NewBulkhead("mysql", 1, 0, time.Second)}},
+		})
+		client := entitiesv1.NewUserServiceClient(conn)
+		first := make(chan error)
+		go func() {
+			_, err := client.GetUser(context.Background(), &entitiesv1.GetUserRequest{Id: 1})
+			first <- err
+		}()
+		<-users.started
+		if _, err := client.GetUser(context.Background(), &entitiesv1.GetUserRequest{Id: 1}); status.Code(err) != codes.Unavailable {
+			t.Errorf("call beyond capacity: %v, want Unavailable", err)
+		}
+		close(users.release)
+		if err := <-first; err != nil {
+			t.Fatalf("first call: %v", err)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"multi-datasource-go/internal/audit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandlers exposes the audit trail of entity changes under /audit.
+type AuditHandlers struct {
+	Store      *audit.Store      // Persistence for audit entries
+	Timeout    time.Duration     // Timeout duration applied to each incoming request
+	Auth       gin.HandlerFunc   // Authentication middleware; nil disables auth and scope checks
+	PreAuth    []gin.HandlerFunc // Middleware run before authentication (per-IP rate limits)
+	Middleware []gin.HandlerFunc // Extra middleware run after authentication (per-caller rate limits, bulkheads)
+}
+
+// Register registers the audit routes. When Auth is set, reads require "audit:read".
+func (h *AuditHandlers) Register(r *gin.Engine) {
+	g := r.Group("/audit", groupMiddleware(h.PreAuth, h.Auth, h.Middleware)...)
+	g.GET("", requireScope(h.Auth, "audit:read"), h.listEntries)
+}
+
+// listEntries handles GET /audit?entityType=user&entityId=1&actor=ci&from=...&to=...&limit=100.
+// from and to are RFC 3339 timestamps; entries are returned newest first.
+func (h *AuditHandlers) listEntries(c *gin.Context) {
+	f := audit.Filter{EntityType: c.Query("entityType"), Actor: c.Query("actor")}
+	switch f.EntityType {
+	case "", "user", "company", "brand":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "entityType must be user, company or brand"})
+		return
+	}
+	if s := c.Query("entityId"); s != "" {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || id <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "entityId must be a positive integer"})
+			return
+		}
+		f.EntityID = id
+	}
+	for _, p := range []struct {
+		name string
+		dst  *time.Time
+	}{{"from", &f.From}, {"to", &f.To}} {
+		if s := c.Query(p.name); s != "" {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": p.name + " must be an RFC 3339 timestamp"})
+				return
+			}
+			*p.dst = t
+		}
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > 1000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 1000"})
+		return
+	}
+	f.Limit = limit
+
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	out, err := h.Store.List(ctx, f)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if out == nil {
+		out = []audit.Entry{}
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// ctx creates a derived context with the configured timeout.
+func (h *AuditHandlers) ctx(c *gin.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.Request.Context(), h.Timeout)
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"slices"
+
+	"multi-datasource-go/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// next is a pass-through middleware used when authentication is disabled.
+func next(c *gin.Context) { c.Next() }
+
+// authenticate returns the authentication middleware for a route group,
+// or a pass-through when authentication is disabled (authn is nil).
+func authenticate(authn gin.HandlerFunc) gin.HandlerFunc {
+	if authn == nil {
+		return next
+	}
+	return authn
+}
+
+// groupMiddleware returns the middleware chain of a route group: the preAuth
+// middleware (per-IP rate limits), which also limits requests with bad
+// credentials, then authentication, then the extra middleware (per-caller
+// rate limits, bulkheads), which can thus key on the authenticated caller.
+func groupMiddleware(preAuth []gin.HandlerFunc, authn gin.HandlerFunc, extra []gin.HandlerFunc) []gin.HandlerFunc {
+	mw := append(slices.Clone(preAuth), authenticate(authn))
+	return append(mw, extra...)
+}
+
+// requireScope returns a middleware rejecting callers without the scope with 403,
+// or a pass-through when authentication is disabled (authn is nil).
+func requireScope(authn gin.HandlerFunc, scope string) gin.HandlerFunc {
+	if authn == nil {
+		return next
+	}
+	return auth.RequireScope(scope)
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"net/http"
+
+	"multi-datasource-go/internal/cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheHandlers exposes the hit/miss counters of the entity caches.
+type CacheHandlers struct {
+	Caches []cache.StatsProvider // One provider per cached entity
+	Auth   gin.HandlerFunc       // Authentication middleware; nil disables auth and scope checks
+}
+
+// Register registers GET /api/cache/stats. When Auth is set, it requires "cache:read".
+func (h *CacheHandlers) Register(r *gin.Engine) {
+	g := r.Group("/api/cache", authenticate(h.Auth))
+	g.GET("/stats", requireScope(h.Auth, "cache:read"), h.stats)
+}
+
+// stats handles GET /api/cache/stats.
+func (h *CacheHandlers) stats(c *gin.Context) {
+	out := make([]cache.Stats, 0, len(h.Caches))
+	for _, p := range h.Caches {
+		out = append(out, p.Stats())
+	}
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// NewEngine returns the Gin engine of the API with recovery and request IDs.
+// The client IP, which per-IP rate limits key on, is taken from
+// X-Forwarded-For and X-Real-IP only when the connection comes from one of
+// trustedProxies (IPs or CIDRs); with none, headers are ignored, so clients
+// cannot pick a new IP, and thus a fresh rate limit bucket, per request.
+func NewEngine(trustedProxies []string) (*gin.Engine, error) {
+	r := gin.New()
+	if len(trustedProxies) == 0 {
+		trustedProxies = nil
+	}
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+	// Add recovery middleware; consider adding gin.Logger() for request logs.
+	// RequestID tags every request so audit entries can be correlated with it.
+	r.Use(gin.Recovery(), RequestID())
+	return r, nil
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"multi-datasource-go/internal/graphql"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandlers exposes the GraphQL schema over users, companies and brands under /graphql.
+type GraphQLHandlers struct {
+	Server     *graphql.Server   // Executes GraphQL requests against the services
+	Timeout    time.Duration     // Timeout duration applied to each incoming request
+	Auth       gin.HandlerFunc   // Authentication middleware; nil disables auth (scopes are checked by the resolvers)
+	PreAuth    []gin.HandlerFunc // Middleware run before authentication (per-IP rate limits)
+	Middleware []gin.HandlerFunc // Extra middleware run after authentication (per-caller rate limits)
+}
+
+// Register registers POST /graphql and, for queries in the URL, GET /graphql.
+// Any authenticated caller may query; fields require the <entity>:read scope
+// of the entity they return and fail individually without it.
+func (h *GraphQLHandlers) Register(r *gin.Engine) {
+	g := r.Group("/graphql", groupMiddleware(h.PreAuth, h.Auth, h.Middleware)...)
+	g.POST("", h.post)
+	g.GET("", h.get)
+}
+
+// post handles POST /graphql with a JSON body {"query", "operationName", "variables"}.
+func (h *GraphQLHandlers) post(c *gin.Context) {
+	var req graphql.Request
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": []gin.H{{"message": err.Error()}}})
+		return
+	}
+	h.execute(c, req)
+}
+
+// get handles GET /graphql?query=...&operationName=...&variables={...}.
+func (h *GraphQLHandlers) get(c *gin.Context) {
+	req := graphql.Request{Query: c.Query("query"), OperationName: c.Query("operationName")}
+	if v := c.Query("variables"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"errors": []gin.H{{"message": "variables must be a JSON object"}}})
+			return
+		}
+	}
+	h.execute(c, req)
+}
+
+// execute runs the request. Like most GraphQL servers it answers 200 even when
+// the response carries errors; only requests that are not GraphQL get a 400.
+func (h *GraphQLHandlers) execute(c *gin.Context, req graphql.Request) {
+	if req.Query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": []gin.H{{"message": "query is required"}}})
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.Timeout)
+	defer cancel()
+	c.JSON(http.StatusOK, h.Server.Execute(ctx, req))
+}
@@ -2,37 +2,59 @@ package http
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
+	"multi-datasource-go/api"
 	"multi-datasource-go/internal/domain"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Handlers groups all HTTP handler dependencies, including
-// repositories for users, companies, and brands.
+// services for users, companies, and brands.
 // It also holds a configurable request timeout used for
-// per-request context control.
+// per-request context control and the optional authentication middleware.
 type Handlers struct {
-	Users     domain.UserRepo    // Repository for MySQL-backed user operations
-	Companies domain.CompanyRepo // Repository for PostgreSQL-backed company operations
-	Brands    domain.BrandRepo   // Repository for Oracle-backed brand operations
-	Timeout   time.Duration      // Timeout duration applied to each incoming request
+	Users     domain.UserService    // Service for MySQL-backed user operations
+	Companies domain.CompanyService // Service for PostgreSQL-backed company operations
+	Brands    domain.BrandService   // Service for Oracle-backed brand operations
+	Timeout   time.Duration         // Timeout duration applied to each incoming request
+	Auth      gin.HandlerFunc       // Authentication middleware (auth.Middleware); nil disables auth and scope checks
+
+	// PreAuth holds middleware per route group ("users", "companies", "brands") run
+	// before authentication, e.g. per-IP rate limits.
+	PreAuth map[string][]gin.HandlerFunc
+
+	// Middleware holds extra middleware per route group, e.g. per-caller rate
+	// limits and datasource bulkheads. It runs after authentication.
+	Middleware map[string][]gin.HandlerFunc
 }
 
 // Register registers all versioned HTTP routes handled by this service.
 // It organizes endpoints under /api/v1, /api/v2, and /api/v3 prefixes
 // to reflect the data source each route interacts with.
+// When Auth is set, every group requires an authenticated caller and
+// each route requires the <entity>:<read|write> scope of its group.
 func (h *Handlers) Register(r *gin.Engine) {
-	v1 := r.Group("/api/v1")
-	v1.POST("/users", h.createUser)
+	v1 := r.Group("/api/v1", groupMiddleware(h.PreAuth["users"], h.Auth, h.Middleware["users"])...)
+	v1.POST("/users", requireScope(h.Auth, "users:write"), h.createUser)
+	v1.GET("/users/:id", requireScope(h.Auth, "users:read"), h.getUser)
+	v1.PUT("/users/:id", requireScope(h.Auth, "users:write"), h.updateUser)
+	v1.DELETE("/users/:id", requireScope(h.Auth, "users:write"), h.deleteUser)
 
-	v2 := r.Group("/api/v2")
-	v2.POST("/companies", h.createCompany)
+	v2 := r.Group("/api/v2", groupMiddleware(h.PreAuth["companies"], h.Auth, h.Middleware["companies"])...)
+	v2.POST("/companies", requireScope(h.Auth, "companies:write"), h.createCompany)
+	v2.GET("/companies/:id", requireScope(h.Auth, "companies:read"), h.getCompany)
+	v2.PUT("/companies/:id", requireScope(h.Auth, "companies:write"), h.updateCompany)
+	v2.DELETE("/companies/:id", requireScope(h.Auth, "companies:write"), h.deleteCompany)
 
-	v3 := r.Group("/api/v3")
-	v3.POST("/brands", h.createBrand)
+	v3 := r.Group("/api/v3", groupMiddleware(h.PreAuth["brands"], h.Auth, h.Middleware["brands"])...)
+	v3.POST("/brands", requireScope(h.Auth, "brands:write"), h.createBrand)
+	v3.GET("/brands/:id", requireScope(h.Auth, "brands:read"), h.getBrand)
+	v3.PUT("/brands/:id", requireScope(h.Auth, "brands:write"), h.updateBrand)
+	v3.DELETE("/brands/:id", requireScope(h.Auth, "brands:write"), h.deleteBrand)
 }
 
 // ctx creates a derived context with the configured timeout.
@@ -42,59 +64,244 @@ func (h *Handlers) ctx(c *gin.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(c.Request.Context(), h.Timeout)
 }
 
+// serviceError maps service errors to HTTP status codes:
+// validation errors to 400, missing entities to 404 and everything else to 500.
+func serviceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// toAPIUser, toAPICompany and toAPIBrand map domain entities onto the
+// response types generated from api/openapi.yaml.
+func toAPIUser(u *domain.User) api.User {
+	return api.User{Id: u.ID, Name: u.Name, LastName: u.LastName, CompanyId: u.CompanyID}
+}
+func toAPICompany(c *domain.Company) api.Company { return api.Company{Id: c.ID, Name: c.Name} }
+func toAPIBrand(b *domain.Brand) api.Brand {
+	return api.Brand{Id: b.ID, Name: b.Name, CompanyId: b.CompanyID}
+}
+
+// ===== Users (MySQL) =====
+
 // createUser handles POST /api/v1/users requests.
-// It binds the request body to a domain.User, validates it,
-// and calls the MySQL repository to persist the record.
+// It binds the request body to an api.UserInput and calls the
+// user service, which validates and persists the record.
 func (h *Handlers) createUser(c *gin.Context) {
-	var u domain.User
-	if err := c.BindJSON(&u); err != nil {
+	var in api.UserInput
+	if err := c.BindJSON(&in); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	ctx, cancel := h.ctx(c)
 	defer cancel()
-	id, err := h.Users.Create(ctx, &u)
+	id, err := h.Users.CreateUser(ctx, in.Name, in.LastName, in.CompanyId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		serviceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, api.CreatedId{Id: id})
+}
+
+// getUser handles GET /api/v1/users/:id requests.
+func (h *Handlers) getUser(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	u, err := h.Users.GetUser(ctx, id)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPIUser(u))
+}
+
+// updateUser handles PUT /api/v1/users/:id requests.
+func (h *Handlers) updateUser(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var in api.UserInput
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	updated, err := h.Users.UpdateUser(ctx, id, in.Name, in.LastName, in.CompanyId)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPIUser(updated))
+}
+
+// deleteUser handles DELETE /api/v1/users/:id requests.
+func (h *Handlers) deleteUser(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	if err := h.Users.DeleteUser(ctx, id); err != nil {
+		serviceError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"id": id})
+	c.Status(http.StatusNoContent)
 }
 
+// ===== Companies (PostgreSQL) =====
+
 // createCompany handles POST /api/v2/companies requests.
-// It binds incoming JSON to a domain.Company object and uses
-// the PostgreSQL repository to insert a new record.
+// It binds incoming JSON to an api.CompanyInput and uses
+// the company service to insert a new record.
 func (h *Handlers) createCompany(c *gin.Context) {
-	var m domain.Company
-	if err := c.BindJSON(&m); err != nil {
+	var in api.CompanyInput
+	if err := c.BindJSON(&in); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	ctx, cancel := h.ctx(c)
 	defer cancel()
-	id, err := h.Companies.Create(ctx, &m)
+	id, err := h.Companies.CreateCompany(ctx, in.Name)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		serviceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, api.CreatedId{Id: id})
+}
+
+// getCompany handles GET /api/v2/companies/:id requests.
+func (h *Handlers) getCompany(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	m, err := h.Companies.GetCompany(ctx, id)
+	if err != nil {
+		serviceError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"id": id})
+	c.JSON(http.StatusOK, toAPICompany(m))
 }
 
+// updateCompany handles PUT /api/v2/companies/:id requests.
+func (h *Handlers) updateCompany(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var in api.CompanyInput
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	updated, err := h.Companies.UpdateCompany(ctx, id, in.Name)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPICompany(updated))
+}
+
+// deleteCompany handles DELETE /api/v2/companies/:id requests.
+func (h *Handlers) deleteCompany(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	if err := h.Companies.DeleteCompany(ctx, id); err != nil {
+		serviceError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ===== Brands (Oracle) =====
+
 // createBrand handles POST /api/v3/brands requests.
-// It binds the JSON payload to a domain.Brand and
-// calls the Oracle repository to persist the data.
+// It binds the JSON payload to an api.BrandInput and
+// calls the brand service to persist the data.
 func (h *Handlers) createBrand(c *gin.Context) {
-	var b domain.Brand
-	if err := c.BindJSON(&b); err != nil {
+	var in api.BrandInput
+	if err := c.BindJSON(&in); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	ctx, cancel := h.ctx(c)
 	defer cancel()
-	id, err := h.Brands.Create(ctx, &b)
+	id, err := h.Brands.CreateBrand(ctx, in.Name, in.CompanyId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		serviceError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, api.CreatedId{Id: id})
+}
+
+// getBrand handles GET /api/v3/brands/:id requests.
+func (h *Handlers) getBrand(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	b, err := h.Brands.GetBrand(ctx, id)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPIBrand(b))
+}
+
+// updateBrand handles PUT /api/v3/brands/:id requests.
+func (h *Handlers) updateBrand(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var in api.BrandInput
+	if err := c.BindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	updated, err := h.Brands.UpdateBrand(ctx, id, in.Name, in.CompanyId)
+	if err != nil {
+		serviceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toAPIBrand(updated))
+}
+
+// deleteBrand handles DELETE /api/v3/brands/:id requests.
+func (h *Handlers) deleteBrand(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	if err := h.Brands.DeleteBrand(ctx, id); err != nil {
+		serviceError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"id": id})
+	c.Status(http.StatusNoContent)
 }
@@ -0,0 +1,299 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"multi-datasource-go/internal/auth"
+	"multi-datasource-go/internal/db/dbtest"
+	"multi-datasource-go/internal/domain"
+	"multi-datasource-go/internal/domain/domaintest"
+	"multi-datasource-go/internal/ratelimit"
+	"multi-datasource-go/internal/repo"
+
+	"github.com/gin-gonic/gin"
+)
+
+// These tests drive the engine built by Handlers.Register end to end: the real
+// domain services over the MySQL user and Oracle brand repositories running on
+// the SQLite stand-in, and over the in-memory company fake (pgx has no stand-in).
+
+// newEngine returns an engine serving fresh, empty datasources.
+// With authn set, every route requires credentials.
+func newEngine(t *testing.T, authn gin.HandlerFunc) *gin.Engine {
+	h := newHandlers(t, authn)
+	r := gin.New()
+	h.Register(r)
+	return r
+}
+
+// newHandlers returns the handlers newEngine registers.
+func newHandlers(t *testing.T, authn gin.HandlerFunc) *Handlers {
+	gin.SetMode(gin.TestMode)
+	dbx := dbtest.Open(t)
+	return &Handlers{
+		Users:     domain.NewUserService(repo.NewMySQLUserRepo(dbx), time.Second),
+		Companies: domain.NewCompanyService(domaintest.NewCompanyRepo(), time.Second),
+		Brands:    domain.NewBrandService(repo.NewOracleBrandRepo(dbx), time.Second),
+		Timeout:   time.Second,
+		Auth:      authn,
+	}
+}
+
+// call sends a request with an optional JSON body and API key and decodes the
+// JSON response into out, if given. It returns the status code.
+func call(t *testing.T, r *gin.Engine, method, path, key string, body, out any) int {
+	t.Helper()
+	var buf bytes.Buffer
+	switch b := body.(type) {
+	case nil:
+	case string: // raw, possibly malformed, JSON
+		buf.WriteString(b)
+	default:
+		if err := json.NewEncoder(&buf).Encode(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(auth.APIKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if out != nil && rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decode %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestEntityLifecycle(t *testing.T) {
+	for _, tc := range []struct {
+		path           string
+		create, update map[string]any
+	}{
+		{"/api/v1/users",
+			map[string]any{"name": "Ada", "lastName": "Byron", "companyId": 1},
+			map[string]any{"name": "Ada", "lastName": "Lovelace"}},
+		{"/api/v2/companies",
+			map[string]any{"name": "Acme"},
+			map[string]any{"name": "Acme Corp"}},
+		{"/api/v3/brands",
+			map[string]any{"name": "Road Runner"},
+			map[string]any{"name": "Coyote", "companyId": 2}},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			r := newEngine(t, nil)
+
+			var created struct{ ID int64 }
+			if code := call(t, r, http.MethodPost, tc.path, "", tc.create, &created); code != http.StatusCreated {
+				t.Fatalf("create: status %d, want 201", code)
+			}
+			item := tc.path + "/" + jsonString(created.ID)
+
+			var got map[string]any
+			if code := call(t, r, http.MethodGet, item, "", nil, &got); code != http.StatusOK {
+				t.Fatalf("get: status %d, want 200", code)
+			}
+			assertFields(t, got, created.ID, tc.create)
+
+			got = nil
+			if code := call(t, r, http.MethodPut, item, "", tc.update, &got); code != http.StatusOK {
+				t.Fatalf("update: status %d, want 200", code)
+			}
+			assertFields(t, got, created.ID, tc.update)
+			got = nil
+			call(t, r, http.MethodGet, item, "", nil, &got)
+			assertFields(t, got, created.ID, tc.update)
+
+			if code := call(t, r, http.MethodDelete, item, "", nil, nil); code != http.StatusNoContent {
+				t.Fatalf("delete: status %d, want 204", code)
+			}
+			for _, method := range []string{http.MethodGet, http.MethodDelete} {
+				if code := call(t, r, method, item, "", nil, nil); code != http.StatusNotFound {
+					t.Fatalf("%s after delete: status %d, want 404", method, code)
+				}
+			}
+			if code := call(t, r, http.MethodPut, item, "", tc.update, nil); code != http.StatusNotFound {
+				t.Fatalf("update after delete: status %d, want 404", code)
+			}
+		})
+	}
+}
+
+func TestInvalidRequests(t *testing.T) {
+	r := newEngine(t, nil)
+	for _, tc := range []struct {
+		name, method, path string
+		body               any
+	}{
+		{"blank name", http.MethodPost, "/api/v1/users", map[string]any{"name": " ", "lastName": "x"}},
+		{"missing last name", http.MethodPost, "/api/v1/users", map[string]any{"name": "Ada"}},
+		{"malformed JSON", http.MethodPost, "/api/v1/users", `{"name":`},
+		{"non-positive company", http.MethodPost, "/api/v1/users", map[string]any{"name": "a", "lastName": "b", "companyId": 0}},
+		{"blank company name", http.MethodPost, "/api/v2/companies", map[string]any{"name": ""}},
+		{"blank brand name", http.MethodPut, "/api/v3/brands/1", map[string]any{"name": ""}},
+		{"non-numeric id", http.MethodGet, "/api/v1/users/abc", nil},
+		{"zero id", http.MethodDelete, "/api/v2/companies/0", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var body struct{ Error string }
+			if code := call(t, r, tc.method, tc.path, "", tc.body, &body); code != http.StatusBadRequest {
+				t.Fatalf("status %d, want 400", code)
+			}
+			if body.Error == "" {
+				t.Fatal(`response has no "error" message`)
+			}
+		})
+	}
+}
+
+// testAuthenticator accepts a fixed set of API keys. The key "outage" fails
+// as if the key store were down.
+type testAuthenticator map[string][]string
+
+func (k testAuthenticator) Authenticate(r *http.Request) (domain.Actor, error) {
+	key := r.Header.Get(auth.APIKeyHeader)
+	if key == "" {
+		return domain.Actor{}, auth.ErrNoCredentials
+	}
+	if key == "outage" {
+		return domain.Actor{}, errors.New("key store unavailable")
+	}
+	scopes, ok := k[key]
+	if !ok {
+		return domain.Actor{}, auth.ErrInvalidCredentials
+	}
+	return domain.Actor{ID: key, Method: "api_key", Scopes: scopes}, nil
+}
+
+func TestAuthorization(t *testing.T) {
+	r := newEngine(t, auth.Middleware(testAuthenticator{
+		"writer": {"users:read", "users:write"},
+		"reader": {"users:read"},
+	}))
+	user := map[string]any{"name": "Ada", "lastName": "Lovelace"}
+
+	for _, tc := range []struct {
+		name, method, path, key string
+		want                    int
+	}{
+		{"no credentials", http.MethodGet, "/api/v1/users/1", "", http.StatusUnauthorized},
+		{"unknown key", http.MethodGet, "/api/v1/users/1", "nope", http.StatusUnauthorized},
+		{"key store outage", http.MethodGet, "/api/v1/users/1", "outage", http.StatusServiceUnavailable},
+		{"write scope", http.MethodPost, "/api/v1/users", "writer", http.StatusCreated},
+		{"read scope", http.MethodGet, "/api/v1/users/1", "reader", http.StatusOK},
+		{"missing write scope", http.MethodPost, "/api/v1/users", "reader", http.StatusForbidden},
+		{"scope of another entity", http.MethodGet, "/api/v2/companies/1", "writer", http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var body any
+			if tc.method == http.MethodPost {
+				body = user
+			}
+			if code := call(t, r, tc.method, tc.path, tc.key, body, nil); code != tc.want {
+				t.Fatalf("status %d, want %d", code, tc.want)
+			}
+		})
+	}
+}
+
+// TestRateLimitIgnoresSpoofedForwardedFor checks that clients cannot escape the
+// per-IP limit by sending a new X-Forwarded-For with each request, unless the
+// header comes from a trusted proxy.
+func TestRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		proxies []string
+		want    []int
+	}{
+		{"no trusted proxies", nil, []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}},
+		{"peer is a trusted proxy", []string{"192.0.2.0/24"}, []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusUnauthorized}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := newHandlers(t, auth.Middleware(testAuthenticator{}))
+			h.PreAuth = map[string][]gin.HandlerFunc{"users": {
+				ratelimit.ClientIPMiddleware(ratelimit.NewMemoryLimiter(), "users", ratelimit.Rate{PerSecond: 0.001, Burst: 2}),
+			}}
+			r, err := NewEngine(tc.proxies)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h.Register(r)
+
+			for i, want := range tc.want {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil) // from 192.0.2.1
+				req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i+1))
+				req.Header.Set(auth.APIKeyHeader, "guess")
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+				if w.Code != want {
+					t.Fatalf("request %d: status %d, want %d", i+1, w.Code, want)
+				}
+			}
+		})
+	}
+}
+
+// TestRateLimitBeforeAuthentication checks that requests with bad credentials
+// are limited per client IP, while authenticated callers get their own bucket.
+func TestRateLimitBeforeAuthentication(t *testing.T) {
+	l := ratelimit.NewMemoryLimiter()
+	ipRate := ratelimit.Rate{PerSecond: 0.001, Burst: 2}
+	h := newHandlers(t, auth.Middleware(testAuthenticator{"reader": {"users:read"}}))
+	h.PreAuth = map[string][]gin.HandlerFunc{"users": {ratelimit.ClientIPMiddleware(l, "users", ipRate)}}
+	h.Middleware = map[string][]gin.HandlerFunc{"users": {ratelimit.Middleware(l, "users", ratelimit.Rate{PerSecond: 0.001, Burst: 1})}}
+	r := gin.New()
+	h.Register(r)
+
+	for i, want := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		if code := call(t, r, http.MethodGet, "/api/v1/users/1", "nope", nil, nil); code != want {
+			t.Fatalf("bad key, request %d: status %d, want %d", i+1, code, want)
+		}
+	}
+
+	// The IP bucket is spent, so a valid key from another IP shows the per-caller bucket.
+	for i, want := range []int{http.StatusNotFound, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/1", nil)
+		req.RemoteAddr = "198.51.100.1:1234" // httptest uses 192.0.2.1
+		req.Header.Set(auth.APIKeyHeader, "reader")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Fatalf("valid key, request %d: status %d, want %d", i+1, w.Code, want)
+		}
+	}
+}
+
+// jsonString formats v the way it appears in JSON.
+func jsonString(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// assertFields checks that entity has the ID and the input fields, with
+// absent companyId meaning no company.
+func assertFields(t *testing.T, entity map[string]any, id int64, in map[string]any) {
+	t.Helper()
+	if jsonString(entity["id"]) != jsonString(id) {
+		t.Errorf("id = %v, want %d", entity["id"], id)
+	}
+	for k, want := range in {
+		if jsonString(entity[k]) != jsonString(want) {
+			t.Errorf("%s = %v, want %v", k, entity[k], want)
+		}
+	}
+	if _, ok := in["companyId"]; !ok {
+		if v, ok := entity["companyId"]; ok {
+			t.Errorf("companyId = %v, want none", v)
+		}
+	}
+}
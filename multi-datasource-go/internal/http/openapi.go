@@ -0,0 +1,106 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIHandlers serves the API contract and a browsable docs UI.
+type OpenAPIHandlers struct {
+	Spec []byte // openapi.yaml as embedded by package api
+}
+
+// Register registers GET /openapi.yaml and GET /docs. Both are public.
+func (h *OpenAPIHandlers) Register(r *gin.Engine) {
+	r.GET("/openapi.yaml", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/yaml", h.Spec)
+	})
+	r.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsPage))
+	})
+}
+
+// docsPage renders /openapi.yaml with Swagger UI (loaded from a CDN).
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Multi-Datasource API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.yaml", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>`
+
+// OpenAPIValidator returns a middleware validating requests against the spec:
+// path and query parameters and JSON bodies. Invalid requests are rejected
+// with 400 before reaching the handlers. Requests for routes the spec does not
+// describe are passed through. Authentication is left to the auth middleware.
+func OpenAPIValidator(doc *openapi3.T) (gin.HandlerFunc, error) {
+	doc.Servers = nil // match on paths only, whatever host the server is reached on
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, err
+	}
+	opts := &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+			c.Next()
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		in := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    opts,
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), in); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": validationMessage(err)})
+			return
+		}
+		c.Next()
+	}, nil
+}
+
+// validationMessage shortens kin-openapi errors to the invalid part and the
+// reason, without the schema dump they carry by default.
+func validationMessage(err error) string {
+	var re *openapi3filter.RequestError
+	if !errors.As(err, &re) {
+		return err.Error()
+	}
+	var se *openapi3.SchemaError
+	if !errors.As(re.Err, &se) {
+		return re.Error()
+	}
+	msg := se.Reason
+	if ptr := se.JSONPointer(); len(ptr) > 0 {
+		msg = `field "` + strings.Join(ptr, ".") + `": ` + msg
+	}
+	switch {
+	case re.Parameter != nil:
+		return `parameter "` + re.Parameter.Name + `": ` + msg
+	case re.RequestBody != nil:
+		return "request body: " + msg
+	default:
+		return msg
+	}
+}
@@ -0,0 +1,226 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"multi-datasource-go/api"
+	"multi-datasource-go/internal/domain"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// missingID is the entity ID the fake services report as not found.
+const missingID = 404
+
+// fakeUsers, fakeCompanies and fakeBrands answer with fixed entities,
+// so handler responses can be checked against the spec without a database.
+type fakeUsers struct{}
+
+func (fakeUsers) CreateUser(_ context.Context, name, _ string, _ *int64) (int64, error) {
+	if name == "" {
+		return 0, domain.ErrInvalidInput
+	}
+	return 1, nil
+}
+func (fakeUsers) GetUser(_ context.Context, id int64) (*domain.User, error) {
+	if id == missingID {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.User{ID: id, Name: "Ada", LastName: "Lovelace"}, nil
+}
+func (fakeUsers) GetUsers(context.Context, []int64) ([]domain.User, error) { return nil, nil }
+func (f fakeUsers) UpdateUser(ctx context.Context, id int64, name, lastName string, companyID *int64) (*domain.User, error) {
+	if _, err := f.GetUser(ctx, id); err != nil {
+		return nil, err
+	}
+	return &domain.User{ID: id, Name: name, LastName: lastName, CompanyID: companyID}, nil
+}
+func (f fakeUsers) DeleteUser(ctx context.Context, id int64) error {
+	_, err := f.GetUser(ctx, id)
+	return err
+}
+
+type fakeCompanies struct{}
+
+func (fakeCompanies) CreateCompany(context.Context, string) (int64, error) { return 1, nil }
+func (fakeCompanies) GetCompany(_ context.Context, id int64) (*domain.Company, error) {
+	if id == missingID {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.Company{ID: id, Name: "Acme"}, nil
+}
+func (fakeCompanies) GetCompanies(context.Context, []int64) ([]domain.Company, error) {
+	return nil, nil
+}
+func (f fakeCompanies) UpdateCompany(ctx context.Context, id int64, name string) (*domain.Company, error) {
+	if _, err := f.GetCompany(ctx, id); err != nil {
+		return nil, err
+	}
+	return &domain.Company{ID: id, Name: name}, nil
+}
+func (f fakeCompanies) DeleteCompany(ctx context.Context, id int64) error {
+	_, err := f.GetCompany(ctx, id)
+	return err
+}
+
+type fakeBrands struct{}
+
+func (fakeBrands) CreateBrand(context.Context, string, *int64) (int64, error) { return 1, nil }
+func (fakeBrands) GetBrand(_ context.Context, id int64) (*domain.Brand, error) {
+	if id == missingID {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.Brand{ID: id, Name: "Zeta"}, nil
+}
+func (fakeBrands) ListBrandsByCompanies(context.Context, []int64) ([]domain.Brand, error) {
+	return nil, nil
+}
+func (f fakeBrands) UpdateBrand(ctx context.Context, id int64, name string, companyID *int64) (*domain.Brand, error) {
+	if _, err := f.GetBrand(ctx, id); err != nil {
+		return nil, err
+	}
+	return &domain.Brand{ID: id, Name: name, CompanyID: companyID}, nil
+}
+func (f fakeBrands) DeleteBrand(ctx context.Context, id int64) error {
+	_, err := f.GetBrand(ctx, id)
+	return err
+}
+
+// newContractEngine returns the entity routes behind the spec validator.
+func newContractEngine(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	doc, err := api.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validate, err := OpenAPIValidator(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := gin.New()
+	h := &Handlers{
+		Users:     fakeUsers{},
+		Companies: fakeCompanies{},
+		Brands:    fakeBrands{},
+		Timeout:   5 * time.Second,
+		Middleware: map[string][]gin.HandlerFunc{
+			"users": {validate}, "companies": {validate}, "brands": {validate},
+		},
+	}
+	h.Register(r)
+	return r
+}
+
+// TestRoutesMatchSpec fails when a route is added to or removed from
+// Handlers.Register without updating api/openapi.yaml, or vice versa.
+func TestRoutesMatchSpec(t *testing.T) {
+	doc, err := api.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var inSpec []string
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			inSpec = append(inSpec, method+" "+path)
+		}
+	}
+
+	param := regexp.MustCompile(`:(\w+)`)
+	var registered []string
+	for _, rt := range newContractEngine(t).Routes() {
+		registered = append(registered, rt.Method+" "+param.ReplaceAllString(rt.Path, "{$1}"))
+	}
+
+	sort.Strings(inSpec)
+	sort.Strings(registered)
+	if strings.Join(inSpec, "\n") != strings.Join(registered, "\n") {
+		t.Fatalf("routes and spec differ\nspec:\n  %s\nrouter:\n  %s",
+			strings.Join(inSpec, "\n  "), strings.Join(registered, "\n  "))
+	}
+}
+
+// TestResponsesMatchSpec sends requests to every operation and validates the
+// status code, headers and body of each response against the spec.
+func TestResponsesMatchSpec(t *testing.T) {
+	doc, err := api.GetSwagger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Servers = nil
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := newContractEngine(t)
+
+	cases := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"POST", "/api/v1/users", `{"name":"Ada","lastName":"Lovelace"}`, 201},
+		{"POST", "/api/v1/users", `{"name":""}`, 400},
+		{"GET", "/api/v1/users/1", "", 200},
+		{"GET", "/api/v1/users/404", "", 404},
+		{"GET", "/api/v1/users/abc", "", 400},
+		{"PUT", "/api/v1/users/1", `{"name":"Ada","lastName":"King"}`, 200},
+		{"DELETE", "/api/v1/users/1", "", 204},
+		{"DELETE", "/api/v1/users/404", "", 404},
+
+		{"POST", "/api/v2/companies", `{"name":"Acme"}`, 201},
+		{"GET", "/api/v2/companies/1", "", 200},
+		{"GET", "/api/v2/companies/404", "", 404},
+		{"PUT", "/api/v2/companies/1", `{"name":"Acme Inc"}`, 200},
+		{"PUT", "/api/v2/companies/1", `{}`, 400},
+		{"DELETE", "/api/v2/companies/1", "", 204},
+
+		{"POST", "/api/v3/brands", `{"name":"Zeta"}`, 201},
+		{"GET", "/api/v3/brands/1", "", 200},
+		{"GET", "/api/v3/brands/0", "", 400},
+		{"PUT", "/api/v3/brands/404", `{"name":"Zeta"}`, 404},
+		{"DELETE", "/api/v3/brands/1", "", 204},
+	}
+	for _, tc := range cases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tc.want, rec.Body)
+			}
+
+			route, params, err := router.FindRoute(httptest.NewRequest(tc.method, tc.path, nil))
+			if err != nil {
+				t.Fatalf("route not in spec: %v", err)
+			}
+			err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: &openapi3filter.RequestValidationInput{
+					Request: req, PathParams: params, Route: route,
+					Options: &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+				},
+				Status: rec.Code,
+				Header: rec.Header(),
+				Body:   io.NopCloser(bytes.NewReader(rec.Body.Bytes())),
+				Options: &openapi3filter.Options{
+					IncludeResponseStatus: true, // undeclared status codes are drift too
+				},
+			})
+			if err != nil {
+				t.Fatalf("response does not match spec: %v", err)
+			}
+		})
+	}
+}
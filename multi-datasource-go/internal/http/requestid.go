@@ -0,0 +1,30 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"multi-datasource-go/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the ID correlating a request across logs, audit entries and clients.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID returns a middleware that propagates the caller's X-Request-ID
+// (or generates one), echoes it in the response and stores it in the request
+// context (see domain.RequestIDFrom).
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > 100 {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			id = hex.EncodeToString(b)
+		}
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(domain.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
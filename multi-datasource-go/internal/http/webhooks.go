@@ -0,0 +1,284 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"multi-datasource-go/internal/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandlers exposes webhook subscription management and
+// delivery inspection/replay under /api/webhooks.
+type WebhookHandlers struct {
+	Store      *webhook.Store      // Persistence for subscriptions and deliveries
+	Dispatcher *webhook.Dispatcher // Used to replay failed deliveries
+	Timeout    time.Duration       // Timeout duration applied to each incoming request
+	Auth       gin.HandlerFunc     // Authentication middleware; nil disables auth and scope checks
+	PreAuth    []gin.HandlerFunc   // Middleware run before authentication (per-IP rate limits)
+	Middleware []gin.HandlerFunc   // Extra middleware run after authentication (per-caller rate limits, bulkheads)
+}
+
+// subscriptionRequest is the body accepted by create and update.
+type subscriptionRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Secret     string   `json:"secret"` // optional on create (generated if empty) and update (kept if empty)
+	Active     *bool    `json:"active"` // defaults to true
+}
+
+// Register registers the webhook routes.
+// When Auth is set, reads require "webhooks:read" and changes "webhooks:write".
+func (h *WebhookHandlers) Register(r *gin.Engine) {
+	g := r.Group("/api/webhooks", groupMiddleware(h.PreAuth, h.Auth, h.Middleware)...)
+	read := requireScope(h.Auth, "webhooks:read")
+	write := requireScope(h.Auth, "webhooks:write")
+
+	g.POST("/subscriptions", write, h.createSubscription)
+	g.GET("/subscriptions", read, h.listSubscriptions)
+	g.GET("/subscriptions/:id", read, h.getSubscription)
+	g.PUT("/subscriptions/:id", write, h.updateSubscription)
+	g.DELETE("/subscriptions/:id", write, h.deleteSubscription)
+
+	g.GET("/deliveries", read, h.listDeliveries)
+	g.GET("/deliveries/:id", read, h.getDelivery)
+	g.POST("/deliveries/:id/replay", write, h.replayDelivery)
+}
+
+// createSubscription handles POST /api/webhooks/subscriptions.
+// The response is the only place where the signing secret is returned.
+func (h *WebhookHandlers) createSubscription(c *gin.Context) {
+	var req subscriptionRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sub, err := req.subscription()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if sub.Secret == "" {
+		if sub.Secret, err = newSecret(); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	sub.CreatedAt = time.Now().UTC()
+
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	if err := h.Store.CreateSubscription(ctx, sub); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, sub)
+}
+
+// listSubscriptions handles GET /api/webhooks/subscriptions.
+func (h *WebhookHandlers) listSubscriptions(c *gin.Context) {
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	subs, err := h.Store.ListSubscriptions(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	out := make([]webhook.Subscription, len(subs))
+	for i, s := range subs {
+		s.Secret = "" // never echo secrets after creation
+		out[i] = s
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// getSubscription handles GET /api/webhooks/subscriptions/:id.
+func (h *WebhookHandlers) getSubscription(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	sub, err := h.Store.GetSubscription(ctx, id)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+	sub.Secret = ""
+	c.JSON(http.StatusOK, sub)
+}
+
+// updateSubscription handles PUT /api/webhooks/subscriptions/:id.
+func (h *WebhookHandlers) updateSubscription(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var req subscriptionRequest
+	if err := c.BindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sub, err := req.subscription()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sub.ID = id
+
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	if err := h.Store.UpdateSubscription(ctx, sub); err != nil {
+		webhookError(c, err)
+		return
+	}
+	updated, err := h.Store.GetSubscription(ctx, id)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+	updated.Secret = ""
+	c.JSON(http.StatusOK, updated)
+}
+
+// deleteSubscription handles DELETE /api/webhooks/subscriptions/:id.
+func (h *WebhookHandlers) deleteSubscription(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	if err := h.Store.DeleteSubscription(ctx, id); err != nil {
+		webhookError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// listDeliveries handles GET /api/webhooks/deliveries?status=dead&limit=100.
+// Use status=dead to inspect the dead-letter list.
+func (h *WebhookHandlers) listDeliveries(c *gin.Context) {
+	status := webhook.Status(c.Query("status"))
+	switch status {
+	case "", webhook.StatusPending, webhook.StatusSucceeded, webhook.StatusDead:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be pending, succeeded or dead"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > 1000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 1000"})
+		return
+	}
+
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	out, err := h.Store.ListDeliveries(ctx, status, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if out == nil {
+		out = []webhook.Delivery{}
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// getDelivery handles GET /api/webhooks/deliveries/:id.
+func (h *WebhookHandlers) getDelivery(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	d, err := h.Store.GetDelivery(ctx, id)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, d)
+}
+
+// replayDelivery handles POST /api/webhooks/deliveries/:id/replay.
+// The delivery is reset to pending and picked up by the dispatcher on its next poll.
+func (h *WebhookHandlers) replayDelivery(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.ctx(c)
+	defer cancel()
+	d, err := h.Dispatcher.Replay(ctx, id)
+	if err != nil {
+		webhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, d)
+}
+
+// ctx creates a derived context with the configured timeout.
+func (h *WebhookHandlers) ctx(c *gin.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.Request.Context(), h.Timeout)
+}
+
+// subscription validates the request and converts it into a Subscription.
+func (r subscriptionRequest) subscription() (*webhook.Subscription, error) {
+	u, err := url.Parse(r.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("url must be an absolute http(s) URL")
+	}
+	var types []string
+	for _, t := range r.EventTypes {
+		t = strings.TrimSpace(t)
+		if strings.Contains(t, ",") {
+			return nil, errors.New("event types must not contain commas")
+		}
+		if t != "" {
+			types = append(types, t)
+		}
+	}
+	if len(types) == 0 {
+		return nil, errors.New("eventTypes must list at least one event type (e.g. \"user.created\" or \"*\")")
+	}
+	active := r.Active == nil || *r.Active
+	return &webhook.Subscription{URL: r.URL, EventTypes: types, Secret: r.Secret, Active: active}, nil
+}
+
+// pathID parses the :id path parameter, answering 400 if it is not a positive integer.
+func pathID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id must be a positive integer"})
+		return 0, false
+	}
+	return id, true
+}
+
+// webhookError maps store errors to HTTP responses.
+func webhookError(c *gin.Context, err error) {
+	if errors.Is(err, webhook.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// newSecret generates a random 32-byte signing secret.
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,85 @@
+// Package outbox implements the transactional outbox pattern.
+//
+// Repositories write an Event into the outbox_events table of their own
+// datasource, in the same transaction as the entity row. A background Relay
+// reads pending events and hands them to a Publisher, so downstream services
+// learn about every committed write (at-least-once, ordered per aggregate).
+// Events also carry the Audit facts of their change, so the audit trail is
+// fed from the same transaction as the entity row.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"multi-datasource-go/internal/db"
+)
+
+// Event is a change notification for one aggregate (user, company or brand).
+type Event struct {
+	ID            int64           `json:"id"`            // outbox row ID, unique per Source
+	Source        string          `json:"source"`        // datasource the event was read from (set by the Relay)
+	AggregateType string          `json:"aggregateType"` // e.g. "user"
+	AggregateID   int64           `json:"aggregateId"`   // ID of the changed entity
+	Type          string          `json:"type"`          // e.g. "user.created"
+	Payload       json.RawMessage `json:"payload"`       // entity state after the change
+	CreatedAt     time.Time       `json:"createdAt"`
+
+	// Audit holds who made the change and the previous state, for the audit
+	// trail; nil for events written before it was recorded. It is not part of
+	// the event downstream consumers see.
+	Audit *Audit `json:"-"`
+}
+
+// Audit holds the facts of a change the event payload lacks.
+type Audit struct {
+	Actor     string          `json:"actor"`               // authenticated caller, or "anonymous"
+	RequestID string          `json:"requestId,omitempty"` // X-Request-ID of the originating request
+	Before    json.RawMessage `json:"before,omitempty"`    // entity state before the change; absent on create
+}
+
+// Key returns a globally unique event key that consumers can use for deduplication.
+func (e Event) Key() string {
+	return fmt.Sprintf("%s:%d", e.Source, e.ID)
+}
+
+// NewEvent builds an event for the given aggregate, marshaling the entity as payload.
+func NewEvent(aggregateType string, aggregateID int64, eventType string, entity any) (Event, error) {
+	payload, err := json.Marshal(entity)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		Payload:       payload,
+		CreatedAt:     time.Now().UTC(),
+	}, nil
+}
+
+// Publisher delivers events to downstream consumers.
+// Publish must be safe to call again with the same event (delivery is at-least-once).
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// InsertStmt returns the INSERT statement and arguments that store an event
+// in the outbox table. Repositories execute it inside their own transaction:
+//
+//	q, args := outbox.InsertStmt(db.MySQL, ev)
+//	_, err = tx.ExecContext(ctx, q, args...)
+func InsertStmt(d db.Dialect, e Event) (string, []any) {
+	var audit sql.NullString
+	if e.Audit != nil {
+		b, _ := json.Marshal(e.Audit) // strings and raw JSON always marshal
+		audit = sql.NullString{String: string(b), Valid: true}
+	}
+	q := fmt.Sprintf(
+		"INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload, created_at, audit) VALUES (%s, %s, %s, %s, %s, %s)",
+		d.Placeholder(1), d.Placeholder(2), d.Placeholder(3), d.Placeholder(4), d.Placeholder(5), d.Placeholder(6))
+	return q, []any{e.AggregateType, e.AggregateID, e.Type, string(e.Payload), e.CreatedAt, audit}
+}
@@ -0,0 +1,146 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// =====================================================
+// In-memory Publisher
+// =====================================================
+
+// MemoryPublisher keeps published events in memory. Useful for local runs and tests.
+// Only the most recent Limit events are retained (0 = unbounded).
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []Event
+	Limit  int
+}
+
+// NewMemoryPublisher creates a MemoryPublisher retaining at most limit events.
+func NewMemoryPublisher(limit int) *MemoryPublisher {
+	return &MemoryPublisher{Limit: limit}
+}
+
+// Publish appends the event, dropping the oldest one when the limit is reached.
+func (p *MemoryPublisher) Publish(_ context.Context, e Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, e)
+	if p.Limit > 0 && len(p.events) > p.Limit {
+		p.events = p.events[len(p.events)-p.Limit:]
+	}
+	return nil
+}
+
+// Events returns a copy of the retained events in publish order.
+func (p *MemoryPublisher) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Event(nil), p.events...)
+}
+
+// =====================================================
+// File Publisher
+// =====================================================
+
+// FilePublisher appends every event as one JSON line to a file.
+type FilePublisher struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewFilePublisher opens (or creates) the file at path in append mode.
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePublisher{f: f}, nil
+}
+
+// Publish writes the event as a single JSON line.
+func (p *FilePublisher) Publish(_ context.Context, e Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.f.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the underlying file.
+func (p *FilePublisher) Close() error {
+	return p.f.Close()
+}
+
+// =====================================================
+// Webhook Publisher
+// =====================================================
+
+// WebhookPublisher POSTs every event as JSON to a fixed URL.
+// Any non-2xx response is treated as a failed delivery, so the Relay retries it.
+type WebhookPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookPublisher creates a WebhookPublisher with the given per-request timeout.
+func NewWebhookPublisher(url string, timeout time.Duration) *WebhookPublisher {
+	return &WebhookPublisher{URL: url, Client: &http.Client{Timeout: timeout}}
+}
+
+// Publish sends the event. Consumers can deduplicate using the Idempotency-Key header.
+func (p *WebhookPublisher) Publish(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", e.Key())
+	req.Header.Set("X-Event-Type", e.Type)
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body) // drain so the connection can be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s responded %s", p.URL, resp.Status)
+	}
+	return nil
+}
+
+// =====================================================
+// Fan-out Publisher
+// =====================================================
+
+// MultiPublisher publishes every event to several publishers in order.
+// It stops at the first failure; the Relay then retries the event on all
+// publishers, so each of them must tolerate duplicates.
+type MultiPublisher []Publisher
+
+// Publish hands the event to every publisher.
+func (m MultiPublisher) Publish(ctx context.Context, e Event) error {
+	for _, p := range m {
+		if err := p.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"multi-datasource-go/internal/db"
+)
+
+// Source is a datasource whose outbox table is drained by the Relay.
+type Source struct {
+	Name    string     // datasource name, copied into Event.Source
+	Dialect db.Dialect // SQL dialect of the datasource
+	DB      *sql.DB    // connection pool (Postgres pools are adapted via db.SQLFromPool)
+}
+
+// Relay polls the outbox tables and publishes pending events.
+//
+// Delivery is at-least-once: an event is marked as published only after
+// Publish returns nil, so a crash in between leads to a redelivery.
+// Events are read in ID order and, once an event of an aggregate fails,
+// later events of the same aggregate are held back until it succeeds,
+// which keeps per-aggregate ordering. An event that failed MaxAttempts times
+// is dead-lettered (dead_at is set and it is logged), so it no longer holds
+// back its aggregate, unless the publisher failed with a Retry error.
+// Run a single Relay per datasource.
+type Relay struct {
+	Sources     []Source
+	Publisher   Publisher
+	Interval    time.Duration // pause between polls when no events are pending
+	BatchSize   int           // max events read per poll and source
+	MaxAttempts int           // failed attempts before an event is dead-lettered
+}
+
+// Run polls until ctx is canceled.
+func (r *Relay) Run(ctx context.Context) {
+	if r.Interval <= 0 {
+		r.Interval = time.Second
+	}
+	if r.BatchSize <= 0 {
+		r.BatchSize = 100
+	}
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = 10
+	}
+	t := time.NewTicker(r.Interval)
+	defer t.Stop()
+	for {
+		for _, src := range r.Sources {
+			// Drain fully published batches right away; wait for the ticker once
+			// caught up or when some events failed (they are retried next tick).
+			for {
+				n, err := r.Drain(ctx, src)
+				if err != nil {
+					log.Printf("outbox %s: %v", src.Name, err)
+				}
+				if err != nil || n < r.BatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Drain publishes one batch of pending events from the source.
+// It returns the number of events published.
+func (r *Relay) Drain(ctx context.Context, src Source) (int, error) {
+	events, err := r.pending(ctx, src)
+	if err != nil {
+		return 0, fmt.Errorf("read pending events: %w", err)
+	}
+
+	published := 0
+	blocked := map[string]bool{} // aggregates with a failed event in this batch
+	for _, p := range events {
+		e := p.Event
+		agg := fmt.Sprintf("%s/%d", e.AggregateType, e.AggregateID)
+		if blocked[agg] {
+			continue
+		}
+		if perr := r.Publisher.Publish(ctx, e); perr != nil {
+			dead := p.attempts+1 >= r.MaxAttempts && !retried(perr)
+			if err := r.markFailed(ctx, src, e.ID, perr, dead); err != nil {
+				return published, err
+			}
+			if dead {
+				log.Printf("outbox %s: event %d (%s of %s) dead-lettered after %d attempts: %v",
+					src.Name, e.ID, e.Type, agg, p.attempts+1, perr)
+				continue
+			}
+			blocked[agg] = true
+			continue
+		}
+		if err := r.markPublished(ctx, src, e.ID); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+// retryError marks a failure the Relay keeps retrying; see Retry.
+type retryError struct{ error }
+
+func (e retryError) Unwrap() error { return e.error }
+
+// Retry marks err as a failure the Relay must retry until it succeeds,
+// for publishers that must not lose an event (e.g. the audit trail).
+// Such an event is never dead-lettered: it holds back its aggregate and
+// its attempts keep growing until the publisher recovers.
+func Retry(err error) error {
+	if err == nil {
+		return nil
+	}
+	return retryError{err}
+}
+
+// retried reports whether err was marked with Retry.
+func retried(err error) bool {
+	var r retryError
+	return errors.As(err, &r)
+}
+
+// pendingEvent is an event read by the Relay, with its failed attempts so far.
+type pendingEvent struct {
+	Event
+	attempts int
+}
+
+// pending reads the oldest events of a source that are neither published nor dead.
+func (r *Relay) pending(ctx context.Context, src Source) ([]pendingEvent, error) {
+	q := fmt.Sprintf(`SELECT id, aggregate_type, aggregate_id, event_type, payload, created_at, attempts, audit
+		FROM outbox_events WHERE published_at IS NULL AND dead_at IS NULL ORDER BY id %s`, src.Dialect.Limit(r.BatchSize))
+	rows, err := src.DB.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []pendingEvent
+	for rows.Next() {
+		var (
+			p       pendingEvent
+			payload string
+			audit   sql.NullString
+		)
+		if err := rows.Scan(&p.ID, &p.AggregateType, &p.AggregateID, &p.Type, &payload, &p.CreatedAt, &p.attempts, &audit); err != nil {
+			return nil, err
+		}
+		if audit.Valid {
+			p.Audit = &Audit{}
+			if err := json.Unmarshal([]byte(audit.String), p.Audit); err != nil {
+				return nil, fmt.Errorf("event %d: audit: %w", p.ID, err)
+			}
+		}
+		p.Source = src.Name
+		p.Payload = []byte(payload)
+		p.CreatedAt = p.CreatedAt.UTC()
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// markPublished flags an event as delivered.
+func (r *Relay) markPublished(ctx context.Context, src Source, id int64) error {
+	d := src.Dialect
+	_, err := src.DB.ExecContext(ctx,
+		fmt.Sprintf("UPDATE outbox_events SET published_at = %s, attempts = attempts + 1, last_error = NULL WHERE id = %s",
+			d.Placeholder(1), d.Placeholder(2)),
+		time.Now().UTC(), id)
+	return err
+}
+
+// markFailed records a failed delivery attempt. The event stays pending,
+// unless dead, which moves it to the dead letters.
+func (r *Relay) markFailed(ctx context.Context, src Source, id int64, cause error, dead bool) error {
+	msg := cause.Error()
+	if len(msg) > 1000 {
+		msg = msg[:1000]
+	}
+	var deadAt *time.Time
+	if dead {
+		now := time.Now().UTC()
+		deadAt = &now
+	}
+	d := src.Dialect
+	_, err := src.DB.ExecContext(ctx,
+		fmt.Sprintf("UPDATE outbox_events SET attempts = attempts + 1, last_error = %s, dead_at = %s WHERE id = %s",
+			d.Placeholder(1), d.Placeholder(2), d.Placeholder(3)),
+		msg, deadAt, id)
+	return err
+}
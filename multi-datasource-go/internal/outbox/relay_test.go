@@ -0,0 +1,111 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"multi-datasource-go/internal/db"
+)
+
+// failingPublisher fails the events of one aggregate and records the others.
+type failingPublisher struct {
+	failID    int64
+	published []int64 // event IDs
+}
+
+func (p *failingPublisher) Publish(_ context.Context, e Event) error {
+	if e.AggregateID == p.failID {
+		return errors.New("consumer down")
+	}
+	p.published = append(p.published, e.ID)
+	return nil
+}
+
+func TestRelayDeadLetters(t *testing.T) {
+	ctx := t.Context()
+	dbx, err := db.OpenSQLite(filepath.Join(t.TempDir(), "outbox.db"), db.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbx.Close()
+
+	// A table of an earlier version, without dead_at, gets it.
+	if _, err := dbx.ExecContext(ctx, `
+		CREATE TABLE outbox_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			aggregate_type TEXT NOT NULL,
+			aggregate_id INTEGER NOT NULL,
+			event_type TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			published_at TIMESTAMP NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NULL
+		)`); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := EnsureTable(ctx, db.SQLite, dbx); err != nil {
+			t.Fatalf("EnsureTable: %v", err)
+		}
+	}
+
+	// Events 1 and 3 are of user 1, whose publishing fails; event 2 is of user 2.
+	for _, id := range []int64{1, 2, 1} {
+		e, err := NewEvent("user", id, "user.updated", map[string]int64{"id": id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, args := InsertStmt(db.SQLite, e)
+		if _, err := dbx.ExecContext(ctx, q, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pub := &failingPublisher{failID: 1}
+	r := &Relay{Publisher: pub, BatchSize: 10, MaxAttempts: 3}
+	src := Source{Name: "sqlite", Dialect: db.SQLite, DB: dbx}
+	for i := range 3 {
+		if _, err := r.Drain(ctx, src); err != nil {
+			t.Fatalf("drain %d: %v", i+1, err)
+		}
+	}
+	// Event 1 holds back event 3 until it is dead-lettered by the third drain,
+	// which then tries event 3 for the first time.
+	if want := []int64{2}; !slices.Equal(pub.published, want) {
+		t.Errorf("published %v, want %v", pub.published, want)
+	}
+	attempts := func(id int64) (int, bool) {
+		t.Helper()
+		var (
+			n    int
+			dead bool
+		)
+		if err := dbx.QueryRowContext(ctx, "SELECT attempts, dead_at IS NOT NULL FROM outbox_events WHERE id = ?", id).Scan(&n, &dead); err != nil {
+			t.Fatal(err)
+		}
+		return n, dead
+	}
+	if n, dead := attempts(1); n != 3 || !dead {
+		t.Errorf("event 1: %d attempts, dead %v; want 3, dead", n, dead)
+	}
+	if n, dead := attempts(3); n != 1 || dead {
+		t.Errorf("event 3: %d attempts, dead %v; want 1, pending", n, dead)
+	}
+
+	// Dead events are not read again.
+	for range 2 {
+		if _, err := r.Drain(ctx, src); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n, _ := attempts(1); n != 3 {
+		t.Errorf("event 1: %d attempts after it died, want 3", n)
+	}
+	if n, dead := attempts(3); n != 3 || !dead {
+		t.Errorf("event 3: %d attempts, dead %v; want 3, dead", n, dead)
+	}
+}
@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"multi-datasource-go/internal/db"
+)
+
+// ddl holds the CREATE TABLE statement of the outbox table per dialect.
+// published_at stays NULL until the Relay has handed the event to the Publisher;
+// dead_at is set instead once the Relay gives up on the event. audit holds the
+// JSON of Event.Audit.
+var ddl = map[db.Dialect]string{
+	db.MySQL: `
+		CREATE TABLE IF NOT EXISTS outbox_events (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			aggregate_type VARCHAR(50) NOT NULL,
+			aggregate_id BIGINT NOT NULL,
+			event_type VARCHAR(100) NOT NULL,
+			payload JSON NOT NULL,
+			created_at TIMESTAMP(6) NOT NULL,
+			published_at TIMESTAMP(6) NULL,
+			dead_at TIMESTAMP(6) NULL,
+			audit JSON NULL,
+			attempts INT NOT NULL DEFAULT 0,
+			last_error VARCHAR(1000) NULL,
+			INDEX idx_outbox_pending (published_at, id)
+		)`,
+	db.Postgres: `
+		CREATE TABLE IF NOT EXISTS outbox_events (
+			id BIGSERIAL PRIMARY KEY,
+			aggregate_type TEXT NOT NULL,
+			aggregate_id BIGINT NOT NULL,
+			event_type TEXT NOT NULL,
+			payload JSONB NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			published_at TIMESTAMPTZ NULL,
+			dead_at TIMESTAMPTZ NULL,
+			audit JSONB NULL,
+			attempts INT NOT NULL DEFAULT 0,
+			last_error TEXT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox_events (id) WHERE published_at IS NULL`,
+	db.Oracle: `
+		BEGIN
+			EXECUTE IMMEDIATE 'CREATE TABLE outbox_events (
+				id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+				aggregate_type VARCHAR2(50) NOT NULL,
+				aggregate_id NUMBER(19) NOT NULL,
+				event_type VARCHAR2(100) NOT NULL,
+				payload CLOB NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				published_at TIMESTAMP NULL,
+				dead_at TIMESTAMP NULL,
+				audit CLOB NULL,
+				attempts NUMBER(10) DEFAULT 0 NOT NULL,
+				last_error VARCHAR2(1000) NULL
+			)';
+			EXECUTE IMMEDIATE 'CREATE INDEX idx_outbox_pending ON outbox_events (published_at, id)';
+		EXCEPTION
+			WHEN OTHERS THEN
+				IF SQLCODE != -955 THEN RAISE; END IF; -- ORA-00955 = name is already used by an existing object
+		END;`,
+	db.SQLite: `
+		CREATE TABLE IF NOT EXISTS outbox_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			aggregate_type TEXT NOT NULL,
+			aggregate_id INTEGER NOT NULL,
+			event_type TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			published_at TIMESTAMP NULL,
+			dead_at TIMESTAMP NULL,
+			audit TEXT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox_events (id) WHERE published_at IS NULL`,
+}
+
+// added holds the columns added to the outbox table after its first version,
+// with the statement adding each to existing tables per dialect.
+var added = []struct {
+	column string
+	ddl    map[db.Dialect]string
+}{
+	{"dead_at", map[db.Dialect]string{
+		db.MySQL:    `ALTER TABLE outbox_events ADD COLUMN dead_at TIMESTAMP(6) NULL`,
+		db.Postgres: `ALTER TABLE outbox_events ADD COLUMN dead_at TIMESTAMPTZ NULL`,
+		db.Oracle:   `ALTER TABLE outbox_events ADD (dead_at TIMESTAMP NULL)`,
+		db.SQLite:   `ALTER TABLE outbox_events ADD COLUMN dead_at TIMESTAMP NULL`,
+	}},
+	{"audit", map[db.Dialect]string{
+		db.MySQL:    `ALTER TABLE outbox_events ADD COLUMN audit JSON NULL`,
+		db.Postgres: `ALTER TABLE outbox_events ADD COLUMN audit JSONB NULL`,
+		db.Oracle:   `ALTER TABLE outbox_events ADD (audit CLOB NULL)`,
+		db.SQLite:   `ALTER TABLE outbox_events ADD COLUMN audit TEXT NULL`,
+	}},
+}
+
+// EnsureTable creates the outbox table (and its pending-events index) if it does not exist,
+// and adds the columns that tables created by earlier versions lack.
+func EnsureTable(ctx context.Context, d db.Dialect, dbx *sql.DB) error {
+	if _, err := dbx.ExecContext(ctx, ddl[d]); err != nil {
+		return err
+	}
+	for _, col := range added {
+		has, err := d.HasColumn(ctx, dbx, "outbox_events", col.column)
+		if err != nil {
+			return fmt.Errorf("inspect outbox_events: %w", err)
+		}
+		if !has {
+			if _, err := dbx.ExecContext(ctx, col.ddl[d]); err != nil {
+				return fmt.Errorf("add outbox_events.%s: %w", col.column, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Bulkhead caps the number of requests using a datasource concurrently.
+// Requests beyond the limit wait up to MaxWait for a slot and are rejected
+// afterwards, so a burst cannot queue behind an exhausted connection pool
+// until the request timeout.
+type Bulkhead struct {
+	Name       string        // datasource name, e.g. "oracle"
+	MaxWait    time.Duration // how long a request may wait for a free slot
+	RetryAfter time.Duration // hint returned to rejected clients
+	slots      chan struct{}
+}
+
+// NewBulkhead creates a bulkhead admitting max concurrent requests.
+func NewBulkhead(name string, max int, maxWait, retryAfter time.Duration) *Bulkhead {
+	return &Bulkhead{Name: name, MaxWait: maxWait, RetryAfter: retryAfter, slots: make(chan struct{}, max)}
+}
+
+// Acquire takes a slot, waiting at most MaxWait. It returns a release
+// function on success and false when the bulkhead is full.
+func (b *Bulkhead) Acquire(ctx context.Context) (release func(), ok bool) {
+	release = func() { <-b.slots }
+	select {
+	case b.slots <- struct{}{}:
+		return release, true
+	default:
+	}
+	if b.MaxWait <= 0 {
+		return nil, false
+	}
+
+	t := time.NewTimer(b.MaxWait)
+	defer t.Stop()
+	select {
+	case b.slots <- struct{}{}:
+		return release, true
+	case <-t.C:
+		return nil, false
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// InFlight returns the number of requests currently holding a slot.
+func (b *Bulkhead) InFlight() int { return len(b.slots) }
@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLimiter keeps token buckets in process memory.
+// Limits apply per application instance.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// bucket is the state of one token bucket.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	rate    Rate
+}
+
+// sweepInterval is how often full buckets are dropped.
+const sweepInterval = time.Minute
+
+// NewMemoryLimiter creates an empty in-memory limiter.
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: map[string]*bucket{}, lastSweep: time.Now()}
+}
+
+// Allow implements Limiter.
+func (l *MemoryLimiter) Allow(_ context.Context, key string, rate Rate) (Result, error) {
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > sweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rate.Burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.rate = rate
+	var res Result
+	b.tokens, res = take(b.tokens, b.updated, now, rate)
+	b.updated = now
+	return res, nil
+}
+
+// sweep drops buckets that have refilled completely; recreating them yields the same state.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	for k, b := range l.buckets {
+		if refill(b.tokens, b.updated, now, b.rate) >= float64(b.rate.Burst) {
+			delete(l.buckets, k)
+		}
+	}
+	l.lastSweep = now
+}
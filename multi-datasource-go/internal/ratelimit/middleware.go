@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"multi-datasource-go/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientIPMiddleware rate limits a route group per client IP. It runs before
+// the authentication middleware, so that floods of requests with bad
+// credentials are limited too, and do not cost a credential check each.
+func ClientIPMiddleware(l Limiter, group string, rate Rate) gin.HandlerFunc {
+	return limit(l, group, rate, func(c *gin.Context) (string, bool) {
+		return group + ":ip:" + c.ClientIP(), true
+	})
+}
+
+// Middleware rate limits a route group per authenticated caller, keyed by
+// identity (API key name or JWT subject), so it must run after the
+// authentication middleware. Anonymous requests pass; ClientIPMiddleware
+// limits them.
+func Middleware(l Limiter, group string, rate Rate) gin.HandlerFunc {
+	return limit(l, group, rate, func(c *gin.Context) (string, bool) {
+		actor, ok := domain.ActorFrom(c.Request.Context())
+		return group + ":actor:" + actor.ID, ok
+	})
+}
+
+// limit rate limits the requests key returns a bucket for. If the limiter
+// backend fails the request is let through (fail open) and the error is logged.
+func limit(l Limiter, group string, rate Rate, key func(*gin.Context) (string, bool)) gin.HandlerFunc {
+	burst := strconv.Itoa(rate.Burst)
+	return func(c *gin.Context) {
+		key, ok := key(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		res, err := l.Allow(c.Request.Context(), key, rate)
+		if err != nil {
+			log.Printf("ratelimit %s: %v", group, err)
+			c.Next()
+			return
+		}
+		c.Header("X-RateLimit-Limit", burst)
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		if !res.Allowed {
+			c.Header("Retry-After", retryAfter(res.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// Middleware sheds requests with 503 and Retry-After when the bulkhead is full.
+func (b *Bulkhead) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		release, ok := b.Acquire(c.Request.Context())
+		if !ok {
+			c.Header("Retry-After", retryAfter(b.RetryAfter))
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": b.Name + " is overloaded, retry later"})
+			return
+		}
+		defer release()
+		c.Next()
+	}
+}
+
+// retryAfter formats a duration as Retry-After seconds, rounded up to at least 1.
+func retryAfter(d time.Duration) string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(d.Seconds()))))
+}
@@ -0,0 +1,62 @@
+// Package ratelimit protects the API and its datasources from bursts.
+//
+//   - Limiter implementations apply a token bucket per key (API caller or client IP)
+//     and route group; MemoryLimiter is per process, SQLLimiter is shared by all
+//     instances through a table in one of the datasources.
+//   - Bulkhead caps the number of concurrent requests per datasource and sheds
+//     excess load before its connection pool is saturated.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Rate configures a token bucket: PerSecond tokens are added per second
+// up to Burst tokens; every request takes one token.
+type Rate struct {
+	PerSecond float64
+	Burst     int
+}
+
+// Result is the outcome of a rate limit check.
+type Result struct {
+	Allowed    bool
+	Remaining  int           // whole tokens left in the bucket
+	RetryAfter time.Duration // time until the next token is available when not allowed
+}
+
+// Limiter decides whether a request identified by key may proceed.
+type Limiter interface {
+	Allow(ctx context.Context, key string, rate Rate) (Result, error)
+}
+
+// refill returns the tokens of a bucket holding tokens at last, as of now.
+func refill(tokens float64, last, now time.Time, rate Rate) float64 {
+	if elapsed := now.Sub(last).Seconds(); elapsed > 0 {
+		tokens = math.Min(float64(rate.Burst), tokens+elapsed*rate.PerSecond)
+	}
+	return tokens
+}
+
+// fullAt returns when a bucket holding tokens at now has refilled completely.
+func fullAt(tokens float64, now time.Time, rate Rate) time.Time {
+	missing := float64(rate.Burst) - tokens
+	if missing <= 0 {
+		return now
+	}
+	return now.Add(time.Duration(missing / rate.PerSecond * float64(time.Second)))
+}
+
+// take refills a bucket holding tokens (last updated at last) up to now and tries
+// to take one token. It returns the new token count and the result.
+func take(tokens float64, last, now time.Time, rate Rate) (float64, Result) {
+	tokens = refill(tokens, last, now, rate)
+	if tokens >= 1 {
+		tokens--
+		return tokens, Result{Allowed: true, Remaining: int(tokens)}
+	}
+	wait := time.Duration((1 - tokens) / rate.PerSecond * float64(time.Second))
+	return tokens, Result{RetryAfter: wait}
+}
@@ -0,0 +1,253 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"multi-datasource-go/internal/db"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTake(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		tokens float64
+		last   time.Time
+		rate   Rate
+		left   float64
+		want   Result
+	}{
+		{"full bucket", 5, now, Rate{PerSecond: 1, Burst: 5}, 4, Result{Allowed: true, Remaining: 4}},
+		{"empty bucket", 0, now, Rate{PerSecond: 2, Burst: 5}, 0, Result{RetryAfter: 500 * time.Millisecond}},
+		{"partly refilled", 0, now.Add(-250 * time.Millisecond), Rate{PerSecond: 2, Burst: 5}, 0.5, Result{RetryAfter: 250 * time.Millisecond}},
+		{"refilled to one token", 0, now.Add(-time.Second), Rate{PerSecond: 1, Burst: 5}, 0, Result{Allowed: true}},
+		{"refill stops at burst", 0, now.Add(-time.Hour), Rate{PerSecond: 1, Burst: 5}, 4, Result{Allowed: true, Remaining: 4}},
+		{"clock went back", 1, now.Add(time.Second), Rate{PerSecond: 1, Burst: 5}, 0, Result{Allowed: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, res := take(tt.tokens, tt.last, now, tt.rate)
+			if left != tt.left || res != tt.want {
+				t.Errorf("take = %v, %+v; want %v, %+v", left, res, tt.left, tt.want)
+			}
+		})
+	}
+}
+
+func TestFullAt(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	rate := Rate{PerSecond: 2, Burst: 5}
+	if got := fullAt(3, now, rate); !got.Equal(now.Add(time.Second)) {
+		t.Errorf("fullAt(3) = %v, want 1s later", got)
+	}
+	if got := fullAt(5, now, rate); !got.Equal(now) {
+		t.Errorf("fullAt(5) = %v, want now", got)
+	}
+}
+
+// testLimiters returns the limiters under test, the SQL one on a SQLite file.
+func testLimiters(t *testing.T) map[string]Limiter {
+	dbx, err := db.OpenSQLite(filepath.Join(t.TempDir(), "ratelimit.db"), db.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dbx.Close() })
+	sql := NewSQLLimiter(db.SQLite, dbx)
+	for range 2 {
+		if err := sql.EnsureTable(t.Context()); err != nil {
+			t.Fatalf("EnsureTable: %v", err)
+		}
+	}
+	return map[string]Limiter{"memory": NewMemoryLimiter(), "sql": sql}
+}
+
+func TestLimiterBurst(t *testing.T) {
+	for name, l := range testLimiters(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := t.Context()
+			rate := Rate{PerSecond: 0.01, Burst: 3}
+			for i := range 3 {
+				res, err := l.Allow(ctx, "users:ip:192.0.2.1", rate)
+				if err != nil || !res.Allowed || res.Remaining != 2-i {
+					t.Fatalf("request %d: %+v, %v; want allowed with %d remaining", i+1, res, err, 2-i)
+				}
+			}
+			res, err := l.Allow(ctx, "users:ip:192.0.2.1", rate)
+			if err != nil || res.Allowed || res.RetryAfter <= 99*time.Second || res.RetryAfter > 100*time.Second {
+				t.Fatalf("request 4: %+v, %v; want rejected, retry after about 100s", res, err)
+			}
+			// Other keys have buckets of their own.
+			if res, err := l.Allow(ctx, "users:ip:192.0.2.2", rate); err != nil || !res.Allowed {
+				t.Fatalf("other key: %+v, %v; want allowed", res, err)
+			}
+		})
+	}
+}
+
+func TestSQLLimiterSweep(t *testing.T) {
+	ctx := t.Context()
+	dbx, err := db.OpenSQLite(filepath.Join(t.TempDir(), "ratelimit.db"), db.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dbx.Close()
+
+	// A table of an earlier version, without full_at, gets it; its rows are swept.
+	if _, err := dbx.ExecContext(ctx, `
+		CREATE TABLE rate_limit_buckets (
+			bucket_key TEXT PRIMARY KEY,
+			tokens REAL NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbx.ExecContext(ctx, "INSERT INTO rate_limit_buckets VALUES ('legacy', 0, ?)", time.Now().UTC()); err != nil {
+		t.Fatal(err)
+	}
+	l := NewSQLLimiter(db.SQLite, dbx)
+	if err := l.EnsureTable(ctx); err != nil {
+		t.Fatalf("EnsureTable: %v", err)
+	}
+
+	// The fast bucket refills within milliseconds, the slow one takes minutes.
+	if _, err := l.Allow(ctx, "fast", Rate{PerSecond: 1000, Burst: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Allow(ctx, "slow", Rate{PerSecond: 0.01, Burst: 2}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	l.lastSweep = time.Time{}
+	if _, err := l.Allow(ctx, "new", Rate{PerSecond: 0.01, Burst: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := dbx.QueryContext(ctx, "SELECT bucket_key FROM rate_limit_buckets ORDER BY bucket_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var keys []string
+	for rows.Next() {
+		var k string
+		if err := rows.Scan(&k); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) != 2 || keys[0] != "new" || keys[1] != "slow" {
+		t.Errorf("buckets after sweep %v, want [new slow]", keys)
+	}
+}
+
+// failingLimiter stands in for an unreachable SQL backend.
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, Rate) (Result, error) {
+	return Result{}, errors.New("database is down")
+}
+
+func TestClientIPMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		limiter    Limiter
+		want       []int
+		retryAfter string // of the last response
+	}{
+		{"limits", NewMemoryLimiter(), []int{http.StatusNoContent, http.StatusTooManyRequests}, "2"},
+		{"fails open", failingLimiter{}, []int{http.StatusNoContent, http.StatusNoContent}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/", ClientIPMiddleware(tt.limiter, "users", Rate{PerSecond: 0.5, Burst: 1}),
+				func(c *gin.Context) { c.Status(http.StatusNoContent) })
+			var w *httptest.ResponseRecorder
+			for i, want := range tt.want {
+				w = httptest.NewRecorder()
+				r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+				if w.Code != want {
+					t.Fatalf("request %d: %d, want %d", i+1, w.Code, want)
+				}
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("Retry-After %q, want %q", got, tt.retryAfter)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                       "1",
+		200 * time.Millisecond:  "1",
+		time.Second:             "1",
+		1500 * time.Millisecond: "2",
+		time.Minute:             "60",
+	} {
+		if got := retryAfter(d); got != want {
+			t.Errorf("retryAfter(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestBulkhead(t *testing.T) {
+	b := NewBulkhead("oracle", 1, 20*time.Millisecond, 3*time.Second)
+	release, ok := b.Acquire(t.Context())
+	if !ok || b.InFlight() != 1 {
+		t.Fatalf("first acquire: ok %v, %d in flight; want ok, 1", ok, b.InFlight())
+	}
+
+	// Full: the caller waits MaxWait, then gives up.
+	start := time.Now()
+	if _, ok := b.Acquire(t.Context()); ok {
+		t.Fatal("acquired a slot of a full bulkhead")
+	}
+	if waited := time.Since(start); waited < 20*time.Millisecond {
+		t.Errorf("gave up after %v, want MaxWait", waited)
+	}
+
+	// A canceled request does not wait.
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	b.MaxWait = time.Minute
+	if _, ok := b.Acquire(ctx); ok {
+		t.Fatal("acquired a slot for a canceled request")
+	}
+
+	// Full requests get 503 with Retry-After.
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	b.MaxWait = 0
+	r.GET("/", b.Middleware(), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "3" {
+		t.Errorf("full bulkhead: %d, Retry-After %q; want 503, 3", w.Code, w.Header().Get("Retry-After"))
+	}
+
+	// A waiting caller gets the slot once it is released.
+	b.MaxWait = time.Minute
+	time.AfterFunc(10*time.Millisecond, release)
+	release, ok = b.Acquire(t.Context())
+	if !ok {
+		t.Fatal("waiting acquire failed after release")
+	}
+	release()
+	if b.InFlight() != 0 {
+		t.Errorf("%d in flight after release, want 0", b.InFlight())
+	}
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusNoContent || b.InFlight() != 0 {
+		t.Errorf("free bulkhead: %d, %d in flight; want 204, 0", w.Code, b.InFlight())
+	}
+}
@@ -0,0 +1,185 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"multi-datasource-go/internal/db"
+)
+
+// ddl holds the CREATE TABLE statement of the rate_limit_buckets table per dialect.
+var ddl = map[db.Dialect]string{
+	db.MySQL: `
+		CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+			bucket_key VARCHAR(200) PRIMARY KEY,
+			tokens DOUBLE NOT NULL,
+			updated_at TIMESTAMP(6) NOT NULL,
+			full_at TIMESTAMP(6) NOT NULL
+		)`,
+	db.Postgres: `
+		CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+			bucket_key TEXT PRIMARY KEY,
+			tokens DOUBLE PRECISION NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			full_at TIMESTAMPTZ NOT NULL
+		)`,
+	db.Oracle: `
+		BEGIN
+			EXECUTE IMMEDIATE 'CREATE TABLE rate_limit_buckets (
+				bucket_key VARCHAR2(200) PRIMARY KEY,
+				tokens BINARY_DOUBLE NOT NULL,
+				updated_at TIMESTAMP NOT NULL,
+				full_at TIMESTAMP NOT NULL
+			)';
+		EXCEPTION
+			WHEN OTHERS THEN
+				IF SQLCODE != -955 THEN RAISE; END IF; -- ORA-00955 = name is already used by an existing object
+		END;`,
+	db.SQLite: `
+		CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+			bucket_key TEXT PRIMARY KEY,
+			tokens REAL NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			full_at TIMESTAMP NOT NULL
+		)`,
+}
+
+// addFullAt holds, per dialect, the statement adding the full_at column to
+// rate_limit_buckets tables created before it existed. Their rows have none
+// and are dropped by the next sweep, as if they had refilled.
+var addFullAt = map[db.Dialect]string{
+	db.MySQL:    `ALTER TABLE rate_limit_buckets ADD COLUMN full_at TIMESTAMP(6) NULL`,
+	db.Postgres: `ALTER TABLE rate_limit_buckets ADD COLUMN full_at TIMESTAMPTZ NULL`,
+	db.Oracle:   `ALTER TABLE rate_limit_buckets ADD (full_at TIMESTAMP NULL)`,
+	db.SQLite:   `ALTER TABLE rate_limit_buckets ADD COLUMN full_at TIMESTAMP NULL`,
+}
+
+// SQLLimiter keeps token buckets in a table, so limits are shared by every
+// application instance using the same datasource. Each check locks the
+// bucket row (SELECT ... FOR UPDATE) for the duration of one short transaction.
+// SQLite has no row locks; its transactions take the database write lock
+// when they begin (see db.OpenSQLite), which serializes the checks instead.
+//
+// Every row records when its bucket will have refilled completely (full_at).
+// Each instance deletes the rows past that time every sweepInterval, like
+// MemoryLimiter does, so the table only holds the buckets of recent callers.
+type SQLLimiter struct {
+	dialect db.Dialect
+	db      *sql.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewSQLLimiter creates a limiter on top of the given connection pool.
+func NewSQLLimiter(d db.Dialect, dbx *sql.DB) *SQLLimiter {
+	return &SQLLimiter{dialect: d, db: dbx, lastSweep: time.Now()}
+}
+
+// EnsureTable creates the rate_limit_buckets table if it does not exist,
+// and adds the full_at column to tables created before it existed.
+func (l *SQLLimiter) EnsureTable(ctx context.Context) error {
+	if _, err := l.db.ExecContext(ctx, ddl[l.dialect]); err != nil {
+		return err
+	}
+	has, err := l.dialect.HasColumn(ctx, l.db, "rate_limit_buckets", "full_at")
+	if err != nil {
+		return fmt.Errorf("inspect rate_limit_buckets: %w", err)
+	}
+	if !has {
+		if _, err := l.db.ExecContext(ctx, addFullAt[l.dialect]); err != nil {
+			return fmt.Errorf("add rate_limit_buckets.full_at: %w", err)
+		}
+	}
+	return nil
+}
+
+// Allow implements Limiter.
+func (l *SQLLimiter) Allow(ctx context.Context, key string, rate Rate) (Result, error) {
+	res, err := l.allow(ctx, key, rate)
+	if errors.Is(err, errRace) {
+		// Another instance created the bucket concurrently; it exists now.
+		res, err = l.allow(ctx, key, rate)
+	}
+	if l.sweepDue() {
+		if err := l.sweep(ctx); err != nil {
+			log.Printf("ratelimit: sweep rate_limit_buckets: %v", err)
+		}
+	}
+	return res, err
+}
+
+// sweepDue reports whether sweepInterval has passed since the last sweep,
+// and if so starts the next interval, so one caller at a time sweeps.
+func (l *SQLLimiter) sweepDue() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if now.Sub(l.lastSweep) <= sweepInterval {
+		return false
+	}
+	l.lastSweep = now
+	return true
+}
+
+// sweep deletes the buckets that have refilled completely; recreating them yields the same state.
+func (l *SQLLimiter) sweep(ctx context.Context) error {
+	_, err := l.db.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM rate_limit_buckets WHERE full_at <= %s OR full_at IS NULL", l.dialect.Placeholder(1)),
+		time.Now().UTC())
+	return err
+}
+
+// errRace reports that the bucket row was inserted concurrently.
+var errRace = errors.New("bucket created concurrently")
+
+// allow runs one locked read-modify-write of the bucket row.
+func (l *SQLLimiter) allow(ctx context.Context, key string, rate Rate) (Result, error) {
+	p := l.dialect.Placeholder
+	tx, err := l.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Result{}, err
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	now := time.Now().UTC()
+	var (
+		tokens float64
+		last   time.Time
+	)
+	lock := " FOR UPDATE"
+	if l.dialect == db.SQLite {
+		lock = ""
+	}
+	err = tx.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT tokens, updated_at FROM rate_limit_buckets WHERE bucket_key = %s%s", p(1), lock), key).
+		Scan(&tokens, &last)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		var res Result
+		tokens, res = take(float64(rate.Burst), now, now, rate)
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf("INSERT INTO rate_limit_buckets (bucket_key, tokens, updated_at, full_at) VALUES (%s, %s, %s, %s)",
+				p(1), p(2), p(3), p(4)),
+			key, tokens, now, fullAt(tokens, now, rate)); err != nil {
+			return Result{}, fmt.Errorf("%w: %v", errRace, err)
+		}
+		return res, tx.Commit()
+	case err != nil:
+		return Result{}, err
+	}
+
+	tokens, res := take(tokens, last, now, rate)
+	if _, err := tx.ExecContext(ctx,
+		fmt.Sprintf("UPDATE rate_limit_buckets SET tokens = %s, updated_at = %s, full_at = %s WHERE bucket_key = %s",
+			p(1), p(2), p(3), p(4)),
+		tokens, now, fullAt(tokens, now, rate), key); err != nil {
+		return Result{}, err
+	}
+	return res, tx.Commit()
+}
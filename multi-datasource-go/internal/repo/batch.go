@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"database/sql"
+	"strings"
+
+	"multi-datasource-go/internal/db"
+)
+
+// maxBatch bounds the IDs of one IN list; Oracle rejects lists of more than 1000 expressions.
+const maxBatch = 1000
+
+// inList renders the placeholders "(?, ?, ...)" of an IN list with n entries.
+func inList(d db.Dialect, n int) string {
+	p := make([]string, n)
+	for i := range p {
+		p[i] = d.Placeholder(i + 1)
+	}
+	return "(" + strings.Join(p, ", ") + ")"
+}
+
+// batches splits ids into chunks of at most maxBatch and converts them into query arguments.
+func batches(ids []int64) [][]any {
+	var out [][]any
+	for len(ids) > 0 {
+		n := min(len(ids), maxBatch)
+		args := make([]any, n)
+		for i, id := range ids[:n] {
+			args[i] = id
+		}
+		out = append(out, args)
+		ids = ids[n:]
+	}
+	return out
+}
+
+// nullID converts an optional reference into a query argument (NULL when nil).
+func nullID(id *int64) sql.NullInt64 {
+	if id == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *id, Valid: true}
+}
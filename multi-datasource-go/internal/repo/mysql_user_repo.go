@@ -3,6 +3,9 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"errors"
+
+	"multi-datasource-go/internal/db"
 	"multi-datasource-go/internal/domain"
 )
 
@@ -19,16 +22,129 @@ func NewMySQLUserRepo(db *sql.DB) *MySQLUserRepo {
 }
 
 // Create inserts a new user record into the MySQL 'users' table.
+// The row and its "user.created" outbox event are written in one transaction.
 // It accepts a context for cancellation and timeout control.
 // Returns the newly inserted record ID, or an error if the insert fails.
 func (r *MySQLUserRepo) Create(ctx context.Context, u *domain.User) (int64, error) {
-	// Execute the INSERT statement using a prepared query with parameter placeholders (safe from SQL injection)
-	res, err := r.db.ExecContext(ctx,
-		"INSERT INTO users (name, last_name) VALUES (?, ?)", u.Name, u.LastName)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
 
+	// Execute the INSERT statement using a prepared query with parameter placeholders (safe from SQL injection)
+	res, err := tx.ExecContext(ctx,
+		"INSERT INTO users (name, last_name, company_id) VALUES (?, ?, ?)", u.Name, u.LastName, nullID(u.CompanyID))
+	if err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+
 	// Retrieve the last inserted ID (auto-increment primary key)
-	return res.LastInsertId()
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+	u.ID = id
+
+	// Record the change event in the same transaction
+	if err := insertEvent(ctx, tx, db.MySQL, "user", id, "user.created", nil, u); err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+
+	return id, tx.Commit()
+}
+
+// Get loads a user by ID. It returns domain.ErrNotFound if no row matches.
+func (r *MySQLUserRepo) Get(ctx context.Context, id int64) (*domain.User, error) {
+	return scanUser(r.db.QueryRowContext(ctx, "SELECT id, name, last_name, company_id FROM users WHERE id = ?", id))
+}
+
+// GetMany loads the users with the given IDs using one IN query per 1000 IDs.
+func (r *MySQLUserRepo) GetMany(ctx context.Context, ids []int64) ([]domain.User, error) {
+	var users []domain.User
+	for _, args := range batches(ids) {
+		rows, err := r.db.QueryContext(ctx,
+			"SELECT id, name, last_name, company_id FROM users WHERE id IN "+inList(db.MySQL, len(args)), args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var u domain.User
+			if err := rows.Scan(&u.ID, &u.Name, &u.LastName, &u.CompanyID); err != nil {
+				return nil, errors.Join(err, rows.Close())
+			}
+			users = append(users, u)
+		}
+		if err := errors.Join(rows.Err(), rows.Close()); err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+// Update overwrites name, last name and company of an existing user.
+// The row and its "user.updated" outbox event are written in one transaction.
+// It returns domain.ErrNotFound if the user does not exist.
+func (r *MySQLUserRepo) Update(ctx context.Context, u *domain.User) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before, err := scanUser(tx.QueryRowContext(ctx, "SELECT id, name, last_name, company_id FROM users WHERE id = ? FOR UPDATE", u.ID))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	// MySQL reports changed rather than matched rows, so an update that keeps
+	// all values affects none; the locked read above already found the row.
+	if _, err := tx.ExecContext(ctx,
+		"UPDATE users SET name = ?, last_name = ?, company_id = ? WHERE id = ?", u.Name, u.LastName, nullID(u.CompanyID), u.ID); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := insertEvent(ctx, tx, db.MySQL, "user", u.ID, "user.updated", before, u); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// Delete removes a user. The row and its "user.deleted" outbox event are
+// written in one transaction. It returns domain.ErrNotFound if no row matches.
+func (r *MySQLUserRepo) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before, err := scanUser(tx.QueryRowContext(ctx, "SELECT id, name, last_name, company_id FROM users WHERE id = ? FOR UPDATE", id))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM users WHERE id = ?", id)
+	if err := affectedOne(res, err); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := insertEvent(ctx, tx, db.MySQL, "user", id, "user.deleted", before, &domain.User{ID: id}); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// affectedOne maps a statement that matched no rows to domain.ErrNotFound.
+func affectedOne(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
 }
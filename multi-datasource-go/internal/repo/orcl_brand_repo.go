@@ -3,7 +3,9 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"errors"
 
+	"multi-datasource-go/internal/db"
 	"multi-datasource-go/internal/domain"
 )
 
@@ -20,25 +22,108 @@ func NewOracleBrandRepo(db *sql.DB) *OracleBrandRepo {
 }
 
 // Create inserts a new brand record into the Oracle 'brands' table.
-// The SQL statement uses Oracle-style positional bind parameters (:1).
-// Depending on your Oracle schema, you might replace this with:
-//
-//	"INSERT INTO brands (id, name) VALUES (brand_seq.NEXTVAL, :1)"
-//
-// or use RETURNING INTO if you need to fetch the generated ID.
-// Returns the number of affected rows or an error if the operation fails.
+// The SQL statement uses Oracle-style positional bind parameters (:1) and
+// RETURNING INTO (:2) to fetch the identity value generated for the row.
+// The row and its "brand.created" outbox event are written in one transaction.
+// Returns the generated brand ID or an error if the operation fails.
 func (r *OracleBrandRepo) Create(ctx context.Context, b *domain.Brand) (int64, error) {
-	// Execute the INSERT command within the provided context (supports timeout/cancel)
-	res, err := r.db.ExecContext(ctx, "INSERT INTO brands (name) VALUES (:1)", b.Name)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, err
 	}
 
-	// Get number of rows affected (should be 1 if successful)
-	rowsAffected, err := res.RowsAffected()
+	// Execute the INSERT command within the provided context (supports timeout/cancel)
+	var id int64
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO brands (name, company_id) VALUES (:1, :2) RETURNING id INTO :3", b.Name, nullID(b.CompanyID), sql.Out{Dest: &id}); err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+	b.ID = id
+
+	// Record the change event in the same transaction
+	if err := insertEvent(ctx, tx, db.Oracle, "brand", id, "brand.created", nil, b); err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+
+	return id, tx.Commit()
+}
+
+// Get loads a brand by ID. It returns domain.ErrNotFound if no row matches.
+func (r *OracleBrandRepo) Get(ctx context.Context, id int64) (*domain.Brand, error) {
+	return scanBrand(r.db.QueryRowContext(ctx, "SELECT id, name, company_id FROM brands WHERE id = :1", id))
+}
+
+// ListByCompanies loads the brands of the given companies using one IN query per 1000 companies.
+func (r *OracleBrandRepo) ListByCompanies(ctx context.Context, companyIDs []int64) ([]domain.Brand, error) {
+	var brands []domain.Brand
+	for _, args := range batches(companyIDs) {
+		rows, err := r.db.QueryContext(ctx,
+			"SELECT id, name, company_id FROM brands WHERE company_id IN "+inList(db.Oracle, len(args))+" ORDER BY id", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var b domain.Brand
+			if err := rows.Scan(&b.ID, &b.Name, &b.CompanyID); err != nil {
+				return nil, errors.Join(err, rows.Close())
+			}
+			brands = append(brands, b)
+		}
+		if err := errors.Join(rows.Err(), rows.Close()); err != nil {
+			return nil, err
+		}
+	}
+	return brands, nil
+}
+
+// Update overwrites name and company of an existing brand and bumps updated_at.
+// The row and its "brand.updated" outbox event are written in one transaction.
+// It returns domain.ErrNotFound if the brand does not exist.
+func (r *OracleBrandRepo) Update(ctx context.Context, b *domain.Brand) error {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return 0, err
+		return err
 	}
 
-	return rowsAffected, nil
+	// Lock the row and read the state before the change for the audit log.
+	before, err := scanBrand(tx.QueryRowContext(ctx, "SELECT id, name, company_id FROM brands WHERE id = :1 FOR UPDATE", b.ID))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	res, err := tx.ExecContext(ctx,
+		"UPDATE brands SET name = :1, company_id = :2, updated_at = SYSTIMESTAMP WHERE id = :3", b.Name, nullID(b.CompanyID), b.ID)
+	if err := affectedOne(res, err); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := insertEvent(ctx, tx, db.Oracle, "brand", b.ID, "brand.updated", before, b); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// Delete removes a brand. The row and its "brand.deleted" outbox event are
+// written in one transaction. It returns domain.ErrNotFound if no row matches.
+func (r *OracleBrandRepo) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before, err := scanBrand(tx.QueryRowContext(ctx, "SELECT id, name, company_id FROM brands WHERE id = :1 FOR UPDATE", id))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM brands WHERE id = :1", id)
+	if err := affectedOne(res, err); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := insertEvent(ctx, tx, db.Oracle, "brand", id, "brand.deleted", before, &domain.Brand{ID: id}); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
 }
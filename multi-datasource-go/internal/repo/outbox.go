@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"multi-datasource-go/internal/db"
+	"multi-datasource-go/internal/domain"
+	"multi-datasource-go/internal/outbox"
+)
+
+// insertEvent records a change event in the outbox table, inside the caller's transaction.
+// before is the entity as read in that transaction before the change (nil on create).
+func insertEvent(ctx context.Context, tx *sql.Tx, d db.Dialect, aggregateType string, id int64, eventType string, before, after any) error {
+	ev, err := newEvent(ctx, aggregateType, id, eventType, before, after)
+	if err != nil {
+		return err
+	}
+	q, args := outbox.InsertStmt(d, ev)
+	_, err = tx.ExecContext(ctx, q, args...)
+	return err
+}
+
+// newEvent builds the outbox event of a change with its audit facts: the
+// caller and request ID from ctx, and the state before the change.
+func newEvent(ctx context.Context, aggregateType string, id int64, eventType string, before, after any) (outbox.Event, error) {
+	ev, err := outbox.NewEvent(aggregateType, id, eventType, after)
+	if err != nil {
+		return outbox.Event{}, err
+	}
+	ev.Audit = &outbox.Audit{Actor: "anonymous", RequestID: domain.RequestIDFrom(ctx)}
+	if a, ok := domain.ActorFrom(ctx); ok {
+		ev.Audit.Actor = a.ID
+	}
+	if before != nil {
+		if ev.Audit.Before, err = json.Marshal(before); err != nil {
+			return outbox.Event{}, err
+		}
+	}
+	return ev, nil
+}
@@ -2,9 +2,13 @@ package repo
 
 import (
 	"context"
+	"errors"
 
+	"multi-datasource-go/internal/db"
 	"multi-datasource-go/internal/domain"
+	"multi-datasource-go/internal/outbox"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -21,12 +25,133 @@ func NewPGCompanyRepo(pool *pgxpool.Pool) *PGCompanyRepo {
 
 // Create inserts a new company record into the PostgreSQL 'companies' table.
 // The statement uses the RETURNING clause to fetch the newly generated ID directly from the database.
+// The row and its "company.created" outbox event are written in one transaction.
 // Context is used to enforce cancellation or timeout limits on the query.
 // Returns the generated company ID or an error if the operation fails.
 func (r *PGCompanyRepo) Create(ctx context.Context, c *domain.Company) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+
 	var id int64
-	err := r.pool.QueryRow(ctx,
+	if err := tx.QueryRow(ctx,
 		"INSERT INTO companies (name) VALUES ($1) RETURNING id", c.Name).
-		Scan(&id)
-	return id, err
+		Scan(&id); err != nil {
+		return 0, errors.Join(err, tx.Rollback(ctx))
+	}
+	c.ID = id
+
+	// Record the change event in the same transaction
+	if err := insertPGEvent(ctx, tx, id, "company.created", nil, c); err != nil {
+		return 0, errors.Join(err, tx.Rollback(ctx))
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+// Get loads a company by ID. It returns domain.ErrNotFound if no row matches.
+func (r *PGCompanyRepo) Get(ctx context.Context, id int64) (*domain.Company, error) {
+	c := &domain.Company{}
+	err := r.pool.QueryRow(ctx, "SELECT id, name FROM companies WHERE id = $1", id).Scan(&c.ID, &c.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetMany loads the companies with the given IDs in one query (id = ANY($1)).
+func (r *PGCompanyRepo) GetMany(ctx context.Context, ids []int64) ([]domain.Company, error) {
+	rows, err := r.pool.Query(ctx, "SELECT id, name FROM companies WHERE id = ANY($1)", ids)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Company, error) {
+		var c domain.Company
+		err := row.Scan(&c.ID, &c.Name)
+		return c, err
+	})
+}
+
+// Update renames an existing company and bumps updated_at.
+// The row and its "company.updated" outbox event are written in one transaction.
+// It returns domain.ErrNotFound if the company does not exist.
+func (r *PGCompanyRepo) Update(ctx context.Context, c *domain.Company) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before := &domain.Company{}
+	err = tx.QueryRow(ctx, "SELECT id, name FROM companies WHERE id = $1 FOR UPDATE", c.ID).Scan(&before.ID, &before.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return errors.Join(err, tx.Rollback(ctx))
+	}
+
+	tag, err := tx.Exec(ctx, "UPDATE companies SET name = $1, updated_at = now() WHERE id = $2", c.Name, c.ID)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return errors.Join(err, tx.Rollback(ctx))
+	}
+
+	if err := insertPGEvent(ctx, tx, c.ID, "company.updated", before, c); err != nil {
+		return errors.Join(err, tx.Rollback(ctx))
+	}
+	return tx.Commit(ctx)
+}
+
+// Delete removes a company. The row and its "company.deleted" outbox event are
+// written in one transaction. It returns domain.ErrNotFound if no row matches.
+func (r *PGCompanyRepo) Delete(ctx context.Context, id int64) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before := &domain.Company{}
+	err = tx.QueryRow(ctx, "SELECT id, name FROM companies WHERE id = $1 FOR UPDATE", id).Scan(&before.ID, &before.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return errors.Join(err, tx.Rollback(ctx))
+	}
+
+	tag, err := tx.Exec(ctx, "DELETE FROM companies WHERE id = $1", id)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = domain.ErrNotFound
+	}
+	if err != nil {
+		return errors.Join(err, tx.Rollback(ctx))
+	}
+
+	if err := insertPGEvent(ctx, tx, id, "company.deleted", before, &domain.Company{ID: id}); err != nil {
+		return errors.Join(err, tx.Rollback(ctx))
+	}
+	return tx.Commit(ctx)
+}
+
+// insertPGEvent records a company change event in the outbox table, inside the pgx transaction.
+func insertPGEvent(ctx context.Context, tx pgx.Tx, id int64, eventType string, before, after *domain.Company) error {
+	var b any
+	if before != nil { // keep a nil *domain.Company from being recorded as null
+		b = before
+	}
+	ev, err := newEvent(ctx, "company", id, eventType, b, after)
+	if err != nil {
+		return err
+	}
+	q, args := outbox.InsertStmt(db.Postgres, ev)
+	_, err = tx.Exec(ctx, q, args...)
+	return err
 }
@@ -0,0 +1,156 @@
+package repo_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"testing"
+
+	"multi-datasource-go/internal/db/dbtest"
+	"multi-datasource-go/internal/domain"
+	"multi-datasource-go/internal/outbox"
+	"multi-datasource-go/internal/repo"
+	"multi-datasource-go/internal/repo/repotest"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestMySQLUserRepo(t *testing.T) {
+	repotest.UserRepoContract(t, func(t *testing.T) domain.UserRepo {
+		return repo.NewMySQLUserRepo(dbtest.Open(t))
+	})
+}
+
+func TestOracleBrandRepo(t *testing.T) {
+	repotest.BrandRepoContract(t, func(t *testing.T) domain.BrandRepo {
+		return repo.NewOracleBrandRepo(dbtest.Open(t))
+	})
+}
+
+func TestSQLiteUserRepo(t *testing.T) {
+	repotest.UserRepoContract(t, func(t *testing.T) domain.UserRepo {
+		return repo.NewSQLiteUserRepo(dbtest.Open(t))
+	})
+}
+
+func TestSQLiteCompanyRepo(t *testing.T) {
+	repotest.CompanyRepoContract(t, func(t *testing.T) domain.CompanyRepo {
+		return repo.NewSQLiteCompanyRepo(dbtest.Open(t))
+	})
+}
+
+func TestSQLiteBrandRepo(t *testing.T) {
+	repotest.BrandRepoContract(t, func(t *testing.T) domain.BrandRepo {
+		return repo.NewSQLiteBrandRepo(dbtest.Open(t))
+	})
+}
+
+// TestPGCompanyRepo needs a real PostgreSQL database, as pgx has no
+// embedded stand-in. Set TEST_POSTGRES_DSN to run it; its tables are emptied.
+func TestPGCompanyRepo(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN not set")
+	}
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	repotest.CompanyRepoContract(t, func(t *testing.T) domain.CompanyRepo {
+		if _, err := pool.Exec(ctx, `
+			CREATE TABLE IF NOT EXISTS companies (
+				id BIGSERIAL PRIMARY KEY,
+				name TEXT NOT NULL,
+				updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+			);
+			CREATE TABLE IF NOT EXISTS outbox_events (
+				id BIGSERIAL PRIMARY KEY,
+				aggregate_type TEXT NOT NULL,
+				aggregate_id BIGINT NOT NULL,
+				event_type TEXT NOT NULL,
+				payload JSONB NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL,
+				published_at TIMESTAMPTZ NULL,
+				dead_at TIMESTAMPTZ NULL,
+				audit JSONB NULL,
+				attempts INT NOT NULL DEFAULT 0,
+				last_error TEXT NULL
+			);
+			TRUNCATE companies, outbox_events`); err != nil {
+			t.Fatal(err)
+		}
+		return repo.NewPGCompanyRepo(pool)
+	})
+}
+
+// TestRepositoriesWriteOutboxEvents checks that every change is recorded in
+// the outbox table in the same transaction as the row, with its audit facts.
+func TestRepositoriesWriteOutboxEvents(t *testing.T) {
+	ctx := domain.WithRequestID(domain.WithActor(context.Background(), domain.Actor{ID: "ci"}), "req-1")
+	dbx := dbtest.Open(t)
+	users := repo.NewMySQLUserRepo(dbx)
+	brands := repo.NewOracleBrandRepo(dbx)
+
+	u := domain.User{Name: "Ada", LastName: "Lovelace"}
+	if _, err := users.Create(ctx, &u); err != nil {
+		t.Fatal(err)
+	}
+	u.LastName = "Byron"
+	if err := users.Update(ctx, &u); err != nil {
+		t.Fatal(err)
+	}
+	b := domain.Brand{Name: "Acme"}
+	if _, err := brands.Create(ctx, &b); err != nil {
+		t.Fatal(err)
+	}
+	if err := brands.Delete(ctx, b.ID); err != nil {
+		t.Fatal(err)
+	}
+	// A failed change must not leave an event behind.
+	if err := brands.Delete(ctx, b.ID); err == nil {
+		t.Fatal("deleting a missing brand succeeded")
+	}
+
+	rows, err := dbx.QueryContext(ctx, "SELECT event_type, aggregate_id FROM outbox_events ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	type event struct {
+		typ string
+		id  int64
+	}
+	var got []event
+	for rows.Next() {
+		var e event
+		if err := rows.Scan(&e.typ, &e.id); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, e)
+	}
+	want := []event{{"user.created", u.ID}, {"user.updated", u.ID}, {"brand.created", b.ID}, {"brand.deleted", b.ID}}
+	if !slices.Equal(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+
+	// The update carries the row as it was before, read in its transaction.
+	var raw string
+	if err := dbx.QueryRowContext(ctx, "SELECT audit FROM outbox_events WHERE event_type = 'user.updated'").Scan(&raw); err != nil {
+		t.Fatal(err)
+	}
+	var a outbox.Audit
+	if err := json.Unmarshal([]byte(raw), &a); err != nil {
+		t.Fatal(err)
+	}
+	var before domain.User
+	if err := json.Unmarshal(a.Before, &before); err != nil {
+		t.Fatal(err)
+	}
+	if a.Actor != "ci" || a.RequestID != "req-1" || before.LastName != "Lovelace" {
+		t.Errorf("audit = %s, want actor ci, request req-1 and last name Lovelace before", raw)
+	}
+}
@@ -0,0 +1,401 @@
+// Package repotest holds the contract every domain repository implementation
+// must satisfy, whatever database it runs on. Implementation tests call the
+// suite of their entity with a constructor returning an empty repository:
+//
+//	func TestMySQLUserRepo(t *testing.T) {
+//		repotest.UserRepoContract(t, func(t *testing.T) domain.UserRepo {
+//			return repo.NewMySQLUserRepo(dbtest.Open(t))
+//		})
+//	}
+package repotest
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"multi-datasource-go/internal/domain"
+)
+
+// missingID is an ID no test creates.
+const missingID = 1 << 40
+
+// ref returns a company reference.
+func ref(id int64) *int64 { return &id }
+
+// canceled returns a context that is already canceled.
+func canceled() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+// UserRepoContract runs the user repository contract against repositories
+// created by newRepo, which must return an empty repository on every call.
+func UserRepoContract(t *testing.T, newRepo func(t *testing.T) domain.UserRepo) {
+	ctx := context.Background()
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		r := newRepo(t)
+		in := domain.User{Name: "Ada", LastName: "Lovelace", CompanyID: ref(7)}
+		id, err := r.Create(ctx, &in)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if id <= 0 || in.ID != id {
+			t.Fatalf("Create returned id %d and set ID %d, want the same positive ID", id, in.ID)
+		}
+		got, err := r.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		assertUser(t, *got, in)
+
+		id2, err := r.Create(ctx, &domain.User{Name: "Alan", LastName: "Turing"})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if id2 == id {
+			t.Fatalf("Create returned id %d twice", id)
+		}
+		got, err = r.Get(ctx, id2)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if got.CompanyID != nil {
+			t.Errorf("CompanyID = %d, want nil", *got.CompanyID)
+		}
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		if _, err := newRepo(t).Get(ctx, missingID); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Get = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("GetMany", func(t *testing.T) {
+		r := newRepo(t)
+		var want []domain.User
+		for _, name := range []string{"a", "b", "c"} {
+			u := domain.User{Name: name, LastName: name}
+			if _, err := r.Create(ctx, &u); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			want = append(want, u)
+		}
+		got, err := r.GetMany(ctx, []int64{want[2].ID, missingID, want[0].ID})
+		if err != nil {
+			t.Fatalf("GetMany: %v", err)
+		}
+		sortByID(got, func(u domain.User) int64 { return u.ID })
+		if len(got) != 2 {
+			t.Fatalf("GetMany returned %d users, want 2", len(got))
+		}
+		assertUser(t, got[0], want[0])
+		assertUser(t, got[1], want[2])
+
+		if got, err := r.GetMany(ctx, nil); err != nil || len(got) != 0 {
+			t.Fatalf("GetMany(nil) = %v, %v; want no users", got, err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		r := newRepo(t)
+		u := domain.User{Name: "Ada", LastName: "Byron"}
+		if _, err := r.Create(ctx, &u); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		u.LastName, u.CompanyID = "Lovelace", ref(3)
+		if err := r.Update(ctx, &u); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		// Writing unchanged values must not be mistaken for a missing row.
+		if err := r.Update(ctx, &u); err != nil {
+			t.Fatalf("Update without changes: %v", err)
+		}
+		got, err := r.Get(ctx, u.ID)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		assertUser(t, *got, u)
+	})
+
+	t.Run("UpdateMissing", func(t *testing.T) {
+		err := newRepo(t).Update(ctx, &domain.User{ID: missingID, Name: "a", LastName: "b"})
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Update = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		r := newRepo(t)
+		u := domain.User{Name: "Ada", LastName: "Lovelace"}
+		if _, err := r.Create(ctx, &u); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := r.Delete(ctx, u.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := r.Get(ctx, u.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Get after Delete = %v, want ErrNotFound", err)
+		}
+		if err := r.Delete(ctx, u.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("second Delete = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		if _, err := newRepo(t).Create(canceled(), &domain.User{Name: "a", LastName: "b"}); err == nil {
+			t.Fatal("Create with a canceled context succeeded")
+		}
+	})
+}
+
+// CompanyRepoContract runs the company repository contract against repositories
+// created by newRepo, which must return an empty repository on every call.
+func CompanyRepoContract(t *testing.T, newRepo func(t *testing.T) domain.CompanyRepo) {
+	ctx := context.Background()
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		r := newRepo(t)
+		in := domain.Company{Name: "Acme"}
+		id, err := r.Create(ctx, &in)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if id <= 0 || in.ID != id {
+			t.Fatalf("Create returned id %d and set ID %d, want the same positive ID", id, in.ID)
+		}
+		got, err := r.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if *got != in {
+			t.Fatalf("Get = %+v, want %+v", *got, in)
+		}
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		if _, err := newRepo(t).Get(ctx, missingID); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Get = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("GetMany", func(t *testing.T) {
+		r := newRepo(t)
+		var want []domain.Company
+		for _, name := range []string{"a", "b", "c"} {
+			c := domain.Company{Name: name}
+			if _, err := r.Create(ctx, &c); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			want = append(want, c)
+		}
+		got, err := r.GetMany(ctx, []int64{want[2].ID, missingID, want[0].ID})
+		if err != nil {
+			t.Fatalf("GetMany: %v", err)
+		}
+		sortByID(got, func(c domain.Company) int64 { return c.ID })
+		if !slices.Equal(got, []domain.Company{want[0], want[2]}) {
+			t.Fatalf("GetMany = %+v, want %+v", got, []domain.Company{want[0], want[2]})
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		r := newRepo(t)
+		c := domain.Company{Name: "Acme"}
+		if _, err := r.Create(ctx, &c); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		c.Name = "Acme Corp"
+		if err := r.Update(ctx, &c); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if err := r.Update(ctx, &c); err != nil {
+			t.Fatalf("Update without changes: %v", err)
+		}
+		got, err := r.Get(ctx, c.ID)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if *got != c {
+			t.Fatalf("Get = %+v, want %+v", *got, c)
+		}
+	})
+
+	t.Run("UpdateMissing", func(t *testing.T) {
+		if err := newRepo(t).Update(ctx, &domain.Company{ID: missingID, Name: "a"}); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Update = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		r := newRepo(t)
+		c := domain.Company{Name: "Acme"}
+		if _, err := r.Create(ctx, &c); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := r.Delete(ctx, c.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := r.Get(ctx, c.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Get after Delete = %v, want ErrNotFound", err)
+		}
+		if err := r.Delete(ctx, c.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("second Delete = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		if _, err := newRepo(t).Create(canceled(), &domain.Company{Name: "a"}); err == nil {
+			t.Fatal("Create with a canceled context succeeded")
+		}
+	})
+}
+
+// BrandRepoContract runs the brand repository contract against repositories
+// created by newRepo, which must return an empty repository on every call.
+func BrandRepoContract(t *testing.T, newRepo func(t *testing.T) domain.BrandRepo) {
+	ctx := context.Background()
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		r := newRepo(t)
+		in := domain.Brand{Name: "Acme", CompanyID: ref(7)}
+		id, err := r.Create(ctx, &in)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if id <= 0 || in.ID != id {
+			t.Fatalf("Create returned id %d and set ID %d, want the same positive ID", id, in.ID)
+		}
+		got, err := r.Get(ctx, id)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		assertBrand(t, *got, in)
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		if _, err := newRepo(t).Get(ctx, missingID); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Get = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("ListByCompanies", func(t *testing.T) {
+		r := newRepo(t)
+		var brands []domain.Brand
+		for _, b := range []domain.Brand{
+			{Name: "a", CompanyID: ref(1)},
+			{Name: "b", CompanyID: ref(2)},
+			{Name: "c", CompanyID: ref(1)},
+			{Name: "d"},
+		} {
+			if _, err := r.Create(ctx, &b); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			brands = append(brands, b)
+		}
+		got, err := r.ListByCompanies(ctx, []int64{1, missingID})
+		if err != nil {
+			t.Fatalf("ListByCompanies: %v", err)
+		}
+		sortByID(got, func(b domain.Brand) int64 { return b.ID })
+		if len(got) != 2 {
+			t.Fatalf("ListByCompanies returned %d brands, want 2", len(got))
+		}
+		assertBrand(t, got[0], brands[0])
+		assertBrand(t, got[1], brands[2])
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		r := newRepo(t)
+		b := domain.Brand{Name: "Acme", CompanyID: ref(1)}
+		if _, err := r.Create(ctx, &b); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		b.Name, b.CompanyID = "Acme Inc", nil
+		if err := r.Update(ctx, &b); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if err := r.Update(ctx, &b); err != nil {
+			t.Fatalf("Update without changes: %v", err)
+		}
+		got, err := r.Get(ctx, b.ID)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		assertBrand(t, *got, b)
+	})
+
+	t.Run("UpdateMissing", func(t *testing.T) {
+		if err := newRepo(t).Update(ctx, &domain.Brand{ID: missingID, Name: "a"}); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Update = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		r := newRepo(t)
+		b := domain.Brand{Name: "Acme"}
+		if _, err := r.Create(ctx, &b); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := r.Delete(ctx, b.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if _, err := r.Get(ctx, b.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("Get after Delete = %v, want ErrNotFound", err)
+		}
+		if err := r.Delete(ctx, b.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Fatalf("second Delete = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("CanceledContext", func(t *testing.T) {
+		if _, err := newRepo(t).Create(canceled(), &domain.Brand{Name: "a"}); err == nil {
+			t.Fatal("Create with a canceled context succeeded")
+		}
+	})
+}
+
+// assertUser and assertBrand compare entities by value, including the
+// company the optional reference points at.
+func assertUser(t *testing.T, got, want domain.User) {
+	t.Helper()
+	if got.ID != want.ID || got.Name != want.Name || got.LastName != want.LastName || !sameRef(got.CompanyID, want.CompanyID) {
+		t.Fatalf("user = %s, want %s", formatUser(got), formatUser(want))
+	}
+}
+
+func assertBrand(t *testing.T, got, want domain.Brand) {
+	t.Helper()
+	if got.ID != want.ID || got.Name != want.Name || !sameRef(got.CompanyID, want.CompanyID) {
+		t.Fatalf("brand = %s, want %s", formatBrand(got), formatBrand(want))
+	}
+}
+
+func sameRef(a, b *int64) bool {
+	return (a == nil) == (b == nil) && (a == nil || *a == *b)
+}
+
+func formatRef(r *int64) string {
+	if r == nil {
+		return "<nil>"
+	}
+	return fmt.Sprint(*r)
+}
+
+func formatUser(u domain.User) string {
+	return fmt.Sprintf("{ID:%d Name:%q LastName:%q CompanyID:%s}", u.ID, u.Name, u.LastName, formatRef(u.CompanyID))
+}
+
+func formatBrand(b domain.Brand) string {
+	return fmt.Sprintf("{ID:%d Name:%q CompanyID:%s}", b.ID, b.Name, formatRef(b.CompanyID))
+}
+
+// sortByID orders entities by ID; repositories return sets in no particular order.
+func sortByID[T any](s []T, id func(T) int64) {
+	slices.SortFunc(s, func(a, b T) int { return cmp.Compare(id(a), id(b)) })
+}
@@ -0,0 +1,45 @@
+package repo
+
+import (
+	"database/sql"
+	"errors"
+
+	"multi-datasource-go/internal/domain"
+)
+
+// scanUser reads a row of (id, name, last_name, company_id).
+// It returns domain.ErrNotFound if the query matched no row.
+func scanUser(row *sql.Row) (*domain.User, error) {
+	u := &domain.User{}
+	if err := notFound(row.Scan(&u.ID, &u.Name, &u.LastName, &u.CompanyID)); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// scanCompany reads a row of (id, name).
+// It returns domain.ErrNotFound if the query matched no row.
+func scanCompany(row *sql.Row) (*domain.Company, error) {
+	c := &domain.Company{}
+	if err := notFound(row.Scan(&c.ID, &c.Name)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// scanBrand reads a row of (id, name, company_id).
+// It returns domain.ErrNotFound if the query matched no row.
+func scanBrand(row *sql.Row) (*domain.Brand, error) {
+	b := &domain.Brand{}
+	if err := notFound(row.Scan(&b.ID, &b.Name, &b.CompanyID)); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrNotFound
+	}
+	return err
+}
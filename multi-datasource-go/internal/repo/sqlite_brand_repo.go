@@ -0,0 +1,126 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"multi-datasource-go/internal/db"
+	"multi-datasource-go/internal/domain"
+)
+
+// SQLiteBrandRepo provides the SQLite-based implementation of the BrandRepo interface.
+// It lets brands live in a local database file during development.
+type SQLiteBrandRepo struct {
+	db *sql.DB // Connection pool to the SQLite database file
+}
+
+// NewSQLiteBrandRepo creates a new SQLiteBrandRepo instance.
+// The caller provides a *sql.DB opened with db.OpenSQLite.
+func NewSQLiteBrandRepo(db *sql.DB) *SQLiteBrandRepo {
+	return &SQLiteBrandRepo{db: db}
+}
+
+// Create inserts a new brand record into the SQLite 'brands' table.
+// The row and its "brand.created" outbox event are written in one transaction.
+// Returns the generated brand ID or an error if the operation fails.
+func (r *SQLiteBrandRepo) Create(ctx context.Context, b *domain.Brand) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := db.SQLite.InsertID(ctx, tx,
+		"INSERT INTO brands (name, company_id) VALUES (?, ?)", b.Name, nullID(b.CompanyID))
+	if err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+	b.ID = id
+
+	// Record the change event in the same transaction
+	if err := insertEvent(ctx, tx, db.SQLite, "brand", id, "brand.created", nil, b); err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+
+	return id, tx.Commit()
+}
+
+// Get loads a brand by ID. It returns domain.ErrNotFound if no row matches.
+func (r *SQLiteBrandRepo) Get(ctx context.Context, id int64) (*domain.Brand, error) {
+	return scanBrand(r.db.QueryRowContext(ctx, "SELECT id, name, company_id FROM brands WHERE id = ?", id))
+}
+
+// ListByCompanies loads the brands of the given companies using one IN query per 1000 companies.
+func (r *SQLiteBrandRepo) ListByCompanies(ctx context.Context, companyIDs []int64) ([]domain.Brand, error) {
+	var brands []domain.Brand
+	for _, args := range batches(companyIDs) {
+		rows, err := r.db.QueryContext(ctx,
+			"SELECT id, name, company_id FROM brands WHERE company_id IN "+inList(db.SQLite, len(args))+" ORDER BY id", args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var b domain.Brand
+			if err := rows.Scan(&b.ID, &b.Name, &b.CompanyID); err != nil {
+				return nil, errors.Join(err, rows.Close())
+			}
+			brands = append(brands, b)
+		}
+		if err := errors.Join(rows.Err(), rows.Close()); err != nil {
+			return nil, err
+		}
+	}
+	return brands, nil
+}
+
+// Update overwrites name and company of an existing brand and bumps updated_at.
+// The row and its "brand.updated" outbox event are written in one transaction.
+// It returns domain.ErrNotFound if the brand does not exist.
+func (r *SQLiteBrandRepo) Update(ctx context.Context, b *domain.Brand) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before, err := scanBrand(tx.QueryRowContext(ctx, "SELECT id, name, company_id FROM brands WHERE id = ?", b.ID))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	res, err := tx.ExecContext(ctx,
+		"UPDATE brands SET name = ?, company_id = ?, updated_at = "+db.SQLiteNow+" WHERE id = ?", b.Name, nullID(b.CompanyID), b.ID)
+	if err := affectedOne(res, err); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := insertEvent(ctx, tx, db.SQLite, "brand", b.ID, "brand.updated", before, b); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// Delete removes a brand. The row and its "brand.deleted" outbox event are
+// written in one transaction. It returns domain.ErrNotFound if no row matches.
+func (r *SQLiteBrandRepo) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before, err := scanBrand(tx.QueryRowContext(ctx, "SELECT id, name, company_id FROM brands WHERE id = ?", id))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM brands WHERE id = ?", id)
+	if err := affectedOne(res, err); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := insertEvent(ctx, tx, db.SQLite, "brand", id, "brand.deleted", before, &domain.Brand{ID: id}); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
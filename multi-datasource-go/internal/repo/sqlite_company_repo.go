@@ -0,0 +1,125 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"multi-datasource-go/internal/db"
+	"multi-datasource-go/internal/domain"
+)
+
+// SQLiteCompanyRepo provides the SQLite-based implementation of the CompanyRepo interface.
+// It lets companies live in a local database file during development.
+type SQLiteCompanyRepo struct {
+	db *sql.DB // Connection pool to the SQLite database file
+}
+
+// NewSQLiteCompanyRepo creates a new SQLiteCompanyRepo instance.
+// The caller provides a *sql.DB opened with db.OpenSQLite.
+func NewSQLiteCompanyRepo(db *sql.DB) *SQLiteCompanyRepo {
+	return &SQLiteCompanyRepo{db: db}
+}
+
+// Create inserts a new company record into the SQLite 'companies' table.
+// The row and its "company.created" outbox event are written in one transaction.
+// Returns the generated company ID or an error if the operation fails.
+func (r *SQLiteCompanyRepo) Create(ctx context.Context, c *domain.Company) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := db.SQLite.InsertID(ctx, tx, "INSERT INTO companies (name) VALUES (?)", c.Name)
+	if err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+	c.ID = id
+
+	// Record the change event in the same transaction
+	if err := insertEvent(ctx, tx, db.SQLite, "company", id, "company.created", nil, c); err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+
+	return id, tx.Commit()
+}
+
+// Get loads a company by ID. It returns domain.ErrNotFound if no row matches.
+func (r *SQLiteCompanyRepo) Get(ctx context.Context, id int64) (*domain.Company, error) {
+	return scanCompany(r.db.QueryRowContext(ctx, "SELECT id, name FROM companies WHERE id = ?", id))
+}
+
+// GetMany loads the companies with the given IDs using one IN query per 1000 IDs.
+func (r *SQLiteCompanyRepo) GetMany(ctx context.Context, ids []int64) ([]domain.Company, error) {
+	var companies []domain.Company
+	for _, args := range batches(ids) {
+		rows, err := r.db.QueryContext(ctx,
+			"SELECT id, name FROM companies WHERE id IN "+inList(db.SQLite, len(args)), args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var c domain.Company
+			if err := rows.Scan(&c.ID, &c.Name); err != nil {
+				return nil, errors.Join(err, rows.Close())
+			}
+			companies = append(companies, c)
+		}
+		if err := errors.Join(rows.Err(), rows.Close()); err != nil {
+			return nil, err
+		}
+	}
+	return companies, nil
+}
+
+// Update renames an existing company and bumps updated_at.
+// The row and its "company.updated" outbox event are written in one transaction.
+// It returns domain.ErrNotFound if the company does not exist.
+func (r *SQLiteCompanyRepo) Update(ctx context.Context, c *domain.Company) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before, err := scanCompany(tx.QueryRowContext(ctx, "SELECT id, name FROM companies WHERE id = ?", c.ID))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	res, err := tx.ExecContext(ctx,
+		"UPDATE companies SET name = ?, updated_at = "+db.SQLiteNow+" WHERE id = ?", c.Name, c.ID)
+	if err := affectedOne(res, err); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := insertEvent(ctx, tx, db.SQLite, "company", c.ID, "company.updated", before, c); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// Delete removes a company. The row and its "company.deleted" outbox event are
+// written in one transaction. It returns domain.ErrNotFound if no row matches.
+func (r *SQLiteCompanyRepo) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before, err := scanCompany(tx.QueryRowContext(ctx, "SELECT id, name FROM companies WHERE id = ?", id))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM companies WHERE id = ?", id)
+	if err := affectedOne(res, err); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := insertEvent(ctx, tx, db.SQLite, "company", id, "company.deleted", before, &domain.Company{ID: id}); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,129 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"multi-datasource-go/internal/db"
+	"multi-datasource-go/internal/domain"
+)
+
+// SQLiteUserRepo provides the SQLite-based implementation of the UserRepo interface.
+// It lets users live in a local database file during development.
+type SQLiteUserRepo struct {
+	db *sql.DB // Connection pool to the SQLite database file
+}
+
+// NewSQLiteUserRepo creates a new SQLiteUserRepo instance.
+// The caller provides a *sql.DB opened with db.OpenSQLite.
+func NewSQLiteUserRepo(db *sql.DB) *SQLiteUserRepo {
+	return &SQLiteUserRepo{db: db}
+}
+
+// Create inserts a new user record into the SQLite 'users' table.
+// The row and its "user.created" outbox event are written in one transaction.
+// Returns the newly inserted record ID, or an error if the insert fails.
+func (r *SQLiteUserRepo) Create(ctx context.Context, u *domain.User) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// The ROWID alias "id" is generated by SQLite and reported by LastInsertId
+	id, err := db.SQLite.InsertID(ctx, tx,
+		"INSERT INTO users (name, last_name, company_id) VALUES (?, ?, ?)", u.Name, u.LastName, nullID(u.CompanyID))
+	if err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+	u.ID = id
+
+	// Record the change event in the same transaction
+	if err := insertEvent(ctx, tx, db.SQLite, "user", id, "user.created", nil, u); err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+
+	return id, tx.Commit()
+}
+
+// Get loads a user by ID. It returns domain.ErrNotFound if no row matches.
+func (r *SQLiteUserRepo) Get(ctx context.Context, id int64) (*domain.User, error) {
+	return scanUser(r.db.QueryRowContext(ctx, "SELECT id, name, last_name, company_id FROM users WHERE id = ?", id))
+}
+
+// GetMany loads the users with the given IDs using one IN query per 1000 IDs.
+func (r *SQLiteUserRepo) GetMany(ctx context.Context, ids []int64) ([]domain.User, error) {
+	var users []domain.User
+	for _, args := range batches(ids) {
+		rows, err := r.db.QueryContext(ctx,
+			"SELECT id, name, last_name, company_id FROM users WHERE id IN "+inList(db.SQLite, len(args)), args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var u domain.User
+			if err := rows.Scan(&u.ID, &u.Name, &u.LastName, &u.CompanyID); err != nil {
+				return nil, errors.Join(err, rows.Close())
+			}
+			users = append(users, u)
+		}
+		if err := errors.Join(rows.Err(), rows.Close()); err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+// Update overwrites name, last name and company of an existing user and bumps updated_at.
+// The row and its "user.updated" outbox event are written in one transaction.
+// It returns domain.ErrNotFound if the user does not exist.
+func (r *SQLiteUserRepo) Update(ctx context.Context, u *domain.User) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before, err := scanUser(tx.QueryRowContext(ctx, "SELECT id, name, last_name, company_id FROM users WHERE id = ?", u.ID))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	// SQLite reports matched rows, so an update that keeps all values still affects one row.
+	res, err := tx.ExecContext(ctx,
+		"UPDATE users SET name = ?, last_name = ?, company_id = ?, updated_at = "+db.SQLiteNow+" WHERE id = ?",
+		u.Name, u.LastName, nullID(u.CompanyID), u.ID)
+	if err := affectedOne(res, err); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := insertEvent(ctx, tx, db.SQLite, "user", u.ID, "user.updated", before, u); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// Delete removes a user. The row and its "user.deleted" outbox event are
+// written in one transaction. It returns domain.ErrNotFound if no row matches.
+func (r *SQLiteUserRepo) Delete(ctx context.Context, id int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Lock the row and read the state before the change for the audit log.
+	before, err := scanUser(tx.QueryRowContext(ctx, "SELECT id, name, last_name, company_id FROM users WHERE id = ?", id))
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	res, err := tx.ExecContext(ctx, "DELETE FROM users WHERE id = ?", id)
+	if err := affectedOne(res, err); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	if err := insertEvent(ctx, tx, db.SQLite, "user", id, "user.deleted", before, &domain.User{ID: id}); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
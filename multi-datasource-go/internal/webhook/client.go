@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// blocked lists the networks deliveries never connect to, besides the loopback,
+// private, link-local, multicast and unspecified addresses netip recognizes:
+// subscriber URLs are chosen by API callers, who must not reach the services
+// or the cloud metadata endpoint behind the server through them.
+var blocked = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT, home of some metadata endpoints
+}
+
+// NewClient returns the HTTP client of the Dispatcher. It refuses to connect
+// to internal addresses, checked on every connection after DNS resolution, so
+// neither redirects nor DNS answers changing after a subscription was saved can
+// lead a delivery there. Addresses in allowed are reachable nevertheless, for
+// subscribers on the internal network. No proxy is used.
+func NewClient(timeout time.Duration, allowed []netip.Prefix) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if addr := ap.Addr().Unmap(); internal(addr) && !contains(allowed, addr) {
+				return fmt.Errorf("webhook: %s is an internal address (add it to webhooks.allowedNetworks to deliver to it)", addr)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // the proxy would connect on our behalf, past the check
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// internal reports whether addr belongs to the server's own networks.
+func internal(addr netip.Addr) bool {
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() ||
+		addr.IsUnspecified() || contains(blocked, addr)
+}
+
+func contains(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClientRefusesInternalAddresses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// The test server listens on loopback, which deliveries must not reach.
+	_, err := NewClient(time.Second, nil).Post(srv.URL, "application/json", strings.NewReader("{}"))
+	if err == nil || !strings.Contains(err.Error(), "internal address") {
+		t.Fatalf("err = %v, want the internal address refused", err)
+	}
+
+	// Unless its network is allowed.
+	resp, err := NewClient(time.Second, []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}).Post(srv.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("allowed network: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestInternal(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"127.0.0.1":       true,
+		"::1":             true,
+		"10.1.2.3":        true,
+		"172.16.0.1":      true,
+		"192.168.1.1":     true,
+		"169.254.169.254": true, // cloud metadata
+		"fe80::1":         true,
+		"fd00::1":         true,
+		"0.0.0.0":         true,
+		"100.100.100.200": true,
+		"8.8.8.8":         false,
+		"2001:4860::8888": false,
+	} {
+		if got := internal(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("internal(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"multi-datasource-go/internal/outbox"
+)
+
+// Signature headers sent with every delivery.
+// Subscribers verify a delivery by recomputing
+//
+//	hex(HMAC-SHA256(secret, timestamp + "." + body))
+//
+// and comparing it with the value after "sha256=" in SignatureHeader.
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// Sign computes the signature header value for a payload.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher fans out events to subscriptions and delivers them.
+//
+// As an outbox.Publisher it only persists one pending Delivery per matching
+// subscription; the HTTP calls happen in Run, so a slow subscriber never
+// blocks the outbox relay.
+type Dispatcher struct {
+	Store          *Store
+	Client         *http.Client
+	MaxAttempts    int           // attempts before a delivery is dead-lettered
+	InitialBackoff time.Duration // wait after the first failure; doubled on each further failure
+	MaxBackoff     time.Duration // upper bound for the wait between attempts
+	Interval       time.Duration // pause between polls for due deliveries
+	BatchSize      int           // max deliveries attempted per poll
+}
+
+// Publish enqueues the event for every active subscription matching its type.
+func (d *Dispatcher) Publish(ctx context.Context, e outbox.Event) error {
+	subs, err := d.Store.ListSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for i := range subs {
+		if !subs[i].Active || !subs[i].Matches(e.Type) {
+			continue
+		}
+		if _, err := d.Store.EnqueueDelivery(ctx, &Delivery{
+			SubscriptionID: subs[i].ID,
+			EventKey:       e.Key(),
+			EventType:      e.Type,
+			Payload:        string(body),
+			Status:         StatusPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}); err != nil {
+			return fmt.Errorf("enqueue delivery for subscription %d: %w", subs[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// Run attempts due deliveries until ctx is canceled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	if d.Interval <= 0 {
+		d.Interval = time.Second
+	}
+	if d.BatchSize <= 0 {
+		d.BatchSize = 50
+	}
+	t := time.NewTicker(d.Interval)
+	defer t.Stop()
+	for {
+		if err := d.deliverDue(ctx); err != nil {
+			log.Printf("webhooks: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// deliverDue attempts one batch of due deliveries. A delivery whose outcome
+// cannot be stored is logged and left for the next poll; the others go ahead.
+func (d *Dispatcher) deliverDue(ctx context.Context) error {
+	due, err := d.Store.DueDeliveries(ctx, time.Now().UTC(), d.BatchSize)
+	if err != nil {
+		return fmt.Errorf("read due deliveries: %w", err)
+	}
+	for i := range due {
+		if err := d.Attempt(ctx, &due[i]); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Printf("webhooks: %v", err)
+		}
+	}
+	return nil
+}
+
+// Attempt sends one delivery and stores the outcome. Failures schedule a retry
+// with exponential backoff, or move the delivery to the dead-letter list once
+// MaxAttempts is reached. The returned error only reports storage problems.
+func (d *Dispatcher) Attempt(ctx context.Context, dl *Delivery) error {
+	sub, err := d.Store.GetSubscription(ctx, dl.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("delivery %d: load subscription: %w", dl.ID, err)
+	}
+
+	code, sendErr := d.send(ctx, sub, dl)
+	now := time.Now().UTC()
+	dl.Attempts++
+	dl.LastStatusCode = code
+	dl.UpdatedAt = now
+	switch {
+	case sendErr == nil:
+		dl.Status = StatusSucceeded
+		dl.LastError = ""
+	case dl.Attempts >= d.MaxAttempts:
+		dl.Status = StatusDead
+		dl.LastError = truncate(sendErr.Error(), 1000)
+	default:
+		dl.Status = StatusPending
+		dl.LastError = truncate(sendErr.Error(), 1000)
+		dl.NextAttemptAt = now.Add(d.backoff(dl.Attempts))
+	}
+	if err := d.Store.UpdateDelivery(ctx, dl); err != nil {
+		return fmt.Errorf("delivery %d: store outcome: %w", dl.ID, err)
+	}
+	return nil
+}
+
+// Replay resets a delivery (typically a dead one) so it is attempted again right away
+// with a fresh retry budget.
+func (d *Dispatcher) Replay(ctx context.Context, id int64) (*Delivery, error) {
+	dl, err := d.Store.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	dl.Status = StatusPending
+	dl.Attempts = 0
+	dl.NextAttemptAt = now
+	dl.UpdatedAt = now
+	if err := d.Store.UpdateDelivery(ctx, dl); err != nil {
+		return nil, err
+	}
+	return dl, nil
+}
+
+// send POSTs the signed payload and returns the response status code.
+// Inactive subscriptions fail without a request, so their deliveries are retried
+// (and eventually dead-lettered) instead of being lost.
+func (d *Dispatcher) send(ctx context.Context, sub *Subscription, dl *Delivery) (int, error) {
+	if !sub.Active {
+		return 0, fmt.Errorf("subscription %d is inactive", sub.ID)
+	}
+	ts := time.Now().Unix()
+	body := []byte(dl.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, dl.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(dl.ID, 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(ts, 10))
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, ts, body))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the wait before the next attempt: InitialBackoff * 2^(attempts-1),
+// capped at MaxBackoff, with up to 20% random jitter to spread retries.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.InitialBackoff
+	for i := 1; i < attempts && wait < d.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > d.MaxBackoff {
+		wait = d.MaxBackoff
+	}
+	return wait + time.Duration(rand.Int64N(int64(wait)/5+1))
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+// Package webhook delivers entity change events to registered subscribers.
+//
+// Subscriptions register a URL for one or more event types. For every matching
+// event a Delivery is persisted, signed with the subscription secret
+// (HMAC-SHA256) and POSTed by the Dispatcher. Failed deliveries are retried
+// with exponential backoff and end up in the dead-letter list once the retry
+// budget is exhausted, from where they can be inspected and replayed.
+package webhook
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when a subscription or delivery does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Subscription registers a subscriber URL for a set of event types.
+type Subscription struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"eventTypes"`       // e.g. ["user.created", "brand.*"] or ["*"]
+	Secret     string    `json:"secret,omitempty"` // HMAC key; only returned when the subscription is created
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// Matches reports whether the subscription wants events of the given type.
+// Supported patterns are an exact type, "*" and a prefix wildcard such as "user.*".
+func (s *Subscription) Matches(eventType string) bool {
+	for _, p := range s.EventTypes {
+		switch {
+		case p == "*" || p == eventType:
+			return true
+		case strings.HasSuffix(p, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(p, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// Status is the state of a delivery.
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // waiting for its (next) attempt
+	StatusSucceeded Status = "succeeded" // subscriber answered 2xx
+	StatusDead      Status = "dead"      // retry budget exhausted (dead-letter list)
+)
+
+// Delivery is one event sent to one subscription.
+type Delivery struct {
+	ID             int64     `json:"id"`
+	SubscriptionID int64     `json:"subscriptionId"`
+	EventKey       string    `json:"eventKey"` // outbox event key (source:id), unique per subscription
+	EventType      string    `json:"eventType"`
+	Payload        string    `json:"payload"` // JSON body POSTed to the subscriber
+	Status         Status    `json:"status"`
+	Attempts       int       `json:"attempts"`
+	NextAttemptAt  time.Time `json:"nextAttemptAt"`
+	LastError      string    `json:"lastError,omitempty"`
+	LastStatusCode int       `json:"lastStatusCode,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
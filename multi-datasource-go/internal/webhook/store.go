@@ -0,0 +1,397 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"multi-datasource-go/internal/db"
+)
+
+// ddl holds the CREATE TABLE statements of the webhook tables per dialect.
+// Oracle statements are wrapped so that ORA-00955 (already exists) is ignored.
+var ddl = map[db.Dialect][]string{
+	db.MySQL: {`
+		CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			url VARCHAR(2000) NOT NULL,
+			event_types VARCHAR(1000) NOT NULL,
+			secret VARCHAR(200) NOT NULL,
+			active SMALLINT NOT NULL DEFAULT 1,
+			created_at TIMESTAMP(6) NOT NULL
+		)`, `
+		CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id BIGINT AUTO_INCREMENT PRIMARY KEY,
+			subscription_id BIGINT NOT NULL,
+			event_key VARCHAR(200) NOT NULL,
+			event_type VARCHAR(100) NOT NULL,
+			payload TEXT NOT NULL,
+			status VARCHAR(20) NOT NULL,
+			attempts INT NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMP(6) NOT NULL,
+			last_error VARCHAR(1000) NULL,
+			last_status_code INT NULL,
+			created_at TIMESTAMP(6) NOT NULL,
+			updated_at TIMESTAMP(6) NOT NULL,
+			UNIQUE KEY uq_webhook_delivery (subscription_id, event_key),
+			INDEX idx_webhook_due (status, next_attempt_at)
+		)`},
+	db.Postgres: {`
+		CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+			id BIGSERIAL PRIMARY KEY,
+			url TEXT NOT NULL,
+			event_types TEXT NOT NULL,
+			secret TEXT NOT NULL,
+			active SMALLINT NOT NULL DEFAULT 1,
+			created_at TIMESTAMPTZ NOT NULL
+		)`, `
+		CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id BIGSERIAL PRIMARY KEY,
+			subscription_id BIGINT NOT NULL,
+			event_key TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			status TEXT NOT NULL,
+			attempts INT NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMPTZ NOT NULL,
+			last_error TEXT NULL,
+			last_status_code INT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			UNIQUE (subscription_id, event_key)
+		)`, `
+		CREATE INDEX IF NOT EXISTS idx_webhook_due ON webhook_deliveries (status, next_attempt_at)`},
+	db.Oracle: {`
+		CREATE TABLE webhook_subscriptions (
+			id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+			url VARCHAR2(2000) NOT NULL,
+			event_types VARCHAR2(1000) NOT NULL,
+			secret VARCHAR2(200) NOT NULL,
+			active NUMBER(1) DEFAULT 1 NOT NULL,
+			created_at TIMESTAMP NOT NULL
+		)`, `
+		CREATE TABLE webhook_deliveries (
+			id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+			subscription_id NUMBER(19) NOT NULL,
+			event_key VARCHAR2(200) NOT NULL,
+			event_type VARCHAR2(100) NOT NULL,
+			payload CLOB NOT NULL,
+			status VARCHAR2(20) NOT NULL,
+			attempts NUMBER(10) DEFAULT 0 NOT NULL,
+			next_attempt_at TIMESTAMP NOT NULL,
+			last_error VARCHAR2(1000) NULL,
+			last_status_code NUMBER(5) NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			CONSTRAINT uq_webhook_delivery UNIQUE (subscription_id, event_key)
+		)`, `
+		CREATE INDEX idx_webhook_due ON webhook_deliveries (status, next_attempt_at)`},
+	db.SQLite: {`
+		CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			url TEXT NOT NULL,
+			event_types TEXT NOT NULL,
+			secret TEXT NOT NULL,
+			active INTEGER NOT NULL DEFAULT 1,
+			created_at TIMESTAMP NOT NULL
+		)`, `
+		CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			subscription_id INTEGER NOT NULL,
+			event_key TEXT NOT NULL,
+			event_type TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			status TEXT NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMP NOT NULL,
+			last_error TEXT NULL,
+			last_status_code INTEGER NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			UNIQUE (subscription_id, event_key)
+		)`, `
+		CREATE INDEX IF NOT EXISTS idx_webhook_due ON webhook_deliveries (status, next_attempt_at)`},
+}
+
+// Store persists subscriptions and deliveries in one of the configured datasources.
+type Store struct {
+	dialect db.Dialect
+	db      *sql.DB
+}
+
+// NewStore creates a Store on top of the given connection pool.
+func NewStore(d db.Dialect, dbx *sql.DB) *Store {
+	return &Store{dialect: d, db: dbx}
+}
+
+// EnsureTables creates the webhook tables if they don't already exist.
+func (s *Store) EnsureTables(ctx context.Context) error {
+	for _, stmt := range ddl[s.dialect] {
+		if s.dialect == db.Oracle {
+			stmt = fmt.Sprintf(`
+				BEGIN
+					EXECUTE IMMEDIATE '%s';
+				EXCEPTION
+					WHEN OTHERS THEN
+						IF SQLCODE != -955 THEN RAISE; END IF;
+				END;`, stmt)
+		}
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// p returns the n-th placeholder of the store dialect.
+func (s *Store) p(n int) string { return s.dialect.Placeholder(n) }
+
+// =====================================================
+// Subscriptions
+// =====================================================
+
+const subscriptionColumns = "id, url, event_types, secret, active, created_at"
+
+// CreateSubscription inserts a subscription and sets its ID.
+func (s *Store) CreateSubscription(ctx context.Context, sub *Subscription) error {
+	id, err := s.dialect.InsertID(ctx, s.db, fmt.Sprintf(
+		"INSERT INTO webhook_subscriptions (url, event_types, secret, active, created_at) VALUES (%s, %s, %s, %s, %s)",
+		s.p(1), s.p(2), s.p(3), s.p(4), s.p(5)),
+		sub.URL, strings.Join(sub.EventTypes, ","), sub.Secret, boolToInt(sub.Active), sub.CreatedAt)
+	if err != nil {
+		return err
+	}
+	sub.ID = id
+	return nil
+}
+
+// GetSubscription returns one subscription, or ErrNotFound.
+func (s *Store) GetSubscription(ctx context.Context, id int64) (*Subscription, error) {
+	row := s.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT %s FROM webhook_subscriptions WHERE id = %s", subscriptionColumns, s.p(1)), id)
+	sub, err := scanSubscription(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return sub, err
+}
+
+// ListSubscriptions returns all subscriptions ordered by ID.
+func (s *Store) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT %s FROM webhook_subscriptions ORDER BY id", subscriptionColumns))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Subscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *sub)
+	}
+	return out, rows.Err()
+}
+
+// UpdateSubscription replaces URL, event types and active flag of a subscription.
+// The secret is kept unless a new one is given.
+func (s *Store) UpdateSubscription(ctx context.Context, sub *Subscription) error {
+	res, err := s.db.ExecContext(ctx, fmt.Sprintf(
+		"UPDATE webhook_subscriptions SET url = %s, event_types = %s, active = %s, secret = COALESCE(%s, secret) WHERE id = %s",
+		s.p(1), s.p(2), s.p(3), s.p(4), s.p(5)),
+		sub.URL, strings.Join(sub.EventTypes, ","), boolToInt(sub.Active), nullString(sub.Secret), sub.ID)
+	if err := affectedOne(res, err); !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	// MySQL reports changed (not matched) rows, so an unchanged row also yields 0.
+	_, err = s.GetSubscription(ctx, sub.ID)
+	return err
+}
+
+// DeleteSubscription removes a subscription together with its deliveries.
+func (s *Store) DeleteSubscription(ctx context.Context, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM webhook_deliveries WHERE subscription_id = %s", s.p(1)), id); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	res, err := tx.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM webhook_subscriptions WHERE id = %s", s.p(1)), id)
+	if err := affectedOne(res, err); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+// =====================================================
+// Deliveries
+// =====================================================
+
+const deliveryColumns = `id, subscription_id, event_key, event_type, payload, status, attempts,
+	next_attempt_at, last_error, last_status_code, created_at, updated_at`
+
+// EnqueueDelivery stores a new pending delivery and sets its ID.
+// It returns false without error if the event was already enqueued for the
+// subscription, which makes re-published outbox events harmless.
+func (s *Store) EnqueueDelivery(ctx context.Context, d *Delivery) (bool, error) {
+	var n int
+	if err := s.db.QueryRowContext(ctx, fmt.Sprintf(
+		"SELECT COUNT(*) FROM webhook_deliveries WHERE subscription_id = %s AND event_key = %s", s.p(1), s.p(2)),
+		d.SubscriptionID, d.EventKey).Scan(&n); err != nil {
+		return false, err
+	}
+	if n > 0 {
+		return false, nil
+	}
+
+	id, err := s.dialect.InsertID(ctx, s.db, fmt.Sprintf(`INSERT INTO webhook_deliveries
+		(subscription_id, event_key, event_type, payload, status, attempts, next_attempt_at, created_at, updated_at)
+		VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)`,
+		s.p(1), s.p(2), s.p(3), s.p(4), s.p(5), s.p(6), s.p(7), s.p(8), s.p(9)),
+		d.SubscriptionID, d.EventKey, d.EventType, d.Payload, string(d.Status), d.Attempts,
+		d.NextAttemptAt, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		return false, err
+	}
+	d.ID = id
+	return true, nil
+}
+
+// GetDelivery returns one delivery, or ErrNotFound.
+func (s *Store) GetDelivery(ctx context.Context, id int64) (*Delivery, error) {
+	row := s.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT %s FROM webhook_deliveries WHERE id = %s", deliveryColumns, s.p(1)), id)
+	d, err := scanDelivery(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return d, err
+}
+
+// DueDeliveries returns pending deliveries whose next attempt is due, oldest first.
+func (s *Store) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]Delivery, error) {
+	return s.queryDeliveries(ctx, fmt.Sprintf(
+		"SELECT %s FROM webhook_deliveries WHERE status = %s AND next_attempt_at <= %s ORDER BY id %s",
+		deliveryColumns, s.p(1), s.p(2), s.dialect.Limit(limit)),
+		string(StatusPending), now)
+}
+
+// ListDeliveries returns the most recent deliveries, optionally filtered by status.
+func (s *Store) ListDeliveries(ctx context.Context, status Status, limit int) ([]Delivery, error) {
+	if status == "" {
+		return s.queryDeliveries(ctx, fmt.Sprintf(
+			"SELECT %s FROM webhook_deliveries ORDER BY id DESC %s", deliveryColumns, s.dialect.Limit(limit)))
+	}
+	return s.queryDeliveries(ctx, fmt.Sprintf(
+		"SELECT %s FROM webhook_deliveries WHERE status = %s ORDER BY id DESC %s",
+		deliveryColumns, s.p(1), s.dialect.Limit(limit)),
+		string(status))
+}
+
+// UpdateDelivery stores the outcome of a delivery attempt (or a replay).
+func (s *Store) UpdateDelivery(ctx context.Context, d *Delivery) error {
+	res, err := s.db.ExecContext(ctx, fmt.Sprintf(`UPDATE webhook_deliveries
+		SET status = %s, attempts = %s, next_attempt_at = %s, last_error = %s, last_status_code = %s, updated_at = %s
+		WHERE id = %s`,
+		s.p(1), s.p(2), s.p(3), s.p(4), s.p(5), s.p(6), s.p(7)),
+		string(d.Status), d.Attempts, d.NextAttemptAt, nullString(d.LastError), nullInt(d.LastStatusCode), d.UpdatedAt, d.ID)
+	return affectedOne(res, err)
+}
+
+// queryDeliveries runs a SELECT over deliveryColumns.
+func (s *Store) queryDeliveries(ctx context.Context, q string, args ...any) ([]Delivery, error) {
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *d)
+	}
+	return out, rows.Err()
+}
+
+// =====================================================
+// Helpers
+// =====================================================
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSubscription(sc scanner) (*Subscription, error) {
+	var (
+		sub    Subscription
+		types  string
+		active int
+	)
+	if err := sc.Scan(&sub.ID, &sub.URL, &types, &sub.Secret, &active, &sub.CreatedAt); err != nil {
+		return nil, err
+	}
+	sub.EventTypes = strings.Split(types, ",")
+	sub.Active = active != 0
+	sub.CreatedAt = sub.CreatedAt.UTC()
+	return &sub, nil
+}
+
+func scanDelivery(sc scanner) (*Delivery, error) {
+	var (
+		d          Delivery
+		status     string
+		lastErr    sql.NullString
+		lastStatus sql.NullInt64
+	)
+	if err := sc.Scan(&d.ID, &d.SubscriptionID, &d.EventKey, &d.EventType, &d.Payload, &status, &d.Attempts,
+		&d.NextAttemptAt, &lastErr, &lastStatus, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		return nil, err
+	}
+	d.Status = Status(status)
+	d.LastError = lastErr.String
+	d.LastStatusCode = int(lastStatus.Int64)
+	d.NextAttemptAt = d.NextAttemptAt.UTC()
+	d.CreatedAt = d.CreatedAt.UTC()
+	d.UpdatedAt = d.UpdatedAt.UTC()
+	return &d, nil
+}
+
+// affectedOne turns "no row affected" into ErrNotFound.
+func affectedOne(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func nullString(s string) sql.NullString { return sql.NullString{String: s, Valid: s != ""} }
+
+func nullInt(i int) sql.NullInt64 { return sql.NullInt64{Int64: int64(i), Valid: i != 0} }